@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+	"unicode/utf8"
+)
+
+// errPreconditionFailed is returned when the If-Match header doesn't match the current account.
+var errPreconditionFailed = errors.New("precondition failed")
+
+const preconditionFailedMessage = "If-Match doesn't match the current version of the account"
+
+// accountDiff computes the update to apply to an account, along with the changes to record for it.
+type accountDiff func(account entities.Account) (store.AccountUpdate, []entities.AccountChange)
+
+// updateAccount applies diff to the account and records the changes in its audit trail and in the audit
+// log, along with an AccountFrozen event when it froze the account, in a single unit of work. Nothing is
+// written when diff has no changes to make. The returned error is store.ErrAccountNotFound or
+// errPreconditionFailed for client errors.
+func (s API) updateAccount(ctx context.Context, accountId int64, ifMatch *string, diff accountDiff) (entities.Account, error) {
+	return s.updateAccountWith(ctx, accountId, ifMatch, diff, nil)
+}
+
+// updateAccountWith is updateAccount, calling afterUpdate in the unit of work once the account was changed,
+// or found unchanged when diff had no changes to make.
+func (s API) updateAccountWith(ctx context.Context, accountId int64, ifMatch *string, diff accountDiff, afterUpdate func(tx store.Tx, before, updated entities.Account) error) (entities.Account, error) {
+	ifVersion, ok := parseIfMatch(ifMatch)
+	if !ok {
+		return entities.Account{}, errPreconditionFailed
+	}
+
+	var updated entities.Account
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		account, err := tx.GetAccountById(ctx, accountId)
+		if err != nil {
+			return err
+		}
+		if !canAccessAccount(ctx, account) {
+			return store.ErrAccountNotFound
+		}
+		if ifVersion != nil && *ifVersion != account.Version {
+			return errPreconditionFailed
+		}
+
+		update, changes := diff(account)
+		if len(changes) == 0 {
+			updated = account
+			if afterUpdate != nil {
+				return afterUpdate(tx, account, updated)
+			}
+			return nil
+		}
+
+		updated, err = tx.UpdateAccount(ctx, accountId, &account.Version, update)
+		if err != nil {
+			return err
+		}
+		if err := tx.AddAccountChanges(ctx, changes); err != nil {
+			return err
+		}
+		if err := appendAuditEvent(ctx, tx, toAccount(account), toAccount(updated)); err != nil {
+			return err
+		}
+		if err := appendAccountFrozen(ctx, tx, account, updated); err != nil {
+			return err
+		}
+		if afterUpdate != nil {
+			return afterUpdate(tx, account, updated)
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// mergePatchAccount implements the JSON Merge Patch semantics of UpdateAccountRequest.
+func mergePatchAccount(patch UpdateAccountRequest, actor string) accountDiff {
+	return func(account entities.Account) (store.AccountUpdate, []entities.AccountChange) {
+		var update store.AccountUpdate
+		var changes []entities.AccountChange
+		change := func(field string, oldValue, newValue *string) {
+			changes = append(changes, entities.AccountChange{
+				AccountId: int64(account.Id),
+				Actor:     actor,
+				Field:     field,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+				ChangedAt: time.Now(),
+			})
+		}
+
+		if patch.Name != nil && *patch.Name != account.Name {
+			update.Name = patch.Name
+			change("name", &account.Name, patch.Name)
+		}
+
+		mergeMap := func(field string, current entities.StringMap, patch *map[string]*string) entities.StringMap {
+			if patch == nil {
+				return nil
+			}
+			merged := maps.Clone(current)
+			changed := false
+			for _, key := range slices.Sorted(maps.Keys(*patch)) {
+				oldValue, existed := current[key]
+				newValue := (*patch)[key]
+				switch {
+				case newValue == nil && existed:
+					delete(merged, key)
+					change(field+"."+key, &oldValue, nil)
+					changed = true
+				case newValue != nil && (!existed || oldValue != *newValue):
+					merged[key] = *newValue
+					if existed {
+						change(field+"."+key, &oldValue, newValue)
+					} else {
+						change(field+"."+key, nil, newValue)
+					}
+					changed = true
+				}
+			}
+			if !changed {
+				return nil
+			}
+			return merged
+		}
+		update.Metadata = mergeMap("metadata", account.Metadata, patch.Metadata)
+		update.Labels = mergeMap("labels", account.Labels, patch.Labels)
+
+		return update, changes
+	}
+}
+
+// setAccountStatus changes the status of an account.
+func setAccountStatus(status entities.AccountStatus, actor string) accountDiff {
+	return func(account entities.Account) (store.AccountUpdate, []entities.AccountChange) {
+		if account.Status == status {
+			return store.AccountUpdate{}, nil
+		}
+		oldValue, newValue := string(account.Status), string(status)
+		return store.AccountUpdate{Status: &status}, []entities.AccountChange{{
+			AccountId: int64(account.Id),
+			Actor:     actor,
+			Field:     "status",
+			OldValue:  &oldValue,
+			NewValue:  &newValue,
+			ChangedAt: time.Now(),
+		}}
+	}
+}
+
+// setAccountTier moves an account to another tier, changing its transfer limits.
+func setAccountTier(tier string, actor string) accountDiff {
+	return func(account entities.Account) (store.AccountUpdate, []entities.AccountChange) {
+		if account.Tier == tier {
+			return store.AccountUpdate{}, nil
+		}
+		oldValue := account.Tier
+		return store.AccountUpdate{Tier: &tier}, []entities.AccountChange{{
+			AccountId: int64(account.Id),
+			Actor:     actor,
+			Field:     "tier",
+			OldValue:  &oldValue,
+			NewValue:  &tier,
+			ChangedAt: time.Now(),
+		}}
+	}
+}
+
+// validateAccountName applies the constraints of the name in CreateAccountRequest.
+func validateAccountName(name string) error {
+	if length := utf8.RuneCountInString(name); length < 1 || length > 255 {
+		return fmt.Errorf("name must be between 1 and 255 characters long")
+	}
+	return nil
+}
+
+// minAmount is the minimum of the amounts in AddBalanceRequest and TransferRequest: a cent.
+const minAmount = 0.01
+
+// validateAmount applies the constraints of the amounts added or transferred, which the gRPC API doesn't
+// get from the spec.
+func validateAmount(amount float64) error {
+	if amount < minAmount {
+		return fmt.Errorf("amount must be at least %.2f", minAmount)
+	}
+	return nil
+}
+
+func validateMapKeys(field string, values *map[string]*string) error {
+	if values == nil {
+		return nil
+	}
+	for key := range *values {
+		if key == "" {
+			return fmt.Errorf("%s keys must not be empty", field)
+		}
+	}
+	return nil
+}
+
+// actorFromContext identifies who is making the request, for the audit trail.
+func actorFromContext(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	return "anonymous"
+}
@@ -2,119 +2,666 @@ package api
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/metrics"
+	"tiny-bank-api/pkg/notify"
+	"tiny-bank-api/pkg/risk"
+	"tiny-bank-api/pkg/sanctions"
 	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
 )
 
+// errAbortTx rolls back a unit of work whose outcome was already recorded as a client error response.
+var errAbortTx = errors.New("transaction aborted")
+
 type API struct {
 	logger *slog.Logger
 	store  store.Store
+	opts   Options
+}
+
+// Options are the optional features of the API.
+type Options struct {
+	// RiskEngine evaluates the transfers, they are all allowed when nil.
+	RiskEngine *risk.Engine
+	// ApprovalThreshold is the amount above which transfers wait for the approval of a second user, 0
+	// disables approvals.
+	ApprovalThreshold float64
+	// ApprovalTTL is how long transfers wait for approval before expiring.
+	ApprovalTTL time.Duration
+	// Screener screens the names of the accounts and transfers against the sanctions list, nothing is
+	// screened when nil.
+	Screener *sanctions.Screener
+	// StreamPollInterval is how often the event streams check for new events, 1s when not set.
+	StreamPollInterval time.Duration
+	// StreamHeartbeat is how often the idle event streams send a comment to keep the connection open, 15s
+	// when not set.
+	StreamHeartbeat time.Duration
+	// StreamsDone ends the event streams when closed, so the server can shut down without waiting for them.
+	StreamsDone <-chan struct{}
+	// EventNotifications wakes the event streams up as soon as events are committed, by any replica, rather
+	// than on their next poll.
+	EventNotifications *notify.Hub
+	// Metrics counts the accounts created and the transfers, nothing is counted when nil.
+	Metrics *metrics.Metrics
 }
 
-func NewAPI(logger *slog.Logger, store store.Store) *API {
+func NewAPI(logger *slog.Logger, store store.Store, opts Options) *API {
+	if opts.StreamPollInterval <= 0 {
+		opts.StreamPollInterval = time.Second
+	}
+	if opts.StreamHeartbeat <= 0 {
+		opts.StreamHeartbeat = 15 * time.Second
+	}
 	return &API{
 		logger: logger,
 		store:  store,
+		opts:   opts,
 	}
 }
 
 func (s API) GetAccounts(ctx context.Context, request GetAccountsRequestObject) (GetAccountsResponseObject, error) {
-	accounts, err := s.store.GetAccounts(ctx)
+	filter, ok := accountFilterFromContext(ctx)
+	if !ok {
+		return GetAccounts200JSONResponse{}, nil
+	}
+
+	accounts, err := s.store.GetAccounts(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	response := make(GetAccounts200JSONResponse, 0, len(accounts))
 	for _, acc := range accounts {
-		response = append(response, Account{
-			Id:        int64(acc.Id),
-			Name:      acc.Name,
-			Balance:   acc.Balance,
-			CreatedAt: acc.CreatedAt,
-			UpdatedAt: acc.UpdatedAt,
+		response = append(response, toAccount(acc))
+	}
+
+	return response, nil
+}
+
+func (s API) GetAccount(ctx context.Context, request GetAccountRequestObject) (GetAccountResponseObject, error) {
+	account, err := s.store.GetAccountById(ctx, request.AccountId)
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return GetAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		return nil, err
+	}
+	if !canAccessAccount(ctx, account) {
+		return GetAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+	}
+
+	return GetAccount200JSONResponse{
+		Body:    toAccount(account),
+		Headers: GetAccount200ResponseHeaders{ETag: accountETag(account.Version)},
+	}, nil
+}
+
+func (s API) UpdateAccount(ctx context.Context, request UpdateAccountRequestObject) (UpdateAccountResponseObject, error) {
+	if request.Body.Name != nil {
+		if err := validateAccountName(*request.Body.Name); err != nil {
+			return UpdateAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+		}
+	}
+	if err := validateMapKeys("metadata", request.Body.Metadata); err != nil {
+		return UpdateAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+	if err := validateMapKeys("labels", request.Body.Labels); err != nil {
+		return UpdateAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	diff := mergePatchAccount(*request.Body, actorFromContext(ctx))
+	var hits []entities.ScreeningHit
+	if request.Body.Name != nil {
+		var blocked bool
+		hits, blocked = s.screenNames(entities.ScreeningSubjectAccount, "updateAccount", *request.Body.Name)
+		if blocked {
+			account, err := s.store.GetAccountById(ctx, request.AccountId)
+			if errors.Is(err, store.ErrAccountNotFound) || (err == nil && !canAccessAccount(ctx, account)) {
+				return UpdateAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := s.refuseBlockedOperation(ctx, hits, &request.AccountId); err != nil {
+				return nil, err
+			}
+			return UpdateAccount403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(sanctionsBlocked(ctx))}, nil
+		}
+		if len(hits) > 0 {
+			diff = freezeOnRename(diff, actorFromContext(ctx))
+		}
+	}
+
+	account, err := s.updateAccountWith(ctx, request.AccountId, request.Params.IfMatch, diff, func(tx store.Tx, before, updated entities.Account) error {
+		if before.Name == updated.Name {
+			return nil
+		}
+		return recordScreeningHits(ctx, tx, hits, &request.AccountId)
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return UpdateAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		if errors.Is(err, errPreconditionFailed) {
+			return UpdateAccount412ApplicationProblemPlusJSONResponse(preconditionFailed(ctx)), nil
+		}
+		return nil, err
+	}
+
+	return UpdateAccount200JSONResponse{
+		Body:    toAccount(account),
+		Headers: UpdateAccount200ResponseHeaders{ETag: accountETag(account.Version)},
+	}, nil
+}
+
+func (s API) GetAccountChanges(ctx context.Context, request GetAccountChangesRequestObject) (GetAccountChangesResponseObject, error) {
+	account, err := s.store.GetAccountById(ctx, request.AccountId)
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return GetAccountChanges404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		return nil, err
+	}
+	if !canAccessAccount(ctx, account) {
+		return GetAccountChanges404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+	}
+
+	changes, err := s.store.GetAccountChanges(ctx, request.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetAccountChanges200JSONResponse, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, AccountChange{
+			Id:        change.Id,
+			Actor:     change.Actor,
+			Field:     change.Field,
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			ChangedAt: change.ChangedAt,
 		})
 	}
 
 	return response, nil
 }
 
-func (s API) CreateAccount(ctx context.Context, request CreateAccountRequestObject) (CreateAccountResponseObject, error) {
-	err := s.store.CreateAccount(ctx, request.Body.Name, 0)
+func (s API) GetAccountTransfers(ctx context.Context, request GetAccountTransfersRequestObject) (GetAccountTransfersResponseObject, error) {
+	if _, err := s.store.GetAccountById(ctx, request.AccountId); err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return GetAccountTransfers404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		return nil, err
+	}
+
+	transfers, err := s.store.GetTransfers(ctx, store.TransferFilter{SourceAccountId: &request.AccountId})
 	if err != nil {
 		return nil, err
 	}
-	return CreateAccount201Response{}, nil
+
+	response := make(GetAccountTransfers200JSONResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		response = append(response, toTransfer(transfer))
+	}
+
+	return response, nil
 }
 
-func (s API) AddBalanceToAccount(ctx context.Context, request AddBalanceToAccountRequestObject) (AddBalanceToAccountResponseObject, error) {
-	if request.Body.Amount <= 0 {
-		return AddBalanceToAccount400Response{}, nil
+func (s API) SetAccountStatus(ctx context.Context, request SetAccountStatusRequestObject) (SetAccountStatusResponseObject, error) {
+	if request.Body.Status != Active && request.Body.Status != Frozen {
+		return SetAccountStatus400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "status must be one of active, frozen")), nil
 	}
 
-	// check if the account exists
-	_, err := s.store.GetAccountById(ctx, request.AccountId)
+	diff := setAccountStatus(entities.AccountStatus(request.Body.Status), actorFromContext(ctx))
+	account, err := s.updateAccount(ctx, request.AccountId, request.Params.IfMatch, diff)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return AddBalanceToAccount404Response{}, nil
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return SetAccountStatus404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		if errors.Is(err, errPreconditionFailed) {
+			return SetAccountStatus412ApplicationProblemPlusJSONResponse(preconditionFailed(ctx)), nil
+		}
+		return nil, err
+	}
+
+	return SetAccountStatus200JSONResponse{
+		Body:    toAccount(account),
+		Headers: SetAccountStatus200ResponseHeaders{ETag: accountETag(account.Version)},
+	}, nil
+}
+
+func (s API) CreateAccount(ctx context.Context, request CreateAccountRequestObject) (CreateAccountResponseObject, error) {
+	if err := validateAccountName(request.Body.Name); err != nil {
+		return CreateAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	account := entities.NewAccount(request.Body.Name, 0)
+
+	// customers always own the accounts they create, admins pick the owner if any
+	principal, _ := auth.PrincipalFromContext(ctx)
+	switch {
+	case principal.IsAdmin():
+		if request.Body.OwnerId != nil {
+			if _, err := s.store.GetCustomerById(ctx, *request.Body.OwnerId); err != nil {
+				if errors.Is(err, store.ErrCustomerNotFound) {
+					return CreateAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "owner not found")), nil
+				}
+				return nil, err
+			}
+		}
+		account.OwnerId = request.Body.OwnerId
+	case principal.CustomerId == nil:
+		return CreateAccount403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(forbidden(ctx, "credentials are not bound to a customer"))}, nil
+	case request.Body.OwnerId != nil && *request.Body.OwnerId != *principal.CustomerId:
+		return CreateAccount403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(forbidden(ctx, "only admins can create accounts for other customers"))}, nil
+	default:
+		account.OwnerId = principal.CustomerId
+	}
+
+	// accounts matching the sanctions list are frozen until compliance staff clear them
+	hits, blocked := s.screenNames(entities.ScreeningSubjectAccount, "createAccount", account.Name)
+	if blocked {
+		if err := s.refuseBlockedOperation(ctx, hits, nil); err != nil {
+			return nil, err
+		}
+		return CreateAccount403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(sanctionsBlocked(ctx))}, nil
+	}
+	if len(hits) > 0 {
+		account.Status = entities.AccountStatusFrozen
+	}
+
+	var response CreateAccountResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		idempotent, err := claimIdempotencyKey(ctx, tx, request.Params.IdempotencyKey, "CreateAccount", request)
+		switch {
+		case errors.Is(err, errIdempotencyKeyReused):
+			response = CreateAccount422ApplicationProblemPlusJSONResponse{IdempotencyKeyReusedApplicationProblemPlusJSONResponse(idempotencyKeyReused(ctx))}
+			return errAbortTx
+		case err != nil:
+			return err
+		case idempotent.replay != nil:
+			response = idempotent.replay
+			return errAbortTx
+		}
+
+		created, err := tx.CreateAccount(ctx, account)
+		if err != nil {
+			return err
+		}
+		if err := appendAuditEvent(ctx, tx, nil, toAccount(created)); err != nil {
+			return err
+		}
+		accountId := int64(created.Id)
+		if err := appendOutboxEvent(ctx, tx, entities.OutboxEventAccountCreated, toAccount(created), created); err != nil {
+			return err
 		}
+		if err := appendAccountFrozen(ctx, tx, entities.Account{}, created); err != nil {
+			return err
+		}
+		if err := recordScreeningHits(ctx, tx, hits, &accountId); err != nil {
+			return err
+		}
+		response = CreateAccount201Response{}
+		return idempotent.save(ctx, tx, response.VisitCreateAccountResponse)
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
 		return nil, err
 	}
+	if _, ok := response.(CreateAccount201Response); ok {
+		s.opts.Metrics.AccountCreated()
+	}
+	return response, nil
+}
 
-	err = s.store.AddBalance(ctx, request.AccountId, request.Body.Amount)
+func (s API) GetCustomers(ctx context.Context, request GetCustomersRequestObject) (GetCustomersResponseObject, error) {
+	customers, err := s.store.GetCustomers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return AddBalanceToAccount200Response{}, nil
+	response := make(GetCustomers200JSONResponse, 0, len(customers))
+	for _, customer := range customers {
+		response = append(response, toCustomer(customer))
+	}
+
+	return response, nil
+}
+
+func (s API) CreateCustomer(ctx context.Context, request CreateCustomerRequestObject) (CreateCustomerResponseObject, error) {
+	if err := validateAccountName(request.Body.Name); err != nil {
+		return CreateCustomer400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	var response CreateCustomerResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		if request.Body.ExternalId != nil {
+			_, found, err := tx.LookupCustomerByExternalId(ctx, *request.Body.ExternalId)
+			if err != nil {
+				return err
+			}
+			if found {
+				response = CreateCustomer400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "external_id is already used by another customer"))
+				return errAbortTx
+			}
+		}
+
+		customer, err := tx.CreateCustomer(ctx, entities.Customer{
+			Name:       request.Body.Name,
+			ExternalId: request.Body.ExternalId,
+			CreatedAt:  time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		response = CreateCustomer201JSONResponse(toCustomer(customer))
+		return appendAuditEvent(ctx, tx, nil, toCustomer(customer))
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s API) AddBalanceToAccount(ctx context.Context, request AddBalanceToAccountRequestObject) (AddBalanceToAccountResponseObject, error) {
+	if err := validateAmount(request.Body.Amount); err != nil {
+		return AddBalanceToAccount400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	var response AddBalanceToAccountResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		idempotent, err := claimIdempotencyKey(ctx, tx, request.Params.IdempotencyKey, "AddBalanceToAccount", request)
+		switch {
+		case errors.Is(err, errIdempotencyKeyReused):
+			response = AddBalanceToAccount422ApplicationProblemPlusJSONResponse{IdempotencyKeyReusedApplicationProblemPlusJSONResponse(idempotencyKeyReused(ctx))}
+			return errAbortTx
+		case err != nil:
+			return err
+		case idempotent.replay != nil:
+			response = idempotent.replay
+			return errAbortTx
+		}
+		response = AddBalanceToAccount200Response{}
+
+		// check if the account exists
+		account, err := tx.GetAccountById(ctx, request.AccountId)
+		if err != nil {
+			if errors.Is(err, store.ErrAccountNotFound) {
+				response = AddBalanceToAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if !canAccessAccount(ctx, account) {
+			response = AddBalanceToAccount404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found"))
+			return errAbortTx
+		}
+
+		if err := tx.AddBalance(ctx, request.AccountId, request.Body.Amount); err != nil {
+			return err
+		}
+		updated, err := tx.GetAccountById(ctx, request.AccountId)
+		if err != nil {
+			return err
+		}
+		if err := appendAuditEvent(ctx, tx, toAccount(account), toAccount(updated)); err != nil {
+			return err
+		}
+		if err := appendOutboxEvent(ctx, tx, entities.OutboxEventBalanceAdded, balanceAddedEvent{
+			Amount:  request.Body.Amount,
+			Account: toAccount(updated),
+		}, updated); err != nil {
+			return err
+		}
+		return idempotent.save(ctx, tx, response.VisitAddBalanceToAccountResponse)
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 func (s API) TransferMoney(ctx context.Context, request TransferMoneyRequestObject) (TransferMoneyResponseObject, error) {
-	if request.Body.Amount <= 0 {
-		return TransferMoney400JSONResponse{Message: "amount must be greater than 0"}, nil
+	response, err := s.transferMoney(ctx, request)
+	s.recordTransfer(response, err, request.Body.Amount)
+	return response, err
+}
+
+func (s API) transferMoney(ctx context.Context, request TransferMoneyRequestObject) (TransferMoneyResponseObject, error) {
+	if err := validateAmount(request.Body.Amount); err != nil {
+		return TransferMoney400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
 	}
 	if request.AccountId == request.Body.TargetAccountId {
-		return TransferMoney400JSONResponse{Message: "cannot transfer to the same account"}, nil
+		return TransferMoney400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "cannot transfer to the same account")), nil
 	}
 
-	tx, err := s.store.BeginTx(ctx)
-	if err != nil {
+	var response TransferMoneyResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		idempotent, err := claimIdempotencyKey(ctx, tx, request.Params.IdempotencyKey, "TransferMoney", request)
+		switch {
+		case errors.Is(err, errIdempotencyKeyReused):
+			response = TransferMoney422ApplicationProblemPlusJSONResponse(idempotencyKeyReused(ctx))
+			return errAbortTx
+		case err != nil:
+			return err
+		case idempotent.replay != nil:
+			response = idempotent.replay
+			return errAbortTx
+		}
+
+		// the unit of work can be retried, so the outcome of a previous attempt must not leak into this one
+		response, err = s.transferInTx(ctx, tx, request)
+		if err != nil {
+			return err
+		}
+		return idempotent.save(ctx, tx, response.VisitTransferMoneyResponse)
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
 		return nil, err
 	}
-	defer func() {
-		if err = tx.Rollback(); err != nil {
-			slog.Warn("Failed to rollback the transaction", "error", err)
-		}
-	}()
 
+	return response, nil
+}
+
+// transferInTx makes the transfer in the unit of work tx. The error is errAbortTx when the response refuses the
+// transfer without committing anything.
+func (s API) transferInTx(ctx context.Context, tx store.Tx, request TransferMoneyRequestObject) (TransferMoneyResponseObject, error) {
 	// check target account exists
-	_, err = s.store.GetAccountByIdWithTx(ctx, tx, request.Body.TargetAccountId)
+	targetAccount, err := tx.GetAccountById(ctx, request.Body.TargetAccountId)
+	if errors.Is(err, store.ErrAccountNotFound) {
+		return TransferMoney404ApplicationProblemPlusJSONResponse(accountNotFound(ctx, "target account not found")), errAbortTx
+	}
 	if err != nil {
-		return TransferMoney400JSONResponse{Message: "target account not found"}, nil
+		return nil, err
 	}
 
-	// Check source account exists and has sufficient balance
-	sourceAccount, err := s.store.GetAccountByIdWithTx(ctx, tx, request.AccountId)
-	if err != nil {
-		return TransferMoney400JSONResponse{Message: "source account not found"}, nil
+	// Check source account exists and is the caller's
+	sourceAccount, err := tx.GetAccountById(ctx, request.AccountId)
+	if errors.Is(err, store.ErrAccountNotFound) || (err == nil && !canAccessAccount(ctx, sourceAccount)) {
+		return TransferMoney404ApplicationProblemPlusJSONResponse(accountNotFound(ctx, "source account not found")), errAbortTx
 	}
-	if sourceAccount.Balance < request.Body.Amount {
-		return TransferMoney400JSONResponse{Message: "insufficient balance"}, nil
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.store.SubtractBalanceWithTx(ctx, tx, request.AccountId, request.Body.Amount)
+	now := time.Now()
+	transfer := entities.Transfer{
+		SourceAccountId: request.AccountId,
+		TargetAccountId: request.Body.TargetAccountId,
+		Amount:          request.Body.Amount,
+		RequestedBy:     actorFromContext(ctx),
+		CreatedAt:       now,
+	}
+	refused, err := checkTransfer(ctx, tx, sourceAccount, targetAccount, transfer.Amount, now)
 	if err != nil {
 		return nil, err
 	}
-	err = s.store.AddBalanceWithTx(ctx, tx, request.Body.TargetAccountId, request.Body.Amount)
+	if refused != "" {
+		return TransferMoney422ApplicationProblemPlusJSONResponse(transferRefused(ctx, refused)), errAbortTx
+	}
+	refused, err = s.evaluateRisk(ctx, tx, sourceAccount, targetAccount, &transfer, now)
 	if err != nil {
 		return nil, err
 	}
+	if refused != "" {
+		_, err := tx.CreateTransfer(ctx, transfer)
+		return TransferMoney422ApplicationProblemPlusJSONResponse(transferDeclined(ctx, refused)), err
+	}
 
-	if err := tx.Commit(); err != nil {
+	hits, blocked := s.screenNames(entities.ScreeningSubjectTransfer, "transferMoney", sourceAccount.Name, targetAccount.Name)
+	switch {
+	case blocked:
+		// the blocked transfer is committed for the record, like the declined ones
+		transfer.Status = entities.TransferStatusBlocked
+		created, err := tx.CreateTransfer(ctx, transfer)
+		if err != nil {
+			return nil, err
+		}
+		response := TransferMoney403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(sanctionsBlocked(ctx))}
+		return response, recordScreeningHits(ctx, tx, hits, &created.Id)
+	case len(hits) > 0 || s.needsApproval(transfer.Amount) || transfer.RiskDecision == entities.RiskDecisionReview:
+		if err := s.holdTransfer(ctx, tx, sourceAccount, &transfer, hits, now); err != nil {
+			return nil, err
+		}
+		return TransferMoney202JSONResponse(toTransfer(transfer)), nil
+	}
+
+	if err := moveMoney(ctx, tx, sourceAccount, targetAccount, transfer); err != nil {
+		return nil, err
+	}
+	created, err := tx.CreateTransfer(ctx, transfer)
+	if err != nil {
 		return nil, err
 	}
+	return TransferMoney200Response{}, appendTransferCompleted(ctx, tx, created)
+}
+
+// checkTransfer runs the checks every transfer must pass before money moves, against the current state of
+// the accounts. It returns why the transfer is refused, or an empty string.
+func checkTransfer(ctx context.Context, tx store.Tx, source, target entities.Account, amount float64, now time.Time) (string, error) {
+	if target.Status == entities.AccountStatusFrozen {
+		return "target account is frozen", nil
+	}
+	if source.Status == entities.AccountStatusFrozen {
+		return "source account is frozen", nil
+	}
+	if source.AvailableBalance() < amount {
+		return "insufficient balance", nil
+	}
+	return checkTransferLimits(ctx, tx, source, amount, now)
+}
+
+// executeTransfer checks the transfer, evaluates the risk rules and moves the money, setting the outcome on
+// transfer. It returns why the transfer is refused, transfers declined by the risk rules must still be
+// recorded while the unit of work of the other refused transfers must be rolled back.
+func (s API) executeTransfer(ctx context.Context, tx store.Tx, source, target entities.Account, transfer *entities.Transfer, now time.Time) (string, error) {
+	refused, err := checkTransfer(ctx, tx, source, target, transfer.Amount, now)
+	if err != nil || refused != "" {
+		return refused, err
+	}
+	if refused, err := s.evaluateRisk(ctx, tx, source, target, transfer, now); err != nil || refused != "" {
+		return refused, err
+	}
+	return "", moveMoney(ctx, tx, source, target, *transfer)
+}
+
+// moveMoney moves the amount of a transfer that passed all the checks, and records it in the audit log.
+func moveMoney(ctx context.Context, tx store.Tx, source, target entities.Account, transfer entities.Transfer) error {
+	if err := tx.SubtractBalance(ctx, transfer.SourceAccountId, transfer.Amount); err != nil {
+		return err
+	}
+	if err := tx.AddBalance(ctx, transfer.TargetAccountId, transfer.Amount); err != nil {
+		return err
+	}
+
+	updatedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return err
+	}
+	updatedTarget, err := tx.GetAccountById(ctx, transfer.TargetAccountId)
+	if err != nil {
+		return err
+	}
+	return appendAuditEvent(ctx, tx,
+		transferSnapshot{Source: toAccount(source), Target: toAccount(target)},
+		transferSnapshot{Source: toAccount(updatedSource), Target: toAccount(updatedTarget)},
+	)
+}
+
+// evaluateRisk runs the risk rules on a transfer that passed the other checks and records their decision
+// on it, so only the transfers that would otherwise go through are evaluated.
+func (s API) evaluateRisk(ctx context.Context, tx store.Tx, source, target entities.Account, transfer *entities.Transfer, now time.Time) (string, error) {
+	decision, evaluations, err := s.opts.RiskEngine.Evaluate(ctx, tx, risk.Transfer{
+		Source: source,
+		Target: target,
+		Amount: transfer.Amount,
+		Time:   now,
+	})
+	if err != nil {
+		return "", err
+	}
+	transfer.Status = entities.TransferStatusCompleted
+	transfer.RiskDecision = decision
+	transfer.RiskEvaluations = evaluations
+	if decision == entities.RiskDecisionDeny {
+		// the declined transfer is committed for the record, without moving any money
+		transfer.Status = entities.TransferStatusDeclined
+		return "transfer declined by the risk checks", nil
+	}
+	return "", nil
+}
+
+func toAccount(account entities.Account) Account {
+	return Account{
+		Id:          int64(account.Id),
+		Name:        account.Name,
+		Balance:     account.Balance,
+		HeldBalance: account.HeldBalance,
+		Status:      AccountStatus(account.Status),
+		Version:     account.Version,
+		Metadata:    account.Metadata,
+		Labels:      account.Labels,
+		OwnerId:     account.OwnerId,
+		Tier:        account.Tier,
+		CreatedAt:   account.CreatedAt,
+		UpdatedAt:   account.UpdatedAt,
+	}
+}
 
-	return TransferMoney200Response{}, nil
+func toTransfer(transfer entities.Transfer) Transfer {
+	evaluations := make([]RiskEvaluation, 0, len(transfer.RiskEvaluations))
+	for _, evaluation := range transfer.RiskEvaluations {
+		evaluations = append(evaluations, RiskEvaluation{
+			Rule:     evaluation.Rule,
+			Decision: RiskDecision(evaluation.Decision),
+			Reason:   evaluation.Reason,
+		})
+	}
+	return Transfer{
+		Id:              transfer.Id,
+		SourceAccountId: transfer.SourceAccountId,
+		TargetAccountId: transfer.TargetAccountId,
+		Amount:          transfer.Amount,
+		Status:          TransferStatus(transfer.Status),
+		RiskDecision:    RiskDecision(transfer.RiskDecision),
+		RiskEvaluations: evaluations,
+		RequestedBy:     transfer.RequestedBy,
+		DecidedBy:       transfer.DecidedBy,
+		ExpiresAt:       transfer.ExpiresAt,
+		CreatedAt:       transfer.CreatedAt,
+	}
+}
+
+func toCustomer(customer entities.Customer) Customer {
+	return Customer{
+		Id:         customer.Id,
+		Name:       customer.Name,
+		ExternalId: customer.ExternalId,
+		CreatedAt:  customer.CreatedAt,
+	}
 }
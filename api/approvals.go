@@ -0,0 +1,300 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// expiryActor is the actor of the audit events recording the expiry of the transfers pending approval.
+const expiryActor = "system:approval-expiry"
+
+// heldTransferSnapshot is the audited state of a transfer pending approval or review and of the source
+// account holding its amount, Transfer is nil before the transfer is requested.
+type heldTransferSnapshot struct {
+	Transfer *Transfer `json:"transfer"`
+	Source   Account   `json:"source"`
+}
+
+func (s API) needsApproval(amount float64) bool {
+	return s.opts.ApprovalThreshold > 0 && amount > s.opts.ApprovalThreshold
+}
+
+// holdTransfer records a transfer that passed the checks as pending, holding its amount on the source
+// account until it is decided. It waits for compliance staff when its names matched the sanctions list, and
+// for approval otherwise, above the approval threshold or when the risk rules flagged it for review.
+func (s API) holdTransfer(ctx context.Context, tx store.Tx, source entities.Account, transfer *entities.Transfer, hits []entities.ScreeningHit, now time.Time) error {
+	if len(hits) > 0 {
+		transfer.Status = entities.TransferStatusPendingReview
+	} else {
+		s.awaitApproval(transfer, now)
+	}
+	if err := tx.HoldBalance(ctx, transfer.SourceAccountId, transfer.Amount); err != nil {
+		return err
+	}
+	created, err := tx.CreateTransfer(ctx, *transfer)
+	if err != nil {
+		return err
+	}
+	*transfer = created
+	if err := recordScreeningHits(ctx, tx, hits, &created.Id); err != nil {
+		return err
+	}
+
+	updatedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return err
+	}
+	held := toTransfer(created)
+	return appendAuditEvent(ctx, tx,
+		heldTransferSnapshot{Source: toAccount(source)},
+		heldTransferSnapshot{Transfer: &held, Source: toAccount(updatedSource)},
+	)
+}
+
+// awaitApproval makes the transfer pending approval, starting its time to live.
+func (s API) awaitApproval(transfer *entities.Transfer, now time.Time) {
+	transfer.Status = entities.TransferStatusPendingApproval
+	if s.opts.ApprovalTTL > 0 {
+		expiresAt := now.Add(s.opts.ApprovalTTL)
+		transfer.ExpiresAt = &expiresAt
+	}
+}
+
+func (s API) GetTransfers(ctx context.Context, request GetTransfersRequestObject) (GetTransfersResponseObject, error) {
+	var filter store.TransferFilter
+	if request.Params.Status != nil {
+		switch *request.Params.Status {
+		case TransferStatusCompleted, TransferStatusDeclined, TransferStatusPendingApproval, TransferStatusRejected, TransferStatusExpired,
+			TransferStatusPendingReview, TransferStatusBlocked:
+		default:
+			return GetTransfers400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "status must be one of completed, declined, pending_approval, rejected, expired, pending_review, blocked")), nil
+		}
+		status := entities.TransferStatus(*request.Params.Status)
+		filter.Status = &status
+	}
+
+	transfers, err := s.store.GetTransfers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetTransfers200JSONResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		response = append(response, toTransfer(transfer))
+	}
+
+	return response, nil
+}
+
+func (s API) ApproveTransfer(ctx context.Context, request ApproveTransferRequestObject) (ApproveTransferResponseObject, error) {
+	var response ApproveTransferResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		transfer, err := tx.GetTransferById(ctx, request.TransferId)
+		if err != nil {
+			if errors.Is(err, store.ErrTransferNotFound) {
+				response = ApproveTransfer404ApplicationProblemPlusJSONResponse(notFound(ctx, "transfer not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if transfer.Status != entities.TransferStatusPendingApproval {
+			response = ApproveTransfer409ApplicationProblemPlusJSONResponse(conflict(ctx, notPendingMessage(transfer)))
+			return errAbortTx
+		}
+		now := time.Now()
+		if transfer.ExpiresAt != nil && !now.Before(*transfer.ExpiresAt) {
+			// the expiry loop hasn't caught up yet, the transfer is expired on the spot
+			response = ApproveTransfer409ApplicationProblemPlusJSONResponse(conflict(ctx, "transfer expired before being approved"))
+			return closePendingTransfer(ctx, tx, transfer, entities.TransferStatusExpired, nil)
+		}
+		approver := actorFromContext(ctx)
+		if approver == transfer.RequestedBy {
+			response = ApproveTransfer403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(forbidden(ctx, "transfers must be approved by a different user than the one requesting them"))}
+			return errAbortTx
+		}
+
+		source, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+		if err != nil {
+			return err
+		}
+		if err := tx.ReleaseHeldBalance(ctx, transfer.SourceAccountId, transfer.Amount); err != nil {
+			return err
+		}
+		// the checks run again on the current balance, which no longer holds the amount of the transfer
+		releasedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+		if err != nil {
+			return err
+		}
+		target, err := tx.GetAccountById(ctx, transfer.TargetAccountId)
+		if err != nil {
+			return err
+		}
+
+		before := toTransfer(transfer)
+		transfer.DecidedBy = &approver
+		refused, err := s.executeTransfer(ctx, tx, releasedSource, target, &transfer, now)
+		if err != nil {
+			return err
+		}
+		if refused != "" && transfer.Status != entities.TransferStatusDeclined {
+			// the transfer stays pending, it may go through once the balance or the limits allow it
+			response = ApproveTransfer422ApplicationProblemPlusJSONResponse(transferRefused(ctx, refused))
+			return errAbortTx
+		}
+		if err := tx.UpdateTransfer(ctx, transfer); err != nil {
+			return err
+		}
+		if err := appendTransferCompleted(ctx, tx, transfer); err != nil {
+			return err
+		}
+
+		updatedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+		if err != nil {
+			return err
+		}
+		after := toTransfer(transfer)
+		response = ApproveTransfer200JSONResponse(after)
+		if refused != "" {
+			// the declined transfer is committed like the ones declined when requested, and answered the same way
+			response = ApproveTransfer422ApplicationProblemPlusJSONResponse(transferDeclined(ctx, refused))
+		}
+		return appendAuditEvent(ctx, tx,
+			heldTransferSnapshot{Transfer: &before, Source: toAccount(source)},
+			heldTransferSnapshot{Transfer: &after, Source: toAccount(updatedSource)},
+		)
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+	if approved, ok := response.(ApproveTransfer200JSONResponse); ok && approved.Status == TransferStatusCompleted {
+		s.opts.Metrics.TransferCompleted(approved.Amount)
+	}
+
+	return response, nil
+}
+
+func (s API) RejectTransfer(ctx context.Context, request RejectTransferRequestObject) (RejectTransferResponseObject, error) {
+	var response RejectTransferResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		transfer, err := tx.GetTransferById(ctx, request.TransferId)
+		if err != nil {
+			if errors.Is(err, store.ErrTransferNotFound) {
+				response = RejectTransfer404ApplicationProblemPlusJSONResponse(notFound(ctx, "transfer not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if transfer.Status != entities.TransferStatusPendingApproval {
+			response = RejectTransfer409ApplicationProblemPlusJSONResponse(conflict(ctx, notPendingMessage(transfer)))
+			return errAbortTx
+		}
+
+		rejecter := actorFromContext(ctx)
+		if err := closePendingTransfer(ctx, tx, transfer, entities.TransferStatusRejected, &rejecter); err != nil {
+			return err
+		}
+		transfer.Status = entities.TransferStatusRejected
+		transfer.DecidedBy = &rejecter
+		response = RejectTransfer200JSONResponse(toTransfer(transfer))
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func notPendingMessage(transfer entities.Transfer) string {
+	return fmt.Sprintf("transfer is %s, not pending approval", transfer.Status)
+}
+
+// closePendingTransfer ends a transfer pending approval without moving money, releasing its held amount.
+func closePendingTransfer(ctx context.Context, tx store.Tx, transfer entities.Transfer, status entities.TransferStatus, decidedBy *string) error {
+	source, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return err
+	}
+	if err := tx.ReleaseHeldBalance(ctx, transfer.SourceAccountId, transfer.Amount); err != nil {
+		return err
+	}
+	before := toTransfer(transfer)
+	transfer.Status = status
+	transfer.DecidedBy = decidedBy
+	if err := tx.UpdateTransfer(ctx, transfer); err != nil {
+		return err
+	}
+
+	updatedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return err
+	}
+	after := toTransfer(transfer)
+	return appendAuditEvent(ctx, tx,
+		heldTransferSnapshot{Transfer: &before, Source: toAccount(source)},
+		heldTransferSnapshot{Transfer: &after, Source: toAccount(updatedSource)},
+	)
+}
+
+// ExpirePendingTransfers expires the transfers that waited for approval past their expiry time, releasing
+// their held amounts. It returns how many were expired.
+func ExpirePendingTransfers(ctx context.Context, s store.Store, now time.Time) (int, error) {
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Subject: expiryActor})
+	ctx = context.WithValue(ctx, requestMetadataKey{}, requestMetadata{operation: "expirePendingTransfers"})
+
+	pending := entities.TransferStatusPendingApproval
+	transfers, err := s.GetTransfers(ctx, store.TransferFilter{Status: &pending, ExpiresBefore: &now})
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, transfer := range transfers {
+		err := s.RunInTx(ctx, func(tx store.Tx) error {
+			// the transfer may have been approved or rejected since it was listed
+			locked, err := tx.GetTransferById(ctx, transfer.Id)
+			if err != nil {
+				return err
+			}
+			if locked.Status != entities.TransferStatusPendingApproval {
+				return errAbortTx
+			}
+			return closePendingTransfer(ctx, tx, locked, entities.TransferStatusExpired, nil)
+		})
+		if errors.Is(err, errAbortTx) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// RunTransferExpiry expires the transfers pending approval every interval, until ctx is done.
+func RunTransferExpiry(ctx context.Context, logger *slog.Logger, s store.Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			expired, err := ExpirePendingTransfers(ctx, s, now)
+			if err != nil {
+				logger.Error("Error expiring transfers pending approval: " + err.Error())
+			}
+			if expired > 0 {
+				logger.Info("Expired transfers pending approval", "count", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// requestMetadata is what the audit events record about the request making a change.
+type requestMetadata struct {
+	operation string
+	requestId string
+	clientIP  string
+}
+
+type requestMetadataKey struct{}
+
+// RecordRequestMetadata keeps the operation and the origin of the request in the context for the audit
+// events. The request id is the one set by chi's RequestID middleware.
+func RecordRequestMetadata(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return f(WithRequestMetadata(ctx, operationID, middleware.GetReqID(ctx), clientIP(r)), w, r, request)
+	}
+}
+
+// WithRequestMetadata keeps the operation and the origin of a request in the context for the audit events,
+// for the APIs not going through the strict handler.
+func WithRequestMetadata(ctx context.Context, operationID, requestId, clientIP string) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, requestMetadata{
+		operation: specOperationId(operationID),
+		requestId: requestId,
+		clientIP:  clientIP,
+	})
+}
+
+// appendAuditEvent records a change made by the request in the audit log, as part of the unit of work tx
+// when there is one. before and after are snapshots of the changed resources in their API representation,
+// before is nil for creations.
+func appendAuditEvent(ctx context.Context, tx store.AuditTrail, before, after any) error {
+	metadata, _ := ctx.Value(requestMetadataKey{}).(requestMetadata)
+	event, err := entities.NewAuditEvent(actorFromContext(ctx), metadata.operation, before, after)
+	if err != nil {
+		return err
+	}
+	event.RequestId = metadata.requestId
+	event.ClientIP = metadata.clientIP
+
+	_, err = tx.AppendAuditEvent(ctx, event)
+	return err
+}
+
+// transferSnapshot is the state of both accounts of a transfer.
+type transferSnapshot struct {
+	Source Account `json:"source"`
+	Target Account `json:"target"`
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+// accountETag is the strong ETag of an account, derived from its version.
+func accountETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch returns the account version required by an If-Match header, or nil when the header is
+// absent or "*". ok is false when the header can't match any version of the account, in which case the
+// precondition has failed.
+func parseIfMatch(header *string) (version *int64, ok bool) {
+	if header == nil || strings.TrimSpace(*header) == "*" {
+		return nil, true
+	}
+
+	// If-Match uses the strong comparison, weak ETags never match.
+	etag := strings.TrimSpace(*header)
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) || len(etag) < 2 {
+		return nil, false
+	}
+	v, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &v, true
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/outbox"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// balanceAddedEvent is the payload of the BalanceAdded events.
+type balanceAddedEvent struct {
+	Amount  float64 `json:"amount"`
+	Account Account `json:"account"`
+}
+
+// transferCompletedEvent is the payload of the TransferCompleted events. It leaves out the risk evaluations
+// and the requester, which are kept from customers.
+type transferCompletedEvent struct {
+	Id              int64     `json:"id"`
+	SourceAccountId int64     `json:"source_account_id"`
+	TargetAccountId int64     `json:"target_account_id"`
+	Amount          float64   `json:"amount"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// appendOutboxEvent stores a domain event about the accounts in the unit of work tx making the change, so
+// the event is published if and only if the change is committed. The event is ordered with the other
+// events of the first account, streamed to the second one too, and delivered to the webhooks of any of them.
+func appendOutboxEvent(ctx context.Context, tx store.Tx, eventType entities.OutboxEventType, payload any, accounts ...entities.Account) error {
+	event, err := entities.NewOutboxEvent(eventType, int64(accounts[0].Id), payload)
+	if err != nil {
+		return err
+	}
+	if len(accounts) > 1 && accounts[1].Id != accounts[0].Id {
+		related := int64(accounts[1].Id)
+		event.RelatedAccountId = &related
+	}
+	event, err = tx.AddOutboxEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	return queueWebhookDeliveries(ctx, tx, event, accounts)
+}
+
+// appendTransferCompleted notifies the transfer once it is completed, the other outcomes move no money.
+func appendTransferCompleted(ctx context.Context, tx store.Tx, transfer entities.Transfer) error {
+	if transfer.Status != entities.TransferStatusCompleted {
+		return nil
+	}
+	source, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return err
+	}
+	target, err := tx.GetAccountById(ctx, transfer.TargetAccountId)
+	if err != nil {
+		return err
+	}
+	return appendOutboxEvent(ctx, tx, entities.OutboxEventTransferCompleted, transferCompletedEvent{
+		Id:              transfer.Id,
+		SourceAccountId: transfer.SourceAccountId,
+		TargetAccountId: transfer.TargetAccountId,
+		Amount:          transfer.Amount,
+		CreatedAt:       transfer.CreatedAt,
+	}, source, target)
+}
+
+// appendAccountFrozen notifies the account when the update froze it.
+func appendAccountFrozen(ctx context.Context, tx store.Tx, before, updated entities.Account) error {
+	if before.Status == entities.AccountStatusFrozen || updated.Status != entities.AccountStatusFrozen {
+		return nil
+	}
+	return appendOutboxEvent(ctx, tx, entities.OutboxEventAccountFrozen, toAccount(updated), updated)
+}
+
+// outboxLease is how long the events being published are kept from the other relays. It outlasts the
+// publishing of a batch, so events are only published again when their relay died meanwhile.
+const outboxLease = 5 * time.Minute
+
+// outboxRetryBackoff is the delay after the first failed attempt to publish an event, doubled after each
+// following one up to outboxMaxRetryBackoff.
+const (
+	outboxRetryBackoff    = time.Second
+	outboxMaxRetryBackoff = 5 * time.Minute
+)
+
+// RelayOutboxEvents publishes up to batchSize events of the outbox due at now to sink, oldest first, and
+// returns how many were published. When an event fails to be published the following events of its
+// account are held back, and the account is backed off, so each account's events are delivered in order,
+// at least once, without holding back the other accounts.
+func RelayOutboxEvents(ctx context.Context, s store.Store, sink outbox.Sink, batchSize int, now time.Time) (int, error) {
+	var events []entities.OutboxEvent
+	err := s.RunInTx(ctx, func(tx store.Tx) error {
+		var err error
+		events, err = tx.ClaimOutboxEvents(ctx, batchSize, now, now.Add(outboxLease))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// the sink is called outside of any unit of work, so a slow one doesn't hold the locks
+	var published []int64
+	var failed []entities.OutboxEvent
+	var publishErr error
+	failedAccounts := map[int64]bool{}
+	for _, event := range events {
+		if failedAccounts[event.AccountId] {
+			continue
+		}
+		if err := sink.Publish(ctx, toOutboxMessage(event)); err != nil {
+			failedAccounts[event.AccountId] = true
+			failed = append(failed, event)
+			publishErr = errors.Join(publishErr, err)
+			continue
+		}
+		published = append(published, event.Id)
+	}
+
+	err = s.RunInTx(ctx, func(tx store.Tx) error {
+		if err := tx.MarkOutboxEventsPublished(ctx, published, time.Now()); err != nil {
+			return err
+		}
+		for _, event := range failed {
+			attempts := event.Attempts + 1
+			if err := tx.DeferOutboxEvent(ctx, event.Id, attempts, now.Add(outboxRetryDelay(attempts))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(publishErr, err)
+	}
+	return len(published), publishErr
+}
+
+// outboxRetryDelay returns how long the account of an event waits after the given number of failed attempts
+// to publish it.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBackoff
+	for i := 1; i < attempts && delay < outboxMaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxRetryBackoff)
+}
+
+// RunOutboxRelay publishes the outbox events to sink every interval, until ctx is done.
+func RunOutboxRelay(ctx context.Context, logger *slog.Logger, s store.Store, sink outbox.Sink, interval time.Duration, batchSize int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// full batches are followed right away by the next one, to catch up on a backlog
+			for {
+				published, err := RelayOutboxEvents(ctx, s, sink, batchSize, time.Now())
+				if err != nil {
+					logger.Error("Error publishing outbox events: " + err.Error())
+				}
+				if published > 0 {
+					logger.Debug("Published outbox events", "count", published)
+				}
+				if err != nil || published < batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func toOutboxMessage(event entities.OutboxEvent) outbox.Message {
+	return outbox.Message{
+		Id:         event.Id,
+		Type:       string(event.Type),
+		AccountId:  event.AccountId,
+		OccurredAt: event.CreatedAt,
+		Data:       json.RawMessage(event.Payload),
+	}
+}
@@ -1,3 +1,4 @@
 package api
 
 //go:generate go tool oapi-codegen --config=openapi-gen-cfg-server.yaml openapi.yaml
+//go:generate go tool oapi-codegen --config=openapi-gen-cfg-client.yaml openapi.yaml
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// errIdempotencyKeyReused is returned when claiming an Idempotency-Key already used for a different request.
+var errIdempotencyKeyReused = errors.New("idempotency key reused")
+
+const idempotencyKeyReusedMessage = "Idempotency-Key was already used for a different request"
+
+// idempotentRequest is a request sent with an Idempotency-Key, claimed in its unit of work.
+type idempotentRequest struct {
+	// key is nil for the requests sent without Idempotency-Key.
+	key *entities.IdempotencyKey
+	// replay is the response saved by the first request with the key, nil when this request is the first.
+	replay *savedResponse
+}
+
+// claimIdempotencyKey claims the Idempotency-Key of a request of the operation for the unit of work. When
+// the principal already used the key for the same request, the saved response is to be replayed instead of
+// processing the request again, and errIdempotencyKeyReused is returned when it was for a different request.
+func claimIdempotencyKey(ctx context.Context, tx store.IdempotencyKeys, key *string, operation string, request any) (idempotentRequest, error) {
+	if key == nil {
+		return idempotentRequest{}, nil
+	}
+	// the request objects hold the path parameters along with the body
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return idempotentRequest{}, err
+	}
+	hash := sha256.Sum256(encoded)
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+	claim := entities.IdempotencyKey{
+		Subject:     principal.Subject,
+		Key:         *key,
+		Operation:   operation,
+		RequestHash: hex.EncodeToString(hash[:]),
+		CreatedAt:   time.Now(),
+	}
+	saved, claimed, err := tx.ClaimIdempotencyKey(ctx, claim)
+	switch {
+	case err != nil:
+		return idempotentRequest{}, err
+	case claimed:
+		return idempotentRequest{key: &saved}, nil
+	case saved.Operation != claim.Operation || saved.RequestHash != claim.RequestHash:
+		return idempotentRequest{}, errIdempotencyKeyReused
+	default:
+		replay := savedResponse(saved)
+		return idempotentRequest{replay: &replay}, nil
+	}
+}
+
+// save saves the response written by visit on the claimed key, so the retries of the request get it back.
+func (r idempotentRequest) save(ctx context.Context, tx store.IdempotencyKeys, visit func(http.ResponseWriter) error) error {
+	if r.key == nil {
+		return nil
+	}
+	recorder := responseRecorder{header: http.Header{}, status: http.StatusOK}
+	if err := visit(&recorder); err != nil {
+		return err
+	}
+	key := *r.key
+	key.ResponseStatus = recorder.status
+	key.ResponseContentType = recorder.header.Get("Content-Type")
+	key.ResponseBody = recorder.body.String()
+	return tx.SaveIdempotentResponse(ctx, key)
+}
+
+// savedResponse replays the response saved on an Idempotency-Key.
+type savedResponse entities.IdempotencyKey
+
+func (r *savedResponse) visit(w http.ResponseWriter) error {
+	if r.ResponseContentType != "" {
+		w.Header().Set("Content-Type", r.ResponseContentType)
+	}
+	w.WriteHeader(r.ResponseStatus)
+	_, err := w.Write([]byte(r.ResponseBody))
+	return err
+}
+
+func (r *savedResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+func (r *savedResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+func (r *savedResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
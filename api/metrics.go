@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"tiny-bank-api/pkg/metrics"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/store"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unknownOperation labels the requests of the paths the spec doesn't have, so they don't grow the number of
+// series.
+const unknownOperation = "unknown"
+
+// Instrument returns a middleware counting the requests of the API in m, and observing how long they took,
+// by operationId and status. It should run before the auth middlewares to measure the requests they reject.
+func Instrument(m *metrics.Metrics) (func(http.Handler) http.Handler, error) {
+	router, err := newSpecRouter()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			operation := unknownOperation
+			if route, _, err := router.FindRoute(r); err == nil {
+				operation = route.Operation.OperationID
+			}
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				// nothing was written, net/http answers 200
+				status = http.StatusOK
+			}
+			labels := []string{operation, strconv.Itoa(status)}
+			m.HTTPRequests.WithLabelValues(labels...).Inc()
+			m.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		})
+	}, nil
+}
+
+// recordTransfer counts the outcome of a transfer request, the refused ones by the code of their problem.
+func (s API) recordTransfer(response TransferMoneyResponseObject, err error, amount float64) {
+	m := s.opts.Metrics
+	switch r := response.(type) {
+	case TransferMoney200Response:
+		m.TransferCreated(string(TransferStatusCompleted), amount)
+	case TransferMoney202JSONResponse:
+		m.TransferCreated(string(r.Status), amount)
+	case TransferMoney400ApplicationProblemPlusJSONResponse:
+		m.TransferFailed(string(r.Code))
+	case TransferMoney403ApplicationProblemPlusJSONResponse:
+		m.TransferFailed(string(r.Code))
+	case TransferMoney404ApplicationProblemPlusJSONResponse:
+		m.TransferFailed(string(r.Code))
+	case TransferMoney409ApplicationProblemPlusJSONResponse:
+		m.TransferFailed(string(r.Code))
+	case TransferMoney422ApplicationProblemPlusJSONResponse:
+		m.TransferFailed(string(r.Code))
+	default:
+		switch {
+		case errors.Is(err, store.ErrTxConflict):
+			m.TransferFailed(string(problem.CodeConflict))
+		case err != nil:
+			m.TransferFailed(string(problem.CodeInternalError))
+		}
+	}
+}
@@ -10,18 +10,79 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
 	"time"
 
+	"tiny-bank-api/pkg/problem"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for AccountStatus.
+const (
+	Active AccountStatus = "active"
+	Frozen AccountStatus = "frozen"
+)
+
+// Defines values for EventType.
+const (
+	AccountCreated    EventType = "AccountCreated"
+	AccountFrozen     EventType = "AccountFrozen"
+	BalanceAdded      EventType = "BalanceAdded"
+	TransferCompleted EventType = "TransferCompleted"
+)
+
+// Defines values for RiskDecision.
+const (
+	Allow  RiskDecision = "allow"
+	Deny   RiskDecision = "deny"
+	Review RiskDecision = "review"
+)
+
+// Defines values for ScreeningHitSubjectType.
+const (
+	ScreeningHitSubjectTypeAccount  ScreeningHitSubjectType = "account"
+	ScreeningHitSubjectTypeTransfer ScreeningHitSubjectType = "transfer"
+)
+
+// Defines values for ScreeningHitStatus.
+const (
+	ScreeningHitStatusBlocked   ScreeningHitStatus = "blocked"
+	ScreeningHitStatusCleared   ScreeningHitStatus = "cleared"
+	ScreeningHitStatusConfirmed ScreeningHitStatus = "confirmed"
+	ScreeningHitStatusPending   ScreeningHitStatus = "pending"
+)
+
+// Defines values for TransferStatus.
+const (
+	TransferStatusBlocked         TransferStatus = "blocked"
+	TransferStatusCompleted       TransferStatus = "completed"
+	TransferStatusDeclined        TransferStatus = "declined"
+	TransferStatusExpired         TransferStatus = "expired"
+	TransferStatusPendingApproval TransferStatus = "pending_approval"
+	TransferStatusPendingReview   TransferStatus = "pending_review"
+	TransferStatusRejected        TransferStatus = "rejected"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Dead      WebhookDeliveryStatus = "dead"
+	Delivered WebhookDeliveryStatus = "delivered"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
 // Account defines model for Account.
 type Account struct {
 	// Balance Current balance of the account
@@ -30,14 +91,69 @@ type Account struct {
 	// CreatedAt Timestamp when the account was created
 	CreatedAt time.Time `json:"created_at"`
 
+	// HeldBalance The part of the balance held for the transfers pending approval, it can't be spent
+	HeldBalance float64 `json:"held_balance"`
+
 	// Id Unique identifier for the account
 	Id int64 `json:"id"`
 
+	// Labels Short key/value pairs used to group and filter accounts
+	Labels map[string]string `json:"labels"`
+
+	// Metadata Free-form details attached to the account
+	Metadata map[string]string `json:"metadata"`
+
 	// Name Name of the account holder
 	Name string `json:"name"`
 
+	// OwnerId The customer owning the account, only admins can access accounts without one
+	OwnerId *int64 `json:"owner_id"`
+
+	// Status Frozen accounts can neither send nor receive transfers
+	Status AccountStatus `json:"status"`
+
+	// Tier The tier setting the transfer limits of the account
+	Tier string `json:"tier"`
+
 	// UpdatedAt Timestamp when the account was last updated
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Version Incremented every time the account is updated
+	Version int64 `json:"version"`
+}
+
+// AccountChange defines model for AccountChange.
+type AccountChange struct {
+	// Actor Who made the change
+	Actor     string    `json:"actor"`
+	ChangedAt time.Time `json:"changed_at"`
+
+	// Field The changed field, metadata and labels keys are prefixed with "metadata." and "labels."
+	Field string `json:"field"`
+	Id    int64  `json:"id"`
+
+	// NewValue Value after the change, null when the field was removed
+	NewValue *string `json:"new_value"`
+
+	// OldValue Value before the change, null when the field didn't exist
+	OldValue *string `json:"old_value"`
+}
+
+// AccountStatus Frozen accounts can neither send nor receive transfers
+type AccountStatus string
+
+// AccountTransferLimits defines model for AccountTransferLimits.
+type AccountTransferLimits struct {
+	// Effective Velocity limits of the transfers made from an account, missing limits don't apply. The amounts are totals
+	// over rolling windows of 24 hours, 7 days and 30 days.
+	Effective TransferLimits `json:"effective"`
+
+	// Overrides Velocity limits of the transfers made from an account, missing limits don't apply. The amounts are totals
+	// over rolling windows of 24 hours, 7 days and 30 days.
+	Overrides TransferLimits `json:"overrides"`
+
+	// Tier The tier of the account
+	Tier string `json:"tier"`
 }
 
 // AddBalanceRequest defines model for AddBalanceRequest.
@@ -50,12 +166,168 @@ type AddBalanceRequest struct {
 type CreateAccountRequest struct {
 	// Name Name of the account holder
 	Name string `json:"name"`
+
+	// OwnerId The customer owning the account, only admins can set it to another customer
+	OwnerId *int64 `json:"owner_id,omitempty"`
+}
+
+// CreateCustomerRequest defines model for CreateCustomerRequest.
+type CreateCustomerRequest struct {
+	ExternalId *string `json:"external_id,omitempty"`
+	Name       string  `json:"name"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	// AccountIds Only deliver the events of these accounts, all the accessible accounts when empty
+	AccountIds *[]int64    `json:"account_ids,omitempty"`
+	EventTypes []EventType `json:"event_types"`
+
+	// Secret The secret signing the deliveries, a random one is generated when not set
+	Secret *string `json:"secret,omitempty"`
+
+	// Url The http or https URL the events are POSTed to
+	Url string `json:"url"`
+}
+
+// Customer defines model for Customer.
+type Customer struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExternalId Subject of the customer at the identity provider, matched against the bearer tokens
+	ExternalId *string `json:"external_id"`
+	Id         int64   `json:"id"`
+	Name       string  `json:"name"`
+}
+
+// EventType The domain events published to the event sinks and webhooks
+type EventType string
+
+// FieldError defines model for FieldError.
+type FieldError = problem.FieldError
+
+// Problem RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type Problem = problem.Problem
+
+// RiskDecision Transfers flagged for review are held pending approval until a back-office user decides them
+type RiskDecision string
+
+// RiskEvaluation defines model for RiskEvaluation.
+type RiskEvaluation struct {
+	// Decision Transfers flagged for review are held pending approval until a back-office user decides them
+	Decision RiskDecision `json:"decision"`
+	Reason   string       `json:"reason"`
+	Rule     string       `json:"rule"`
+}
+
+// ScreeningHit defines model for ScreeningHit.
+type ScreeningHit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedAt *time.Time `json:"decided_at"`
+	DecidedBy *string    `json:"decided_by"`
+	EntryName string     `json:"entry_name"`
+
+	// EntryUid The uid of the matching entry of the sanctions list
+	EntryUid string `json:"entry_uid"`
+	Id       int64  `json:"id"`
+
+	// MatchedName The name or alias of the entry that matched
+	MatchedName string `json:"matched_name"`
+
+	// Operation The operation the name was screened on
+	Operation string `json:"operation"`
+
+	// Score The similarity of the names, from 0 to 1
+	Score        float64 `json:"score"`
+	ScreenedName string  `json:"screened_name"`
+
+	// Status Pending hits wait for compliance staff, who clear false positives and confirm true matches. Blocked
+	// hits were close enough to refuse the operation right away.
+	Status ScreeningHitStatus `json:"status"`
+
+	// SubjectId The account or transfer, null when the operation was refused before creating it
+	SubjectId   *int64                  `json:"subject_id"`
+	SubjectType ScreeningHitSubjectType `json:"subject_type"`
+}
+
+// ScreeningHitSubjectType defines model for ScreeningHit.SubjectType.
+type ScreeningHitSubjectType string
+
+// ScreeningHitStatus Pending hits wait for compliance staff, who clear false positives and confirm true matches. Blocked
+// hits were close enough to refuse the operation right away.
+type ScreeningHitStatus string
+
+// SetAccountStatusRequest defines model for SetAccountStatusRequest.
+type SetAccountStatusRequest struct {
+	// Status Frozen accounts can neither send nor receive transfers
+	Status AccountStatus `json:"status"`
+}
+
+// SetAccountTransferLimitsRequest defines model for SetAccountTransferLimitsRequest.
+type SetAccountTransferLimitsRequest struct {
+	// Overrides Velocity limits of the transfers made from an account, missing limits don't apply. The amounts are totals
+	// over rolling windows of 24 hours, 7 days and 30 days.
+	Overrides TransferLimits `json:"overrides"`
+
+	// Tier The tier of the account
+	Tier string `json:"tier"`
+}
+
+// TierTransferLimits defines model for TierTransferLimits.
+type TierTransferLimits struct {
+	// Limits Velocity limits of the transfers made from an account, missing limits don't apply. The amounts are totals
+	// over rolling windows of 24 hours, 7 days and 30 days.
+	Limits TransferLimits `json:"limits"`
+	Tier   string         `json:"tier"`
+}
+
+// Transfer defines model for Transfer.
+type Transfer struct {
+	Amount    float64   `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// DecidedBy The subject of the credentials that approved or rejected the transfer
+	DecidedBy *string `json:"decided_by"`
+
+	// ExpiresAt When the transfer expires if it is still pending approval
+	ExpiresAt *time.Time `json:"expires_at"`
+	Id        int64      `json:"id"`
+
+	// RequestedBy The subject of the credentials that requested the transfer
+	RequestedBy string `json:"requested_by"`
+
+	// RiskDecision Transfers flagged for review are held pending approval until a back-office user decides them
+	RiskDecision RiskDecision `json:"risk_decision"`
+
+	// RiskEvaluations The risk rules that matched the transfer, in evaluation order
+	RiskEvaluations []RiskEvaluation `json:"risk_evaluations"`
+	SourceAccountId int64            `json:"source_account_id"`
+
+	// Status Declined transfers were denied by the risk rules, no money moved. Transfers pending approval end up
+	// completed, declined, rejected by a back-office user or expired. Transfers pending review matched the
+	// sanctions list, they are blocked when the match is confirmed.
+	Status          TransferStatus `json:"status"`
+	TargetAccountId int64          `json:"target_account_id"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	// Message A custom error message describing what went wrong
-	Message string `json:"message"`
+// TransferLimits Velocity limits of the transfers made from an account, missing limits don't apply. The amounts are totals
+// over rolling windows of 24 hours, 7 days and 30 days.
+type TransferLimits struct {
+	// DailyAmount The maximum amount transferred over the last 24 hours
+	DailyAmount *float64 `json:"daily_amount,omitempty"`
+
+	// DailyCount The maximum number of transfers over the last 24 hours
+	DailyCount *int64 `json:"daily_count,omitempty"`
+
+	// MaxAmount The maximum amount of a single transfer
+	MaxAmount *float64 `json:"max_amount,omitempty"`
+
+	// MonthlyAmount The maximum amount transferred over the last 30 days
+	MonthlyAmount *float64 `json:"monthly_amount,omitempty"`
+
+	// WeeklyAmount The maximum amount transferred over the last 7 days
+	WeeklyAmount *float64 `json:"weekly_amount,omitempty"`
 }
 
 // TransferRequest defines model for TransferRequest.
@@ -67,15 +339,214 @@ type TransferRequest struct {
 	TargetAccountId int64 `json:"targetAccountId"`
 }
 
+// TransferStatus Declined transfers were denied by the risk rules, no money moved. Transfers pending approval end up
+// completed, declined, rejected by a back-office user or expired. Transfers pending review matched the
+// sanctions list, they are blocked when the match is confirmed.
+type TransferStatus string
+
+// UpdateAccountRequest defines model for UpdateAccountRequest.
+type UpdateAccountRequest struct {
+	// Labels Labels to set, or to remove when null
+	Labels *map[string]*string `json:"labels,omitempty"`
+
+	// Metadata Metadata keys to set, or to remove when null
+	Metadata *map[string]*string `json:"metadata,omitempty"`
+
+	// Name Name of the account holder
+	Name *string `json:"name,omitempty"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	AccountIds []int64     `json:"account_ids"`
+	CreatedAt  time.Time   `json:"created_at"`
+	CreatedBy  string      `json:"created_by"`
+	EventTypes []EventType `json:"event_types"`
+	Id         int64       `json:"id"`
+
+	// OwnerId The customer who created the webhook, null for the webhooks of back-office users
+	OwnerId *int64 `json:"owner_id"`
+
+	// Secret The secret signing the deliveries, only returned when the webhook is created
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts The attempts made since the delivery was last queued
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+	EventId     int64      `json:"event_id"`
+
+	// EventType The domain events published to the event sinks and webhooks
+	EventType     EventType  `json:"event_type"`
+	Id            int64      `json:"id"`
+	LastAttemptAt *time.Time `json:"last_attempt_at"`
+	LastError     *string    `json:"last_error"`
+
+	// LastResponseStatus The HTTP status of the last attempt, null when no response was received
+	LastResponseStatus *int       `json:"last_response_status"`
+	NextAttemptAt      *time.Time `json:"next_attempt_at"`
+
+	// Payload The body POSTed to the URL of the webhook
+	Payload map[string]interface{} `json:"payload"`
+
+	// Status Dead deliveries failed every attempt, they are only delivered again on request.
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId int64                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus Dead deliveries failed every attempt, they are only delivered again on request.
+type WebhookDeliveryStatus string
+
+// AccountId defines model for AccountId.
+type AccountId = int64
+
+// DeliveryId defines model for DeliveryId.
+type DeliveryId = int64
+
+// HitId defines model for HitId.
+type HitId = int64
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// LastEventId defines model for LastEventId.
+type LastEventId = int64
+
+// Tier defines model for Tier.
+type Tier = string
+
+// TransferId defines model for TransferId.
+type TransferId = int64
+
+// WebhookId defines model for WebhookId.
+type WebhookId = int64
+
+// Conflict RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type Conflict = Problem
+
+// Forbidden RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type Forbidden = Problem
+
+// IdempotencyKeyReused RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type IdempotencyKeyReused = Problem
+
+// TooManyRequests RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type TooManyRequests = Problem
+
+// Unauthorized RFC 7807 problem details, the body of every error response. Clients should branch on `code`, the
+// `detail` is meant for humans and may change.
+type Unauthorized = Problem
+
+// CreateAccountParams defines parameters for CreateAccount.
+type CreateAccountParams struct {
+	// IdempotencyKey Unique key of the request, e.g. a UUID, letting the client retry it safely: the first request
+	// completing with the key has its response saved, and the next requests of the same credentials with the
+	// key get this response back without being processed again. Reusing the key for a different request is
+	// refused with a 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// UpdateAccountParams defines parameters for UpdateAccount.
+type UpdateAccountParams struct {
+	// IfMatch Only apply the change if the account still matches this ETag
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// AddBalanceToAccountParams defines parameters for AddBalanceToAccount.
+type AddBalanceToAccountParams struct {
+	// IdempotencyKey Unique key of the request, e.g. a UUID, letting the client retry it safely: the first request
+	// completing with the key has its response saved, and the next requests of the same credentials with the
+	// key get this response back without being processed again. Reusing the key for a different request is
+	// refused with a 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamAccountEventsParams defines parameters for StreamAccountEvents.
+type StreamAccountEventsParams struct {
+	// LastEventID Resume the stream after this event, only the new events are streamed when not set
+	LastEventID *LastEventId `json:"Last-Event-ID,omitempty"`
+}
+
+// SetAccountStatusParams defines parameters for SetAccountStatus.
+type SetAccountStatusParams struct {
+	// IfMatch Only apply the change if the account still matches this ETag
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// TransferMoneyParams defines parameters for TransferMoney.
+type TransferMoneyParams struct {
+	// IdempotencyKey Unique key of the request, e.g. a UUID, letting the client retry it safely: the first request
+	// completing with the key has its response saved, and the next requests of the same credentials with the
+	// key get this response back without being processed again. Reusing the key for a different request is
+	// refused with a 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// SetAccountTransferLimitsParams defines parameters for SetAccountTransferLimits.
+type SetAccountTransferLimitsParams struct {
+	// IfMatch Only apply the change if the account still matches this ETag
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// LastEventID Resume the stream after this event, only the new events are streamed when not set
+	LastEventID *LastEventId `json:"Last-Event-ID,omitempty"`
+}
+
+// GetScreeningHitsParams defines parameters for GetScreeningHits.
+type GetScreeningHitsParams struct {
+	Status *ScreeningHitStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetTransfersParams defines parameters for GetTransfers.
+type GetTransfersParams struct {
+	Status *TransferStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
 // CreateAccountJSONRequestBody defines body for CreateAccount for application/json ContentType.
 type CreateAccountJSONRequestBody = CreateAccountRequest
 
+// UpdateAccountApplicationMergePatchPlusJSONRequestBody defines body for UpdateAccount for application/merge-patch+json ContentType.
+type UpdateAccountApplicationMergePatchPlusJSONRequestBody = UpdateAccountRequest
+
 // AddBalanceToAccountJSONRequestBody defines body for AddBalanceToAccount for application/json ContentType.
 type AddBalanceToAccountJSONRequestBody = AddBalanceRequest
 
+// SetAccountStatusJSONRequestBody defines body for SetAccountStatus for application/json ContentType.
+type SetAccountStatusJSONRequestBody = SetAccountStatusRequest
+
 // TransferMoneyJSONRequestBody defines body for TransferMoney for application/json ContentType.
 type TransferMoneyJSONRequestBody = TransferRequest
 
+// SetAccountTransferLimitsJSONRequestBody defines body for SetAccountTransferLimits for application/json ContentType.
+type SetAccountTransferLimitsJSONRequestBody = SetAccountTransferLimitsRequest
+
+// CreateCustomerJSONRequestBody defines body for CreateCustomer for application/json ContentType.
+type CreateCustomerJSONRequestBody = CreateCustomerRequest
+
+// SetTierTransferLimitsJSONRequestBody defines body for SetTierTransferLimits for application/json ContentType.
+type SetTierTransferLimitsJSONRequestBody = TransferLimits
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get all accounts
@@ -83,13 +554,85 @@ type ServerInterface interface {
 	GetAccounts(w http.ResponseWriter, r *http.Request)
 	// Create a new account
 	// (POST /accounts)
-	CreateAccount(w http.ResponseWriter, r *http.Request)
+	CreateAccount(w http.ResponseWriter, r *http.Request, params CreateAccountParams)
+	// Get an account
+	// (GET /accounts/{accountId})
+	GetAccount(w http.ResponseWriter, r *http.Request, accountId AccountId)
+	// Update the details of an account
+	// (PATCH /accounts/{accountId})
+	UpdateAccount(w http.ResponseWriter, r *http.Request, accountId AccountId, params UpdateAccountParams)
 	// Add balance to an account
 	// (POST /accounts/{accountId}/add-balance)
-	AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64)
+	AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64, params AddBalanceToAccountParams)
+	// Get the history of changes made to an account
+	// (GET /accounts/{accountId}/changes)
+	GetAccountChanges(w http.ResponseWriter, r *http.Request, accountId AccountId)
+	// Stream the activity of an account
+	// (GET /accounts/{accountId}/events)
+	StreamAccountEvents(w http.ResponseWriter, r *http.Request, accountId AccountId, params StreamAccountEventsParams)
+	// Freeze or unfreeze an account
+	// (PUT /accounts/{accountId}/status)
+	SetAccountStatus(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountStatusParams)
 	// Transfer money to another account
 	// (POST /accounts/{accountId}/transfer)
-	TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64)
+	TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64, params TransferMoneyParams)
+	// Get the transfer limits of an account
+	// (GET /accounts/{accountId}/transfer-limits)
+	GetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId)
+	// Set the tier of an account and the limits overriding the ones of its tier
+	// (PUT /accounts/{accountId}/transfer-limits)
+	SetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountTransferLimitsParams)
+	// Get the transfers made from an account, with the decisions of the risk rules
+	// (GET /accounts/{accountId}/transfers)
+	GetAccountTransfers(w http.ResponseWriter, r *http.Request, accountId AccountId)
+	// Get all customers
+	// (GET /customers)
+	GetCustomers(w http.ResponseWriter, r *http.Request)
+	// Create a new customer
+	// (POST /customers)
+	CreateCustomer(w http.ResponseWriter, r *http.Request)
+	// Stream the activity of all the accounts
+	// (GET /events)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// List the names that matched the sanctions list
+	// (GET /screening-hits)
+	GetScreeningHits(w http.ResponseWriter, r *http.Request, params GetScreeningHitsParams)
+	// Clear a pending hit as a false positive
+	// (POST /screening-hits/{hitId}/clear)
+	ClearScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId)
+	// Confirm a pending hit as a true match
+	// (POST /screening-hits/{hitId}/confirm)
+	ConfirmScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId)
+	// List the account tiers having transfer limits
+	// (GET /transfer-limits/tiers)
+	GetTiers(w http.ResponseWriter, r *http.Request)
+	// Set the transfer limits of the accounts of a tier
+	// (PUT /transfer-limits/tiers/{tier})
+	SetTierTransferLimits(w http.ResponseWriter, r *http.Request, tier Tier)
+	// List the transfers, for example the ones pending approval
+	// (GET /transfers)
+	GetTransfers(w http.ResponseWriter, r *http.Request, params GetTransfersParams)
+	// Approve a transfer pending approval
+	// (POST /transfers/{transferId}/approve)
+	ApproveTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId)
+	// Reject a transfer pending approval, releasing its held amount
+	// (POST /transfers/{transferId}/reject)
+	RejectTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId)
+	// List the webhooks
+	// (GET /webhooks)
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	// Subscribe a URL to the events of accounts
+	// (POST /webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete a webhook along with its deliveries
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookId)
+	// Get the delivery history of a webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookId, params GetWebhookDeliveriesParams)
+	// Deliver an event again
+	// (POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver)
+	RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookId, deliveryId DeliveryId)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -104,19 +647,163 @@ func (_ Unimplemented) GetAccounts(w http.ResponseWriter, r *http.Request) {
 
 // Create a new account
 // (POST /accounts)
-func (_ Unimplemented) CreateAccount(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) CreateAccount(w http.ResponseWriter, r *http.Request, params CreateAccountParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an account
+// (GET /accounts/{accountId})
+func (_ Unimplemented) GetAccount(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update the details of an account
+// (PATCH /accounts/{accountId})
+func (_ Unimplemented) UpdateAccount(w http.ResponseWriter, r *http.Request, accountId AccountId, params UpdateAccountParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Add balance to an account
 // (POST /accounts/{accountId}/add-balance)
-func (_ Unimplemented) AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64) {
+func (_ Unimplemented) AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64, params AddBalanceToAccountParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the history of changes made to an account
+// (GET /accounts/{accountId}/changes)
+func (_ Unimplemented) GetAccountChanges(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream the activity of an account
+// (GET /accounts/{accountId}/events)
+func (_ Unimplemented) StreamAccountEvents(w http.ResponseWriter, r *http.Request, accountId AccountId, params StreamAccountEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Freeze or unfreeze an account
+// (PUT /accounts/{accountId}/status)
+func (_ Unimplemented) SetAccountStatus(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountStatusParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Transfer money to another account
 // (POST /accounts/{accountId}/transfer)
-func (_ Unimplemented) TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64) {
+func (_ Unimplemented) TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64, params TransferMoneyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the transfer limits of an account
+// (GET /accounts/{accountId}/transfer-limits)
+func (_ Unimplemented) GetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the tier of an account and the limits overriding the ones of its tier
+// (PUT /accounts/{accountId}/transfer-limits)
+func (_ Unimplemented) SetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountTransferLimitsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the transfers made from an account, with the decisions of the risk rules
+// (GET /accounts/{accountId}/transfers)
+func (_ Unimplemented) GetAccountTransfers(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all customers
+// (GET /customers)
+func (_ Unimplemented) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new customer
+// (POST /customers)
+func (_ Unimplemented) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream the activity of all the accounts
+// (GET /events)
+func (_ Unimplemented) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the names that matched the sanctions list
+// (GET /screening-hits)
+func (_ Unimplemented) GetScreeningHits(w http.ResponseWriter, r *http.Request, params GetScreeningHitsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear a pending hit as a false positive
+// (POST /screening-hits/{hitId}/clear)
+func (_ Unimplemented) ClearScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm a pending hit as a true match
+// (POST /screening-hits/{hitId}/confirm)
+func (_ Unimplemented) ConfirmScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the account tiers having transfer limits
+// (GET /transfer-limits/tiers)
+func (_ Unimplemented) GetTiers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the transfer limits of the accounts of a tier
+// (PUT /transfer-limits/tiers/{tier})
+func (_ Unimplemented) SetTierTransferLimits(w http.ResponseWriter, r *http.Request, tier Tier) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the transfers, for example the ones pending approval
+// (GET /transfers)
+func (_ Unimplemented) GetTransfers(w http.ResponseWriter, r *http.Request, params GetTransfersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a transfer pending approval
+// (POST /transfers/{transferId}/approve)
+func (_ Unimplemented) ApproveTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a transfer pending approval, releasing its held amount
+// (POST /transfers/{transferId}/reject)
+func (_ Unimplemented) RejectTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the webhooks
+// (GET /webhooks)
+func (_ Unimplemented) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Subscribe a URL to the events of accounts
+// (POST /webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a webhook along with its deliveries
+// (DELETE /webhooks/{webhookId})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the delivery history of a webhook
+// (GET /webhooks/{webhookId}/deliveries)
+func (_ Unimplemented) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookId, params GetWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deliver an event again
+// (POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver)
+func (_ Unimplemented) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookId, deliveryId DeliveryId) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -132,6 +819,14 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // GetAccounts operation middleware
 func (siw *ServerInterfaceWrapper) GetAccounts(w http.ResponseWriter, r *http.Request) {
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetAccounts(w, r)
 	}))
@@ -146,8 +841,42 @@ func (siw *ServerInterfaceWrapper) GetAccounts(w http.ResponseWriter, r *http.Re
 // CreateAccount operation middleware
 func (siw *ServerInterfaceWrapper) CreateAccount(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateAccountParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateAccount(w, r)
+		siw.Handler.CreateAccount(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -157,13 +886,13 @@ func (siw *ServerInterfaceWrapper) CreateAccount(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// AddBalanceToAccount operation middleware
-func (siw *ServerInterfaceWrapper) AddBalanceToAccount(w http.ResponseWriter, r *http.Request) {
+// GetAccount operation middleware
+func (siw *ServerInterfaceWrapper) GetAccount(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "accountId" -------------
-	var accountId int64
+	var accountId AccountId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -171,8 +900,16 @@ func (siw *ServerInterfaceWrapper) AddBalanceToAccount(w http.ResponseWriter, r
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddBalanceToAccount(w, r, accountId)
+		siw.Handler.GetAccount(w, r, accountId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -182,13 +919,13 @@ func (siw *ServerInterfaceWrapper) AddBalanceToAccount(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// TransferMoney operation middleware
-func (siw *ServerInterfaceWrapper) TransferMoney(w http.ResponseWriter, r *http.Request) {
+// UpdateAccount operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAccount(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "accountId" -------------
-	var accountId int64
+	var accountId AccountId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -196,8 +933,40 @@ func (siw *ServerInterfaceWrapper) TransferMoney(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateAccountParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TransferMoney(w, r, accountId)
+		siw.Handler.UpdateAccount(w, r, accountId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -207,288 +976,3984 @@ func (siw *ServerInterfaceWrapper) TransferMoney(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// AddBalanceToAccount operation middleware
+func (siw *ServerInterfaceWrapper) AddBalanceToAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddBalanceToAccountParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddBalanceToAccount(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAccountChanges operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountChanges(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAccountChanges(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamAccountEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamAccountEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamAccountEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID LastEventId
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamAccountEvents(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetAccountStatus operation middleware
+func (siw *ServerInterfaceWrapper) SetAccountStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetAccountStatusParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetAccountStatus(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TransferMoney operation middleware
+func (siw *ServerInterfaceWrapper) TransferMoney(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"transfers:create"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transfers:create"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransferMoneyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransferMoney(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAccountTransferLimits operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountTransferLimits(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAccountTransferLimits(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetAccountTransferLimits operation middleware
+func (siw *ServerInterfaceWrapper) SetAccountTransferLimits(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetAccountTransferLimitsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetAccountTransferLimits(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAccountTransfers operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountTransfers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId AccountId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAccountTransfers(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomers operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCustomer operation middleware
+func (siw *ServerInterfaceWrapper) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCustomer(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID LastEventId
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScreeningHits operation middleware
+func (siw *ServerInterfaceWrapper) GetScreeningHits(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScreeningHitsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScreeningHits(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearScreeningHit operation middleware
+func (siw *ServerInterfaceWrapper) ClearScreeningHit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hitId" -------------
+	var hitId HitId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hitId", chi.URLParam(r, "hitId"), &hitId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hitId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearScreeningHit(w, r, hitId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmScreeningHit operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmScreeningHit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hitId" -------------
+	var hitId HitId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hitId", chi.URLParam(r, "hitId"), &hitId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hitId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmScreeningHit(w, r, hitId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTiers operation middleware
+func (siw *ServerInterfaceWrapper) GetTiers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTiers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetTierTransferLimits operation middleware
+func (siw *ServerInterfaceWrapper) SetTierTransferLimits(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tier" -------------
+	var tier Tier
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tier", chi.URLParam(r, "tier"), &tier, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tier", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetTierTransferLimits(w, r, tier)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTransfers operation middleware
+func (siw *ServerInterfaceWrapper) GetTransfers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTransfersParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTransfers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ApproveTransfer operation middleware
+func (siw *ServerInterfaceWrapper) ApproveTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transferId" -------------
+	var transferId TransferId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transferId", chi.URLParam(r, "transferId"), &transferId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transferId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"transfers:create"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transfers:create"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveTransfer(w, r, transferId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RejectTransfer operation middleware
+func (siw *ServerInterfaceWrapper) RejectTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transferId" -------------
+	var transferId TransferId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transferId", chi.URLParam(r, "transferId"), &transferId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transferId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"transfers:create"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transfers:create"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectTransfer(w, r, transferId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:read"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookDeliveries(w, r, webhookId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RedeliverWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId DeliveryId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", chi.URLParam(r, "deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deliveryId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"accounts:write"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeliverWebhookDelivery(w, r, webhookId, deliveryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
 
 func (e *UnescapedCookieParamError) Error() string {
 	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts", wrapper.GetAccounts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/accounts", wrapper.CreateAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}", wrapper.GetAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/accounts/{accountId}", wrapper.UpdateAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/accounts/{accountId}/add-balance", wrapper.AddBalanceToAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/changes", wrapper.GetAccountChanges)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/events", wrapper.StreamAccountEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/accounts/{accountId}/status", wrapper.SetAccountStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/accounts/{accountId}/transfer", wrapper.TransferMoney)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/transfer-limits", wrapper.GetAccountTransferLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/accounts/{accountId}/transfer-limits", wrapper.SetAccountTransferLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/transfers", wrapper.GetAccountTransfers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers", wrapper.GetCustomers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/customers", wrapper.CreateCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events", wrapper.StreamEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/screening-hits", wrapper.GetScreeningHits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/screening-hits/{hitId}/clear", wrapper.ClearScreeningHit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/screening-hits/{hitId}/confirm", wrapper.ConfirmScreeningHit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transfer-limits/tiers", wrapper.GetTiers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/transfer-limits/tiers/{tier}", wrapper.SetTierTransferLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transfers", wrapper.GetTransfers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transfers/{transferId}/approve", wrapper.ApproveTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transfers/{transferId}/reject", wrapper.RejectTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/webhooks/{webhookId}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{webhookId}/deliveries", wrapper.GetWebhookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver", wrapper.RedeliverWebhookDelivery)
+	})
+
+	return r
+}
+
+type ConflictApplicationProblemPlusJSONResponse Problem
+
+type EventStreamTexteventStreamResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type ForbiddenApplicationProblemPlusJSONResponse Problem
+
+type IdempotencyKeyReusedApplicationProblemPlusJSONResponse Problem
+
+type TooManyRequestsResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+type TooManyRequestsApplicationProblemPlusJSONResponse struct {
+	Body Problem
+
+	Headers TooManyRequestsResponseHeaders
+}
+
+type UnauthorizedApplicationProblemPlusJSONResponse Problem
+
+type GetAccountsRequestObject struct {
+}
+
+type GetAccountsResponseObject interface {
+	VisitGetAccountsResponse(w http.ResponseWriter) error
+}
+
+type GetAccounts200JSONResponse []Account
+
+func (response GetAccounts200JSONResponse) VisitGetAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccounts401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccounts401ApplicationProblemPlusJSONResponse) VisitGetAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccounts403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccounts403ApplicationProblemPlusJSONResponse) VisitGetAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccounts429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccounts429ApplicationProblemPlusJSONResponse) VisitGetAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccountRequestObject struct {
+	Params CreateAccountParams
+	Body   *CreateAccountJSONRequestBody
+}
+
+type CreateAccountResponseObject interface {
+	VisitCreateAccountResponse(w http.ResponseWriter) error
+}
+
+type CreateAccount201Response struct {
+}
+
+func (response CreateAccount201Response) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type CreateAccount400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateAccount400ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response CreateAccount401ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response CreateAccount403ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response CreateAccount409ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount422ApplicationProblemPlusJSONResponse struct {
+	IdempotencyKeyReusedApplicationProblemPlusJSONResponse
+}
+
+func (response CreateAccount422ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response CreateAccount429ApplicationProblemPlusJSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccountRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+}
+
+type GetAccountResponseObject interface {
+	VisitGetAccountResponse(w http.ResponseWriter) error
+}
+
+type GetAccount200ResponseHeaders struct {
+	ETag string
+}
+
+type GetAccount200JSONResponse struct {
+	Body    Account
+	Headers GetAccount200ResponseHeaders
+}
+
+func (response GetAccount200JSONResponse) VisitGetAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccount401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccount401ApplicationProblemPlusJSONResponse) VisitGetAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccount403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccount403ApplicationProblemPlusJSONResponse) VisitGetAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccount404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetAccount404ApplicationProblemPlusJSONResponse) VisitGetAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccount429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccount429ApplicationProblemPlusJSONResponse) VisitGetAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateAccountRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+	Params    UpdateAccountParams
+	Body      *UpdateAccountApplicationMergePatchPlusJSONRequestBody
+}
+
+type UpdateAccountResponseObject interface {
+	VisitUpdateAccountResponse(w http.ResponseWriter) error
+}
+
+type UpdateAccount200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateAccount200JSONResponse struct {
+	Body    Account
+	Headers UpdateAccount200ResponseHeaders
+}
+
+func (response UpdateAccount200JSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateAccount400ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateAccount400ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response UpdateAccount401ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response UpdateAccount403ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount404ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateAccount404ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response UpdateAccount409ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount412ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateAccount412ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response UpdateAccount429ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddBalanceToAccountRequestObject struct {
+	AccountId int64 `json:"accountId"`
+	Params    AddBalanceToAccountParams
+	Body      *AddBalanceToAccountJSONRequestBody
+}
+
+type AddBalanceToAccountResponseObject interface {
+	VisitAddBalanceToAccountResponse(w http.ResponseWriter) error
+}
+
+type AddBalanceToAccount200Response struct {
+}
+
+func (response AddBalanceToAccount200Response) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type AddBalanceToAccount400ApplicationProblemPlusJSONResponse Problem
+
+func (response AddBalanceToAccount400ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response AddBalanceToAccount401ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response AddBalanceToAccount403ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount404ApplicationProblemPlusJSONResponse Problem
+
+func (response AddBalanceToAccount404ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response AddBalanceToAccount409ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount422ApplicationProblemPlusJSONResponse struct {
+	IdempotencyKeyReusedApplicationProblemPlusJSONResponse
+}
+
+func (response AddBalanceToAccount422ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddBalanceToAccount429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response AddBalanceToAccount429ApplicationProblemPlusJSONResponse) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccountChangesRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+}
+
+type GetAccountChangesResponseObject interface {
+	VisitGetAccountChangesResponse(w http.ResponseWriter) error
+}
+
+type GetAccountChanges200JSONResponse []AccountChange
+
+func (response GetAccountChanges200JSONResponse) VisitGetAccountChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountChanges401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountChanges401ApplicationProblemPlusJSONResponse) VisitGetAccountChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountChanges403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountChanges403ApplicationProblemPlusJSONResponse) VisitGetAccountChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountChanges404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetAccountChanges404ApplicationProblemPlusJSONResponse) VisitGetAccountChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountChanges429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountChanges429ApplicationProblemPlusJSONResponse) VisitGetAccountChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type StreamAccountEventsRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+	Params    StreamAccountEventsParams
+}
+
+type StreamAccountEventsResponseObject interface {
+	VisitStreamAccountEventsResponse(w http.ResponseWriter) error
+}
+
+type StreamAccountEvents200TexteventStreamResponse struct {
+	EventStreamTexteventStreamResponse
+}
+
+func (response StreamAccountEvents200TexteventStreamResponse) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type StreamAccountEvents401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response StreamAccountEvents401ApplicationProblemPlusJSONResponse) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamAccountEvents403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response StreamAccountEvents403ApplicationProblemPlusJSONResponse) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamAccountEvents404ApplicationProblemPlusJSONResponse Problem
+
+func (response StreamAccountEvents404ApplicationProblemPlusJSONResponse) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamAccountEvents429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response StreamAccountEvents429ApplicationProblemPlusJSONResponse) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetAccountStatusRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+	Params    SetAccountStatusParams
+	Body      *SetAccountStatusJSONRequestBody
+}
+
+type SetAccountStatusResponseObject interface {
+	VisitSetAccountStatusResponse(w http.ResponseWriter) error
+}
+
+type SetAccountStatus200ResponseHeaders struct {
+	ETag string
+}
+
+type SetAccountStatus200JSONResponse struct {
+	Body    Account
+	Headers SetAccountStatus200ResponseHeaders
+}
+
+func (response SetAccountStatus200JSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetAccountStatus400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountStatus400ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountStatus401ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountStatus403ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountStatus404ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountStatus409ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus412ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountStatus412ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountStatus429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountStatus429ApplicationProblemPlusJSONResponse) VisitSetAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type TransferMoneyRequestObject struct {
+	AccountId int64 `json:"accountId"`
+	Params    TransferMoneyParams
+	Body      *TransferMoneyJSONRequestBody
+}
+
+type TransferMoneyResponseObject interface {
+	VisitTransferMoneyResponse(w http.ResponseWriter) error
+}
+
+type TransferMoney200Response struct {
+}
+
+func (response TransferMoney200Response) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type TransferMoney202JSONResponse Transfer
+
+func (response TransferMoney202JSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney400ApplicationProblemPlusJSONResponse Problem
+
+func (response TransferMoney400ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response TransferMoney401ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response TransferMoney403ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney404ApplicationProblemPlusJSONResponse Problem
+
+func (response TransferMoney404ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney409ApplicationProblemPlusJSONResponse Problem
+
+func (response TransferMoney409ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney422ApplicationProblemPlusJSONResponse Problem
+
+func (response TransferMoney422ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransferMoney429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response TransferMoney429ApplicationProblemPlusJSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccountTransferLimitsRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+}
+
+type GetAccountTransferLimitsResponseObject interface {
+	VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error
+}
+
+type GetAccountTransferLimits200JSONResponse AccountTransferLimits
+
+func (response GetAccountTransferLimits200JSONResponse) VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransferLimits401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransferLimits401ApplicationProblemPlusJSONResponse) VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransferLimits403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransferLimits403ApplicationProblemPlusJSONResponse) VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransferLimits404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetAccountTransferLimits404ApplicationProblemPlusJSONResponse) VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransferLimits429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransferLimits429ApplicationProblemPlusJSONResponse) VisitGetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetAccountTransferLimitsRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+	Params    SetAccountTransferLimitsParams
+	Body      *SetAccountTransferLimitsJSONRequestBody
+}
+
+type SetAccountTransferLimitsResponseObject interface {
+	VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error
+}
+
+type SetAccountTransferLimits200JSONResponse AccountTransferLimits
+
+func (response SetAccountTransferLimits200JSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountTransferLimits400ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountTransferLimits401ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountTransferLimits403ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountTransferLimits404ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountTransferLimits409ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits412ApplicationProblemPlusJSONResponse Problem
+
+func (response SetAccountTransferLimits412ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAccountTransferLimits429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response SetAccountTransferLimits429ApplicationProblemPlusJSONResponse) VisitSetAccountTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccountTransfersRequestObject struct {
+	AccountId AccountId `json:"accountId"`
+}
+
+type GetAccountTransfersResponseObject interface {
+	VisitGetAccountTransfersResponse(w http.ResponseWriter) error
+}
+
+type GetAccountTransfers200JSONResponse []Transfer
+
+func (response GetAccountTransfers200JSONResponse) VisitGetAccountTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransfers401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransfers401ApplicationProblemPlusJSONResponse) VisitGetAccountTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransfers403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransfers403ApplicationProblemPlusJSONResponse) VisitGetAccountTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransfers404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetAccountTransfers404ApplicationProblemPlusJSONResponse) VisitGetAccountTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountTransfers429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAccountTransfers429ApplicationProblemPlusJSONResponse) VisitGetAccountTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCustomersRequestObject struct {
+}
+
+type GetCustomersResponseObject interface {
+	VisitGetCustomersResponse(w http.ResponseWriter) error
+}
+
+type GetCustomers200JSONResponse []Customer
+
+func (response GetCustomers200JSONResponse) VisitGetCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomers401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetCustomers401ApplicationProblemPlusJSONResponse) VisitGetCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomers403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetCustomers403ApplicationProblemPlusJSONResponse) VisitGetCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomers429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetCustomers429ApplicationProblemPlusJSONResponse) VisitGetCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateCustomerRequestObject struct {
+	Body *CreateCustomerJSONRequestBody
+}
+
+type CreateCustomerResponseObject interface {
+	VisitCreateCustomerResponse(w http.ResponseWriter) error
+}
+
+type CreateCustomer201JSONResponse Customer
+
+func (response CreateCustomer201JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCustomer400ApplicationProblemPlusJSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response CreateCustomer401ApplicationProblemPlusJSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response CreateCustomer403ApplicationProblemPlusJSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response CreateCustomer409ApplicationProblemPlusJSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response CreateCustomer429ApplicationProblemPlusJSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type StreamEventsRequestObject struct {
+	Params StreamEventsParams
+}
+
+type StreamEventsResponseObject interface {
+	VisitStreamEventsResponse(w http.ResponseWriter) error
+}
+
+type StreamEvents200TexteventStreamResponse struct {
+	EventStreamTexteventStreamResponse
+}
+
+func (response StreamEvents200TexteventStreamResponse) VisitStreamEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type StreamEvents401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response StreamEvents401ApplicationProblemPlusJSONResponse) VisitStreamEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamEvents403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response StreamEvents403ApplicationProblemPlusJSONResponse) VisitStreamEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamEvents429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response StreamEvents429ApplicationProblemPlusJSONResponse) VisitStreamEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetScreeningHitsRequestObject struct {
+	Params GetScreeningHitsParams
+}
+
+type GetScreeningHitsResponseObject interface {
+	VisitGetScreeningHitsResponse(w http.ResponseWriter) error
+}
+
+type GetScreeningHits200JSONResponse []ScreeningHit
+
+func (response GetScreeningHits200JSONResponse) VisitGetScreeningHitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScreeningHits400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetScreeningHits400ApplicationProblemPlusJSONResponse) VisitGetScreeningHitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScreeningHits401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetScreeningHits401ApplicationProblemPlusJSONResponse) VisitGetScreeningHitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScreeningHits403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetScreeningHits403ApplicationProblemPlusJSONResponse) VisitGetScreeningHitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScreeningHits429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetScreeningHits429ApplicationProblemPlusJSONResponse) VisitGetScreeningHitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ClearScreeningHitRequestObject struct {
+	HitId HitId `json:"hitId"`
+}
+
+type ClearScreeningHitResponseObject interface {
+	VisitClearScreeningHitResponse(w http.ResponseWriter) error
+}
+
+type ClearScreeningHit200JSONResponse ScreeningHit
+
+func (response ClearScreeningHit200JSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response ClearScreeningHit401ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response ClearScreeningHit403ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit404ApplicationProblemPlusJSONResponse Problem
+
+func (response ClearScreeningHit404ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit409ApplicationProblemPlusJSONResponse Problem
+
+func (response ClearScreeningHit409ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit422ApplicationProblemPlusJSONResponse Problem
+
+func (response ClearScreeningHit422ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearScreeningHit429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response ClearScreeningHit429ApplicationProblemPlusJSONResponse) VisitClearScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ConfirmScreeningHitRequestObject struct {
+	HitId HitId `json:"hitId"`
+}
+
+type ConfirmScreeningHitResponseObject interface {
+	VisitConfirmScreeningHitResponse(w http.ResponseWriter) error
+}
+
+type ConfirmScreeningHit200JSONResponse ScreeningHit
+
+func (response ConfirmScreeningHit200JSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmScreeningHit401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response ConfirmScreeningHit401ApplicationProblemPlusJSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmScreeningHit403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response ConfirmScreeningHit403ApplicationProblemPlusJSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmScreeningHit404ApplicationProblemPlusJSONResponse Problem
+
+func (response ConfirmScreeningHit404ApplicationProblemPlusJSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmScreeningHit409ApplicationProblemPlusJSONResponse Problem
+
+func (response ConfirmScreeningHit409ApplicationProblemPlusJSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmScreeningHit429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response ConfirmScreeningHit429ApplicationProblemPlusJSONResponse) VisitConfirmScreeningHitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTiersRequestObject struct {
+}
+
+type GetTiersResponseObject interface {
+	VisitGetTiersResponse(w http.ResponseWriter) error
+}
+
+type GetTiers200JSONResponse []TierTransferLimits
+
+func (response GetTiers200JSONResponse) VisitGetTiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTiers401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTiers401ApplicationProblemPlusJSONResponse) VisitGetTiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTiers403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTiers403ApplicationProblemPlusJSONResponse) VisitGetTiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTiers429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetTiers429ApplicationProblemPlusJSONResponse) VisitGetTiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetTierTransferLimitsRequestObject struct {
+	Tier Tier `json:"tier"`
+	Body *SetTierTransferLimitsJSONRequestBody
+}
+
+type SetTierTransferLimitsResponseObject interface {
+	VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error
+}
+
+type SetTierTransferLimits200JSONResponse TierTransferLimits
+
+func (response SetTierTransferLimits200JSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTierTransferLimits400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetTierTransferLimits400ApplicationProblemPlusJSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTierTransferLimits401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response SetTierTransferLimits401ApplicationProblemPlusJSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTierTransferLimits403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response SetTierTransferLimits403ApplicationProblemPlusJSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTierTransferLimits409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response SetTierTransferLimits409ApplicationProblemPlusJSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetTierTransferLimits429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response SetTierTransferLimits429ApplicationProblemPlusJSONResponse) VisitSetTierTransferLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTransfersRequestObject struct {
+	Params GetTransfersParams
+}
+
+type GetTransfersResponseObject interface {
+	VisitGetTransfersResponse(w http.ResponseWriter) error
+}
+
+type GetTransfers200JSONResponse []Transfer
+
+func (response GetTransfers200JSONResponse) VisitGetTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTransfers400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetTransfers400ApplicationProblemPlusJSONResponse) VisitGetTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTransfers401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTransfers401ApplicationProblemPlusJSONResponse) VisitGetTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTransfers403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTransfers403ApplicationProblemPlusJSONResponse) VisitGetTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTransfers429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetTransfers429ApplicationProblemPlusJSONResponse) VisitGetTransfersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ApproveTransferRequestObject struct {
+	TransferId TransferId `json:"transferId"`
+}
+
+type ApproveTransferResponseObject interface {
+	VisitApproveTransferResponse(w http.ResponseWriter) error
+}
+
+type ApproveTransfer200JSONResponse Transfer
+
+func (response ApproveTransfer200JSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response ApproveTransfer401ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response ApproveTransfer403ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer404ApplicationProblemPlusJSONResponse Problem
+
+func (response ApproveTransfer404ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer409ApplicationProblemPlusJSONResponse Problem
+
+func (response ApproveTransfer409ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer422ApplicationProblemPlusJSONResponse Problem
+
+func (response ApproveTransfer422ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveTransfer429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response ApproveTransfer429ApplicationProblemPlusJSONResponse) VisitApproveTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RejectTransferRequestObject struct {
+	TransferId TransferId `json:"transferId"`
+}
+
+type RejectTransferResponseObject interface {
+	VisitRejectTransferResponse(w http.ResponseWriter) error
+}
+
+type RejectTransfer200JSONResponse Transfer
+
+func (response RejectTransfer200JSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectTransfer401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response RejectTransfer401ApplicationProblemPlusJSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectTransfer403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response RejectTransfer403ApplicationProblemPlusJSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectTransfer404ApplicationProblemPlusJSONResponse Problem
+
+func (response RejectTransfer404ApplicationProblemPlusJSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectTransfer409ApplicationProblemPlusJSONResponse Problem
+
+func (response RejectTransfer409ApplicationProblemPlusJSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectTransfer429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response RejectTransfer429ApplicationProblemPlusJSONResponse) VisitRejectTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetWebhooksRequestObject struct {
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooks200JSONResponse []Webhook
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooks401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhooks401ApplicationProblemPlusJSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooks403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhooks403ApplicationProblemPlusJSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooks429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhooks429ApplicationProblemPlusJSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateWebhookRequestObject struct {
+	Body *CreateWebhookJSONRequestBody
+}
+
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(w http.ResponseWriter) error
+}
+
+type CreateWebhook201JSONResponse Webhook
+
+func (response CreateWebhook201JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhook400ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response CreateWebhook401ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response CreateWebhook403ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook404ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhook404ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response CreateWebhook409ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response CreateWebhook429ApplicationProblemPlusJSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteWebhookRequestObject struct {
+	WebhookId WebhookId `json:"webhookId"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhook204Response struct {
+}
+
+func (response DeleteWebhook204Response) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhook401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteWebhook401ApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteWebhook403ApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteWebhook404ApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteWebhook409ApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteWebhook429ApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetWebhookDeliveriesRequestObject struct {
+	WebhookId WebhookId `json:"webhookId"`
+	Params    GetWebhookDeliveriesParams
+}
+
+type GetWebhookDeliveriesResponseObject interface {
+	VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type GetWebhookDeliveries200JSONResponse []WebhookDelivery
+
+func (response GetWebhookDeliveries200JSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveries400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetWebhookDeliveries400ApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveries401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhookDeliveries401ApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveries403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhookDeliveries403ApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveries404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetWebhookDeliveries404ApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveries429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetWebhookDeliveries429ApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RedeliverWebhookDeliveryRequestObject struct {
+	WebhookId  WebhookId  `json:"webhookId"`
+	DeliveryId DeliveryId `json:"deliveryId"`
+}
+
+type RedeliverWebhookDeliveryResponseObject interface {
+	VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error
+}
+
+type RedeliverWebhookDelivery202JSONResponse WebhookDelivery
+
+func (response RedeliverWebhookDelivery202JSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response RedeliverWebhookDelivery401ApplicationProblemPlusJSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response RedeliverWebhookDelivery403ApplicationProblemPlusJSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery404ApplicationProblemPlusJSONResponse Problem
+
+func (response RedeliverWebhookDelivery404ApplicationProblemPlusJSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery409ApplicationProblemPlusJSONResponse Problem
+
+func (response RedeliverWebhookDelivery409ApplicationProblemPlusJSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response RedeliverWebhookDelivery429ApplicationProblemPlusJSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get all accounts
+	// (GET /accounts)
+	GetAccounts(ctx context.Context, request GetAccountsRequestObject) (GetAccountsResponseObject, error)
+	// Create a new account
+	// (POST /accounts)
+	CreateAccount(ctx context.Context, request CreateAccountRequestObject) (CreateAccountResponseObject, error)
+	// Get an account
+	// (GET /accounts/{accountId})
+	GetAccount(ctx context.Context, request GetAccountRequestObject) (GetAccountResponseObject, error)
+	// Update the details of an account
+	// (PATCH /accounts/{accountId})
+	UpdateAccount(ctx context.Context, request UpdateAccountRequestObject) (UpdateAccountResponseObject, error)
+	// Add balance to an account
+	// (POST /accounts/{accountId}/add-balance)
+	AddBalanceToAccount(ctx context.Context, request AddBalanceToAccountRequestObject) (AddBalanceToAccountResponseObject, error)
+	// Get the history of changes made to an account
+	// (GET /accounts/{accountId}/changes)
+	GetAccountChanges(ctx context.Context, request GetAccountChangesRequestObject) (GetAccountChangesResponseObject, error)
+	// Stream the activity of an account
+	// (GET /accounts/{accountId}/events)
+	StreamAccountEvents(ctx context.Context, request StreamAccountEventsRequestObject) (StreamAccountEventsResponseObject, error)
+	// Freeze or unfreeze an account
+	// (PUT /accounts/{accountId}/status)
+	SetAccountStatus(ctx context.Context, request SetAccountStatusRequestObject) (SetAccountStatusResponseObject, error)
+	// Transfer money to another account
+	// (POST /accounts/{accountId}/transfer)
+	TransferMoney(ctx context.Context, request TransferMoneyRequestObject) (TransferMoneyResponseObject, error)
+	// Get the transfer limits of an account
+	// (GET /accounts/{accountId}/transfer-limits)
+	GetAccountTransferLimits(ctx context.Context, request GetAccountTransferLimitsRequestObject) (GetAccountTransferLimitsResponseObject, error)
+	// Set the tier of an account and the limits overriding the ones of its tier
+	// (PUT /accounts/{accountId}/transfer-limits)
+	SetAccountTransferLimits(ctx context.Context, request SetAccountTransferLimitsRequestObject) (SetAccountTransferLimitsResponseObject, error)
+	// Get the transfers made from an account, with the decisions of the risk rules
+	// (GET /accounts/{accountId}/transfers)
+	GetAccountTransfers(ctx context.Context, request GetAccountTransfersRequestObject) (GetAccountTransfersResponseObject, error)
+	// Get all customers
+	// (GET /customers)
+	GetCustomers(ctx context.Context, request GetCustomersRequestObject) (GetCustomersResponseObject, error)
+	// Create a new customer
+	// (POST /customers)
+	CreateCustomer(ctx context.Context, request CreateCustomerRequestObject) (CreateCustomerResponseObject, error)
+	// Stream the activity of all the accounts
+	// (GET /events)
+	StreamEvents(ctx context.Context, request StreamEventsRequestObject) (StreamEventsResponseObject, error)
+	// List the names that matched the sanctions list
+	// (GET /screening-hits)
+	GetScreeningHits(ctx context.Context, request GetScreeningHitsRequestObject) (GetScreeningHitsResponseObject, error)
+	// Clear a pending hit as a false positive
+	// (POST /screening-hits/{hitId}/clear)
+	ClearScreeningHit(ctx context.Context, request ClearScreeningHitRequestObject) (ClearScreeningHitResponseObject, error)
+	// Confirm a pending hit as a true match
+	// (POST /screening-hits/{hitId}/confirm)
+	ConfirmScreeningHit(ctx context.Context, request ConfirmScreeningHitRequestObject) (ConfirmScreeningHitResponseObject, error)
+	// List the account tiers having transfer limits
+	// (GET /transfer-limits/tiers)
+	GetTiers(ctx context.Context, request GetTiersRequestObject) (GetTiersResponseObject, error)
+	// Set the transfer limits of the accounts of a tier
+	// (PUT /transfer-limits/tiers/{tier})
+	SetTierTransferLimits(ctx context.Context, request SetTierTransferLimitsRequestObject) (SetTierTransferLimitsResponseObject, error)
+	// List the transfers, for example the ones pending approval
+	// (GET /transfers)
+	GetTransfers(ctx context.Context, request GetTransfersRequestObject) (GetTransfersResponseObject, error)
+	// Approve a transfer pending approval
+	// (POST /transfers/{transferId}/approve)
+	ApproveTransfer(ctx context.Context, request ApproveTransferRequestObject) (ApproveTransferResponseObject, error)
+	// Reject a transfer pending approval, releasing its held amount
+	// (POST /transfers/{transferId}/reject)
+	RejectTransfer(ctx context.Context, request RejectTransferRequestObject) (RejectTransferResponseObject, error)
+	// List the webhooks
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// Subscribe a URL to the events of accounts
+	// (POST /webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
+	// Delete a webhook along with its deliveries
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+	// Get the delivery history of a webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	GetWebhookDeliveries(ctx context.Context, request GetWebhookDeliveriesRequestObject) (GetWebhookDeliveriesResponseObject, error)
+	// Deliver an event again
+	// (POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver)
+	RedeliverWebhookDelivery(ctx context.Context, request RedeliverWebhookDeliveryRequestObject) (RedeliverWebhookDeliveryResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetAccounts operation middleware
+func (sh *strictHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
+	var request GetAccountsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccounts(ctx, request.(GetAccountsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccounts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountsResponseObject); ok {
+		if err := validResponse.VisitGetAccountsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAccount operation middleware
+func (sh *strictHandler) CreateAccount(w http.ResponseWriter, r *http.Request, params CreateAccountParams) {
+	var request CreateAccountRequestObject
+
+	request.Params = params
+
+	var body CreateAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAccount(ctx, request.(CreateAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAccountResponseObject); ok {
+		if err := validResponse.VisitCreateAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccount operation middleware
+func (sh *strictHandler) GetAccount(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	var request GetAccountRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccount(ctx, request.(GetAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountResponseObject); ok {
+		if err := validResponse.VisitGetAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateAccount operation middleware
+func (sh *strictHandler) UpdateAccount(w http.ResponseWriter, r *http.Request, accountId AccountId, params UpdateAccountParams) {
+	var request UpdateAccountRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	var body UpdateAccountApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAccount(ctx, request.(UpdateAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateAccountResponseObject); ok {
+		if err := validResponse.VisitUpdateAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddBalanceToAccount operation middleware
+func (sh *strictHandler) AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64, params AddBalanceToAccountParams) {
+	var request AddBalanceToAccountRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	var body AddBalanceToAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddBalanceToAccount(ctx, request.(AddBalanceToAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddBalanceToAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddBalanceToAccountResponseObject); ok {
+		if err := validResponse.VisitAddBalanceToAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccountChanges operation middleware
+func (sh *strictHandler) GetAccountChanges(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	var request GetAccountChangesRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountChanges(ctx, request.(GetAccountChangesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountChanges")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountChangesResponseObject); ok {
+		if err := validResponse.VisitGetAccountChangesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StreamAccountEvents operation middleware
+func (sh *strictHandler) StreamAccountEvents(w http.ResponseWriter, r *http.Request, accountId AccountId, params StreamAccountEventsParams) {
+	var request StreamAccountEventsRequestObject
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	request.AccountId = accountId
+	request.Params = params
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamAccountEvents(ctx, request.(StreamAccountEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamAccountEvents")
+	}
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type RequiredParamError struct {
-	ParamName string
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamAccountEventsResponseObject); ok {
+		if err := validResponse.VisitStreamAccountEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// SetAccountStatus operation middleware
+func (sh *strictHandler) SetAccountStatus(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountStatusParams) {
+	var request SetAccountStatusRequestObject
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	request.AccountId = accountId
+	request.Params = params
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	var body SetAccountStatusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetAccountStatus(ctx, request.(SetAccountStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetAccountStatus")
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetAccountStatusResponseObject); ok {
+		if err := validResponse.VisitSetAccountStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+// TransferMoney operation middleware
+func (sh *strictHandler) TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64, params TransferMoneyParams) {
+	var request TransferMoneyRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	var body TransferMoneyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.TransferMoney(ctx, request.(TransferMoneyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TransferMoney")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(TransferMoneyResponseObject); ok {
+		if err := validResponse.VisitTransferMoneyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+// GetAccountTransferLimits operation middleware
+func (sh *strictHandler) GetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	var request GetAccountTransferLimitsRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountTransferLimits(ctx, request.(GetAccountTransferLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountTransferLimits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountTransferLimitsResponseObject); ok {
+		if err := validResponse.VisitGetAccountTransferLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+// SetAccountTransferLimits operation middleware
+func (sh *strictHandler) SetAccountTransferLimits(w http.ResponseWriter, r *http.Request, accountId AccountId, params SetAccountTransferLimitsParams) {
+	var request SetAccountTransferLimitsRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	var body SetAccountTransferLimitsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetAccountTransferLimits(ctx, request.(SetAccountTransferLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetAccountTransferLimits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetAccountTransferLimitsResponseObject); ok {
+		if err := validResponse.VisitSetAccountTransferLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+// GetAccountTransfers operation middleware
+func (sh *strictHandler) GetAccountTransfers(w http.ResponseWriter, r *http.Request, accountId AccountId) {
+	var request GetAccountTransfersRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountTransfers(ctx, request.(GetAccountTransfersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountTransfers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountTransfersResponseObject); ok {
+		if err := validResponse.VisitGetAccountTransfersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// GetCustomers operation middleware
+func (sh *strictHandler) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	var request GetCustomersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCustomers(ctx, request.(GetCustomersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCustomers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCustomersResponseObject); ok {
+		if err := validResponse.VisitGetCustomersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+// CreateCustomer operation middleware
+func (sh *strictHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var request CreateCustomerRequestObject
+
+	var body CreateCustomerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCustomer(ctx, request.(CreateCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCustomerResponseObject); ok {
+		if err := validResponse.VisitCreateCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+// StreamEvents operation middleware
+func (sh *strictHandler) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	var request StreamEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamEvents(ctx, request.(StreamEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamEventsResponseObject); ok {
+		if err := validResponse.VisitStreamEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetScreeningHits operation middleware
+func (sh *strictHandler) GetScreeningHits(w http.ResponseWriter, r *http.Request, params GetScreeningHitsParams) {
+	var request GetScreeningHitsRequestObject
 
-	if r == nil {
-		r = chi.NewRouter()
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetScreeningHits(ctx, request.(GetScreeningHitsRequestObject))
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetScreeningHits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetScreeningHitsResponseObject); ok {
+		if err := validResponse.VisitGetScreeningHitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ClearScreeningHit operation middleware
+func (sh *strictHandler) ClearScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId) {
+	var request ClearScreeningHitRequestObject
+
+	request.HitId = hitId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ClearScreeningHit(ctx, request.(ClearScreeningHitRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ClearScreeningHit")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/accounts", wrapper.GetAccounts)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/accounts", wrapper.CreateAccount)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/accounts/{accountId}/add-balance", wrapper.AddBalanceToAccount)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/accounts/{accountId}/transfer", wrapper.TransferMoney)
-	})
+	response, err := handler(r.Context(), w, r, request)
 
-	return r
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ClearScreeningHitResponseObject); ok {
+		if err := validResponse.VisitClearScreeningHitResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetAccountsRequestObject struct {
-}
+// ConfirmScreeningHit operation middleware
+func (sh *strictHandler) ConfirmScreeningHit(w http.ResponseWriter, r *http.Request, hitId HitId) {
+	var request ConfirmScreeningHitRequestObject
 
-type GetAccountsResponseObject interface {
-	VisitGetAccountsResponse(w http.ResponseWriter) error
-}
+	request.HitId = hitId
 
-type GetAccounts200JSONResponse []Account
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmScreeningHit(ctx, request.(ConfirmScreeningHitRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmScreeningHit")
+	}
 
-func (response GetAccounts200JSONResponse) VisitGetAccountsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ConfirmScreeningHitResponseObject); ok {
+		if err := validResponse.VisitConfirmScreeningHitResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateAccountRequestObject struct {
-	Body *CreateAccountJSONRequestBody
-}
+// GetTiers operation middleware
+func (sh *strictHandler) GetTiers(w http.ResponseWriter, r *http.Request) {
+	var request GetTiersRequestObject
 
-type CreateAccountResponseObject interface {
-	VisitCreateAccountResponse(w http.ResponseWriter) error
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTiers(ctx, request.(GetTiersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTiers")
+	}
 
-type CreateAccount201Response struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (response CreateAccount201Response) VisitCreateAccountResponse(w http.ResponseWriter) error {
-	w.WriteHeader(201)
-	return nil
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTiersResponseObject); ok {
+		if err := validResponse.VisitGetTiersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type AddBalanceToAccountRequestObject struct {
-	AccountId int64 `json:"accountId"`
-	Body      *AddBalanceToAccountJSONRequestBody
-}
+// SetTierTransferLimits operation middleware
+func (sh *strictHandler) SetTierTransferLimits(w http.ResponseWriter, r *http.Request, tier Tier) {
+	var request SetTierTransferLimitsRequestObject
 
-type AddBalanceToAccountResponseObject interface {
-	VisitAddBalanceToAccountResponse(w http.ResponseWriter) error
-}
+	request.Tier = tier
 
-type AddBalanceToAccount200Response struct {
-}
+	var body SetTierTransferLimitsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response AddBalanceToAccount200Response) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetTierTransferLimits(ctx, request.(SetTierTransferLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetTierTransferLimits")
+	}
 
-type AddBalanceToAccount400Response struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (response AddBalanceToAccount400Response) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetTierTransferLimitsResponseObject); ok {
+		if err := validResponse.VisitSetTierTransferLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type AddBalanceToAccount404Response struct {
-}
+// GetTransfers operation middleware
+func (sh *strictHandler) GetTransfers(w http.ResponseWriter, r *http.Request, params GetTransfersParams) {
+	var request GetTransfersRequestObject
 
-func (response AddBalanceToAccount404Response) VisitAddBalanceToAccountResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	request.Params = params
 
-type TransferMoneyRequestObject struct {
-	AccountId int64 `json:"accountId"`
-	Body      *TransferMoneyJSONRequestBody
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTransfers(ctx, request.(GetTransfersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTransfers")
+	}
 
-type TransferMoneyResponseObject interface {
-	VisitTransferMoneyResponse(w http.ResponseWriter) error
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type TransferMoney200Response struct {
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTransfersResponseObject); ok {
+		if err := validResponse.VisitGetTransfersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response TransferMoney200Response) VisitTransferMoneyResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+// ApproveTransfer operation middleware
+func (sh *strictHandler) ApproveTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId) {
+	var request ApproveTransferRequestObject
 
-type TransferMoney400JSONResponse ErrorResponse
+	request.TransferId = transferId
 
-func (response TransferMoney400JSONResponse) VisitTransferMoneyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveTransfer(ctx, request.(ApproveTransferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveTransfer")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get all accounts
-	// (GET /accounts)
-	GetAccounts(ctx context.Context, request GetAccountsRequestObject) (GetAccountsResponseObject, error)
-	// Create a new account
-	// (POST /accounts)
-	CreateAccount(ctx context.Context, request CreateAccountRequestObject) (CreateAccountResponseObject, error)
-	// Add balance to an account
-	// (POST /accounts/{accountId}/add-balance)
-	AddBalanceToAccount(ctx context.Context, request AddBalanceToAccountRequestObject) (AddBalanceToAccountResponseObject, error)
-	// Transfer money to another account
-	// (POST /accounts/{accountId}/transfer)
-	TransferMoney(ctx context.Context, request TransferMoneyRequestObject) (TransferMoneyResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveTransferResponseObject); ok {
+		if err := validResponse.VisitApproveTransferResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// RejectTransfer operation middleware
+func (sh *strictHandler) RejectTransfer(w http.ResponseWriter, r *http.Request, transferId TransferId) {
+	var request RejectTransferRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.TransferId = transferId
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RejectTransfer(ctx, request.(RejectTransferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RejectTransfer")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RejectTransferResponseObject); ok {
+		if err := validResponse.VisitRejectTransferResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// GetAccounts operation middleware
-func (sh *strictHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
-	var request GetAccountsRequestObject
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request GetWebhooksRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAccounts(ctx, request.(GetAccountsRequestObject))
+		return sh.ssi.GetWebhooks(ctx, request.(GetWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAccounts")
+		handler = middleware(handler, "GetWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetAccountsResponseObject); ok {
-		if err := validResponse.VisitGetAccountsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -496,11 +4961,11 @@ func (sh *strictHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateAccount operation middleware
-func (sh *strictHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
-	var request CreateAccountRequestObject
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request CreateWebhookRequestObject
 
-	var body CreateAccountJSONRequestBody
+	var body CreateWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -508,18 +4973,18 @@ func (sh *strictHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateAccount(ctx, request.(CreateAccountRequestObject))
+		return sh.ssi.CreateWebhook(ctx, request.(CreateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateAccount")
+		handler = middleware(handler, "CreateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateAccountResponseObject); ok {
-		if err := validResponse.VisitCreateAccountResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -527,32 +4992,25 @@ func (sh *strictHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AddBalanceToAccount operation middleware
-func (sh *strictHandler) AddBalanceToAccount(w http.ResponseWriter, r *http.Request, accountId int64) {
-	var request AddBalanceToAccountRequestObject
-
-	request.AccountId = accountId
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookId) {
+	var request DeleteWebhookRequestObject
 
-	var body AddBalanceToAccountJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AddBalanceToAccount(ctx, request.(AddBalanceToAccountRequestObject))
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AddBalanceToAccount")
+		handler = middleware(handler, "DeleteWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AddBalanceToAccountResponseObject); ok {
-		if err := validResponse.VisitAddBalanceToAccountResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -560,32 +5018,53 @@ func (sh *strictHandler) AddBalanceToAccount(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// TransferMoney operation middleware
-func (sh *strictHandler) TransferMoney(w http.ResponseWriter, r *http.Request, accountId int64) {
-	var request TransferMoneyRequestObject
+// GetWebhookDeliveries operation middleware
+func (sh *strictHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookId, params GetWebhookDeliveriesParams) {
+	var request GetWebhookDeliveriesRequestObject
 
-	request.AccountId = accountId
+	request.WebhookId = webhookId
+	request.Params = params
 
-	var body TransferMoneyJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhookDeliveries(ctx, request.(GetWebhookDeliveriesRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhookDeliveries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetWebhookDeliveriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RedeliverWebhookDelivery operation middleware
+func (sh *strictHandler) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookId, deliveryId DeliveryId) {
+	var request RedeliverWebhookDeliveryRequestObject
+
+	request.WebhookId = webhookId
+	request.DeliveryId = deliveryId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.TransferMoney(ctx, request.(TransferMoneyRequestObject))
+		return sh.ssi.RedeliverWebhookDelivery(ctx, request.(RedeliverWebhookDeliveryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "TransferMoney")
+		handler = middleware(handler, "RedeliverWebhookDelivery")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(TransferMoneyResponseObject); ok {
-		if err := validResponse.VisitTransferMoneyResponse(w); err != nil {
+	} else if validResponse, ok := response.(RedeliverWebhookDeliveryResponseObject); ok {
+		if err := validResponse.VisitRedeliverWebhookDeliveryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -596,23 +5075,138 @@ func (sh *strictHandler) TransferMoney(w http.ResponseWriter, r *http.Request, a
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9SWTW/cNhPHvwoxz3NoAWVXfisCAT2s06Iw0BZB6qCH1ChmxVktU5GUyaE3C2O/e0G9",
-	"WSvJsZ2mh55WC5Gc+f/nxxndQ251ZQ0Z9pDdg8+3pLF+XOW5DYbjY+VsRY4V1S/WWKLJKT5K8rlTFStr",
-	"IIM3wTkyLNoFwm4Eb0lge1AC9Al1VRJkJ2maLi4S2FinkSEDacO6JEhAK6N00JClCfC+IsjABL0mB4cE",
-	"ckfIJP9Enga/Vpo8o67EbktmGFjs0It2KwxjItMrVjqGbUN5dsoUMZSS0xDvjboNJJQkw2qjyImNdY9K",
-	"HERShr87f4iiDFPRKDKoZ4z8FfXYPbG1pSQ3jAArpVGK362ValZDqOSX2lWiZ9Huf6ZnhwQc3QblSEL2",
-	"IRrYykt6Yo4qeJTfTX+cXX+knGP6Kykvm43v6DaQn0ERdYfoSF2UU78TbAVKGX+GGh8yGjL5FJKL9GRC",
-	"5Uh2m9Gcnje19vZaPSrpaxKh8dPPZAreQnZ6cVEr6f6fPFW/Oo85GT86Z9078pU1nqb5a/IeixkJK5EH",
-	"z1YLigeIdp1oFq2VKcRuiyx2sYPsnDXFkbIrc4elksI1vmVdeXXwLNYkitrceBvRiPRJOrss5wReOzR+",
-	"Q+4fUsftMR16jK4gnmsVF+nLsUugOa/F6UrOp3P1Q0fNcfiYlKOc1B01b9tkh2mdPqODzdM/TW7qc9yq",
-	"zMbOgPL2Snhyd21/1WiwiHis0fzV5e8XMYjimo3fgn5ficv4evX2ChK4I+ebs9JFujiJbtmKDFYKMjhb",
-	"pIszSKBC3tblXHZnxj8F1YWN5caYTzQWfuqleIiCG/br9adpGn9ya5gaJrCqSpXXm5cffcyiG6vxSTHp",
-	"euP/HW0gg/8tHwbwsp2+y270HnrX0DncN6aNb1WpPMci9yriIh+0RrdvchdYloPXCVTWz6g86k/QFJY8",
-	"X1q5f5HEzymb7YGHY4zYBTpMbD6ZAaVluR0qwoc8J+83oSz3IxeauAKFoV1/BeOSvvjLe+xoPSxRyleD",
-	"z5x5vx7m07V9cK1Ch5qYnIfsw+ev5OAuxhGlraG9YAvxYkBWA9rN0Az67GDsVTLw/ukLe/PvFHY6rJ9V",
-	"1XRa1V9qG1DKSUUTOJ/bMBoN4htaFIuk68R/hDQ9y78X6bfNAeePc2Qsi40NRo7gWUnZf9PGWplnENQ3",
-	"1Efx6cZMrfdl4HgbXH7ETz9sGog2zur/JEbj2fulEHXniBijJH6cpa+S9vE30UybHjE6Auz6uHo1Y5a3",
-	"5AagxQ31UGzwCK6EDLbMVbZcljbHcms9Z6/T1+kyzrnDzeHvAAAA//9ETr9V3A0AAA==",
+	"H4sIAAAAAAAC/+x9a3MbN7LoX0HNPVUnqTukaNnOQ1Xng2MnJ8pj47Xk660b5krgTJPEagbgAqAkrq/+",
+	"+6nGazBDDB+SLSuO9sNG5swAjUa/u9F4nxWiXggOXKvs6H02B1qCNH9+f0pn+N8SVCHZQjPBs6Ps/4BU",
+	"THAipkTPgdCiEEuuc6IFUcBLMqHFBWGcHE8Hv1JdzMnVHDipRcmmK8ZnhOksz1Qxh5ri4Hq1gOwoU1oy",
+	"PstubvLsDdXwC6uZHpj/X4fgb8t6AhIBkPCvJSitDCRFxYBrUlBOanoBCAMlk6VUOkfINBGcwCXIFZGg",
+	"FoIrsKBJqoFUOJUiVAIBTicVlCkoGdcwA9kB8w3UlHEE/xagKs2qygIs2WyuCb2iq33mVpBA0QkUgpeK",
+	"LLlmVRI7lEyXVWXx04KPzijjmwG4ybMFlbQG7SjlhSWC43IdktM5kONXHWrJ8ozhwwXV8yzPOK1xAhpG",
+	"yTMEh0kosyMtlxBDMxWyptrC89WzLE/h5xVUDHd6O0BXMJkLcUFK90UasrIZ766g/ch2QJMqJABSFJmz",
+	"HmTN2YdA1HEJ9UJo4MXqZ1itg/WWs38tgVzAyoPm6CQnMJwNCSVv3x6/ykkFWiO4EaVJ0HJFmCaKTqFa",
+	"HZlnU4bk5sYYcxQ9FZgvr5iem1dwrjlVBNkxMKqil1DmhPLSvMPhWjcU65FGayCFhBK4ZrRSYcgxxzFn",
+	"oImes2hQI6nwJbHUZAIIxUKKApSC0rLBkLyBpfILw1GmQhJKSjadggQegCBMjbmE6RI/NfNS8uzwcDjm",
+	"fvesXG32L8L8AFEf71xNr38BPtPz7Ojw+fM8qxn3/36SJyTm8dRI2vXt+41XK0IXi2pld2ZO+QwIa/Fi",
+	"kEC6mIOyKDKSvw9wJ9e3SPFfqNLfX0JaKLwBtazB7pqWQGtCpxqknRzwq5wI7qDmcGV/s+LZfgClFd5c",
+	"aKJA9wGLUAwMGIPjV9m+3HHKQKZZFYfvyDSiGUgPR5tX3ZN+Vl1QrUHih//vdzr492jw7dngj/dP8q+e",
+	"3fxHltrxU0m5moLcLkq0e7MHsmacu4qSd1aS7ixy0wBdhVHuBs9Nnnk+NxrqpeDTihVGVRaCa+DmT+QN",
+	"VlCE82AhxaSC+n//UyHQ76Pp/kPCNDvK/tdBYyod2Kfq4LX9yk65vmwJSixlAYpcgQRrBjEoyWRFCsGL",
+	"pYyliMpjEWt09QSMIGVQZjd5Zij5xDBAZyEarvWB4ZKBCs/7+TMJq2NFMXXslhOgxdyKM5TGrMzNf3Ew",
+	"Qh2jWlbwgvmnk9/+RmpQis6ALJaTiqk5lGgb4lP7gWL8QuH3JdV0zHFdPwg5YWUJ/L63J1YXFaoDSlQh",
+	"FkA87eFGIehiAdLAkRMhCRc8sL8UFQQdVNCqAkmYIrSqxBWUY66F+ZUwbdfaVrmoX6C872V3lA+5ogiw",
+	"BFquiFFhPWoO4T8V4lfKV2/sL+red8xaF1f4f+ISpLUTggmf5bEHk3AlUlO7Lw66r/db+buN0nySttl3",
+	"HQVfxxHQohq8QE3Zb/FrQa4oQ3tmKiSsWUsRaW6x8W/y7C2nSz0Xkv37fkn0V6bQ6MoJ45e0QrED1wvD",
+	"jUISCZfiAsqYdY3+ceNG7gj+uZDIuZpZLTChFeUFrGPvpRPE7oV1dwWuKZqq2dGT0Wg0fJ43KqgUy0kF",
+	"mbHSWL2ss6NR0EfcuH+4d4UEqqE8owln7ZTVoDStF9akiSY2jOk+zeI5qYaBZjWsGwdI/1V51rtS5KEF",
+	"ldov0a8YvzJsHxsNiiyAl2j/0sVCiktaoQZAxfSfSGFELaCNndHeiGFlr9fBzP5OGcgAWGpD8u3mQJ5V",
+	"dAKVoQFalgznodXrFm2s4bHDX3MhNboAB5e0WiIOmVRWWGpBZlIsF0YLTlmlQXpAVQzp+0zBrDYMlEnQ",
+	"lFU4jZtXTP4JhWHzGjRF1XgHYH+QAAPECinNPIpQrWkRqeIEIt9nhazPcD+yl4Mno2eHSeisnbYW50jY",
+	"w3NRWVs8zJC9YDUtyTshSpYkXXHFQZ6xHhOyWCotapBEXHHvk4Xwk3EXaFkzrozhRAt05MI+BD9PcNhG",
+	"PXxZVRgC8nbnOjUpTfVSbZNwTgyd2JdvcusHJJeGT4iKnGjPgT4w1S+QEBheUlmmELpclLcVOxVVmrjv",
+	"d5Y9lzY4uD7ZMS8kIO1D6YJwOERrUqai6cLynuY7eR+Nr/B7xkrvT+RB4nfEYtjCBuSI8YK4yL3rFonv",
+	"FlL/SHCI2/aXxtle10G00CJBBe/mgtS0hMhRb+0y5YKvamFAXkO7fd9v825bNWVQ9TGaHY6YV3Li8WLk",
+	"m8UMCkLrji8kTNm1j3qMAxKH48y8P3a4HI6z1no4TYPFytYS+iU6h6szI4oTIWr8OYQU/HpygozdELtZ",
+	"nSF1CbW4NGTXw/oNeKIqN88a2V2bpi1ZiToUrpnS2+dNEbilI7+PLRrYQJUnQXJ1NYb4N/BGXKII5cD0",
+	"3MglXhJuTK8C2GVkHmR5Bhw1++8IDrsEhMeMlP2xtogAgw9eGPNWrXMITKdgR9siYDsD4fZcgpSsBLX/",
+	"p1uE860EcGffnDBpgMyjtSY3rSy/sxLLuVsJcVJ7U3cdcPsMVT4tu5qfNKIwtm23mbbD0ZM1I66zSgdR",
+	"aj0vjRh1ZNC7pA9pYuwVSv2gBogCTZhFPheGj/zn+1qvHfwa9PRj96WbpRe9cK1Bclq5ZUYqZqnno///",
+	"fPp18Q0UXxdPnxZfFaPRs8mETuGbw/2R6ffxw2zOflhw0ch+trHbdsZK1RM2dzmfJnDljTAVNl3l6Et7",
+	"MgCl2KSCyOREkQ/1QpuskoZa7aja3C9USrrCf5vpz/BXM0IYapOAM6HCUxzoxiD22H70ZH10BYWEHgli",
+	"nxHFZoHgHV4Y4OqJpLwUNREc0H6bAQdJ9XpwftNOf5WyW2WVBmiu9QKjAPhfRd6++SXeH7RHXv92cmrc",
+	"nJZUMK8fHRyg58tBDt2TYSHqA6QTdaAZXw0mlF90oB09+2YbKSKw7U1K0qXn/jVabMcGdrPeOkzc8VWX",
+	"ZtYQlPRii2rzb+tX6xVmuy5ZCTJ32R+X9VL2tQlQifQvLoCrLN9HUGw1ozqiZzcffqs82cFqcmZnhPLU",
+	"XjXMkyTCUmBcz1Pd5jA3L33CI7aWvI8QQjtOzb8oS/NPb568tAlS85v75od+++oHNAW/l1IkiGyDuR9y",
+	"+Sa2bbZelCtnqDJOSqGRpbnQLgJ+yURFg6uqFlC0yaN2JtIagC4z0N5Ea0aQeqlMTIlqUgH6nmhrbN1U",
+	"b/36kdd2M8+uBzMxcD+6UOUwwlT0woDVCyG1y8jNTebOSYUBXbCDxcXMBzsNID6EuZ7f/OEl+fqb0dfE",
+	"ve3DMHmDXZtlkSsCCEVISg/JSxPgVkTNxbIqyURSXsyJ4OS8ECWc5zajfW5HPEepWwPl2oTI5suackt0",
+	"NV05B8QmoTsiR5QJ2v6VFnPGYSCBlsi+5ILxEiF1yzga8wE5d0HZMxdRPj9qJa1KAQo9GyNRAn0gYU0k",
+	"UOQIIpdVsORCXsUMvYwCzudHpN4zDmzGmPpckgPM5HOUp+x2tqb5lJQCoTah8QRgivIC/6HOJpUoLgx0",
+	"1Ka+vOg0c/nXSMWwvmFAzrnQZ1Ox5GXAk00IBkQZFxDBY+afl8xaEVp0QTTDebslHpYGv41IMAmboklc",
+	"dffFTGeGqkHPRWlGchkBByLSfnhfLRfIE+aB/cJ8XLh8andVdhGm9EppqsFi1IuKdhYNXe+QEG2yodXK",
+	"zLCQJqNhwp9nU8oqWEOhj1MoxgvrcrtgDpmxS+BxFZoZ0vutZ65Sw43nPCHLnDYu5MklGX1bJxY/cHuW",
+	"EoqK8QZspi4M7Svin6x/zJrM3NkFrM4kRIDeNm1nRpZUw5lZTxhvSy7NcbszNIyc8jwFEl+3u4KkOqe8",
+	"rCAmuDGP1F1HZGR5FnO6jS5aps3ybI3XsjwL5G4CHx0WsKG7Di1neeZp1Ei/NWLK8qxLD/FPfosyNFZS",
+	"e5LlWYxSfK+FKlRFjZJbx0AihG9SAonIIHV7dCUFFlByWycjCsswbR/ez+T34chFAY7Ifno2z8wqVNpk",
+	"cBaA4IE3jJRvy5w8pG2i4keqHfdYBWHL23ZxaCKVnXCR3AxJc/i47BSvWRQCqcRMhdIFuiyZxp/ajoNQ",
+	"+oBOihKmgxH+L4kq1RNVQ1T9eHr62ksVVLsNLFbjx9M9G42S/iDTVUJd25SUWtY1laE+r6Oy86Y0DjfD",
+	"mhw9lHPcppzUQnXSJn775tgn61ahWK4Lx/lS8qNgTx2534/OyVRYjvXUg0hqwZX+cCtHdYNv+NTjMor/",
+	"O7bLrU20o/3oLb87GI9vmLp4BQVL50tOQwZ2WtHZzMl2CZcMroyfa/K13eysq/elprRxIKZTVgBqBoka",
+	"h5WmvA/qSDAbYZnlmR3YYIOvkr4Fgvs9Rr6pdgC3LcoyWsomNm4t22wRdWUDXQeCPDnEXPtoZGoWvLR6",
+	"MrI/pqgTlWt7pIrKGQz6HJIOhZjP82YlAbiUi3jiS3R/ZPrDuPR2izZ+s9Wv9mNMVh2MLtgFrI6e7TIG",
+	"cC1XZ+vO9qvfvs/JT2LOs96Pln2R0yULMtjIfSRa80lTvBtbzi32f/pV9qEiCM5WP0tHmJvCTkloxWjQ",
+	"bRZSo7vcCFm+K2KCyZueLzwm2s+OVp0tAIeSCN6aqogD6ElNVAjZszTFalZRiYEfMQ3TqZxMpajJCM24",
+	"J61ajuG3yWTAWgmHBzZBMz8JTl4J2KwzN4mLmM2aRLqyAa7eQL13h9D8cIK0m4ZrEG8zgLZ022XvDJrD",
+	"YZW7Fgs4YL3ubDJmYRcdjNkf20SUiWG1BowprLsVMVu2+LrDCZ5sIq24JUCW2Ja1jXjtlNMcvQpTk4Yq",
+	"zJT8M1NypDSdTnNyNRekqICiP1EpIAuhGGbErGmGRjyTNUHs+iL1IfnOegZjbgcH3LNKKCDAxXI2R1q2",
+	"W9rZ6+aIzbDloDhFigtHSIL7wGRt/vaeSEoznoBuJVd7cw63qhrpEIAbI7knAY52crMXnk+SLF1IqNmy",
+	"zvL9i943plJTGMH6/W3J5ir8fjsM3D4N7GZOAu6m25TuDRM/H+0kpe9ikkxWPSqlk2iI4mlGW1q71Mfr",
+	"8NVOzKNFGv+80ke0YsVOlo6NBKpkZdM7L+T9NC5uqPDoCzOVRvbQS9d+7qtz+jj5DOe53AG/YYR+pDrL",
+	"7zBpLzN1cXZrwx0/huAR9Hi/UdArtp9a8GJ8lzQjESFtUn+nsEDHL0mEBmyw8KzJ+N5iq3YT3Z5to4o/",
+	"9D9039yHt6xtW19Raqa8ScQEtd7e8cQmdqhyqyGwLl67p4UrUaDJ2Y6kNgXGpubNWKBNGDv3kX//lYu3",
+	"4kG2IWkqW2zGVwttouMmgilFVdnjhLwUV2a+w2dkLpZS5eRrUtKVNSuejszfqdxISVm1OttUV1PTayyH",
+	"CfU1bjEmMeFLBkz5pJ+6W0C+f41N7sAqtkPFw5njBsu7gJUgxgDTk7Q3db0PmsQUkwKMz6q0qHp+O7zU",
+	"guv5h9owRxbd/boVYFcAFx8Krq/XwHp+K6huNrDwHUvMgq51mSsrkFI24PNbodOOt/NJ8/b01iFw1Ys9",
+	"qvIW0rgJLHWA2yQq+/ylVyEpFNjWODYlcBbl84JCzQkXpBYcVsSUrw7Jae+xDQK8JMtFOHENZR6SUHlj",
+	"mk1Wqdih8PZTcgoXk4wU+5i3ozkmBr0ystr5UY0b7rIAigRvq+2YFVEBRJSScZOfRYabX0TmbcP4vRDf",
+	"3OTIvTV13duqE7efJNkhShfv+y9mQNs/Q9sSDOFKki2ecLzslgdF9oTlVzekre/eH6RPVrqZEmyuBnBr",
+	"8d9dSvNu41X5b3qCtE9T39y5BLAL+K3M4B3LY01Exy4yPvHtQnA+Keh+NTZaV+ao7HahttuXMpryXQl6",
+	"KXksnxyQRkKFYq1my67mCoqz5z9NR1V9+es39d8P/z55Ovv5UP3fb+p335b/uHi2+Onr66y/wvG2JYo7",
+	"hAnXyxLzFuW3CHGroe+YyXdWSTCV1lAvdI8f6J9ae78p2PCdVZojR/9awrKN5aTxebtwhpnsjjkWi9EO",
+	"/zw73ImBmt3Yi3lvxayIzTOH9zst2AwEvqwwystyuF5Yw8Hlt5+PRjsP6PPfZ7smz50CMUTilhUH9bmI",
+	"+jpR5c29cs3F2C5GOFx/GMwt6KoStOxX0Pa79XWbEsFQymzWjbXOax001th0t0BFh5mbeIUb+TZBkpQI",
+	"ioaL+KbFBg2SojBFkCX7iqV+25qWkbj3lUu2HCIQUzBTRXQAwFdFE8F9vG2YzByE983ftExncxQUS8zB",
+	"neBWWMn5YsF+htWLpU508Hnx+tj0HaoZ1/6QHZLAuTHQLHLOMU5YiLqmvLThEVfziMa3ySHaRh5NGoS6",
+	"E2qCgxpz39uIA5QqJ+fmEMs5mUlqQixV5eiuHpKfcdaJWHJDlrRR+QZj7sxtq1QOcTrm4opbyOxJGAM8",
+	"TofVoKICQhXq5dYCbaWmfRCvkHxx7moSz3Nybtck5Hk+5udNbukcbVa3kC9zeyzHzjqnzgX0y8R5NvRp",
+	"+sfgxetj16HJ21Bmv5D6vjMl8n7nbMH8D55Rfnp3mnV5+83J4fOvELbvzR8/vTslbuHG9Yr6bBl/aLZE",
+	"8vvp3c8nrX3F7ZNI0SZuZhZjnpyToqKsHvMv1IIWQBRgabeG8ktfz3iuioV7i3xhbMEvbVctUxXGeFEt",
+	"y4Y0egloOOan5lgAoYUOBl1s/ykg59EZBVOnrOfApI9pW5QbgQTZkcNdg2O0hmxrCManIs0XvgpRSFJT",
+	"Tmdo1qF5FIhvGOp98ExE/XZBvsPHL14fR+d+j7LRcDR84nL1nC4Y1hwMR8OnNkc1Nzx64MfEf8ysjRnQ",
+	"gdGI7L+D/2+jqFHzocPRaEP7jPW2GTtZ926yddt+vZ/GC7ORJgDnIbzJs2ejJ31zBOgPWi1AzEdPt3/U",
+	"tPPBLw6/3f5Ft6lMLCmzo9/bMjKkztWRNHL2Jn/fYsX1F/7IM1cnZ3fKSLUYGQuhEn7Di3Ac1nkzhkub",
+	"8zSoKq54CM7UeXwA0H7TSEJTnMtX4XPD0mNuaj5YVPIRn8FpB1KOmsFCFQ3TBgwPoD196+rAGnk45ibZ",
+	"7vLsIfBied9mzn0DOCtcTDmE5dE2mb/sVKDEjSB/T29z88pBp98g7ovTqd+JcrUXk2zijeQ505u2iYKm",
+	"180aoz7pJYKAYrU0ag7bZ64sS4zuszlOt0rzHjl5tAMnh0ZrhvUPt3+Q7If1seXGlWQaNgoO/0ZLcrx0",
+	"LG16ElIvgG/yRj0cvA9dTG92UBV7M1ATYra8cwcts5NySTfhaoL6iXbBm7pamXdubu6TZp/dJ3N6WcGF",
+	"JvZswsPVgLyhYGPopBqIvkBkoVKwnf1+BTkD8hrfJV+YQ25Pv/3qS++h1kttTo2ZM3ndQzNDcuLa29Cm",
+	"oYiQY27i6cby1cK68zbQrAjTQ/I3uLJlirb353YdSSp2AWix2ho+wVNKrBXpvwsP5ts1nuvNuruqqxHJ",
+	"A7MhexJmMoGxk9q7F8nhHvkuP21FeidR8pdRwA9AmO1tBDw5vO8ekXEbq3DAcIdDgg/V7LB87eLltp+b",
+	"mLYEeJ8JckDLchD1AvRuTlscNm1mTkWvUNyhrbvvNGOT0lp85DbvD8XbWO/Ss7vM7aRfDeJoWT44N4N8",
+	"gf3ec1/vMV6ORk+L/yKjLx/F31/MB3pRlqFpqBa7SSF7UHuX2NlL9+Yn9Iv2ib5ZcHeJwTV99TDZXJXI",
+	"U+Y2hOzRGfr0zhAqsTlTWtgTaW6jXEPGHYnctoOJaLzboBmj1YMT4JqY5K6KGq7rta4y3et1bGy+2x4U",
+	"nSSf6VRD8j1G6c0ApKDSZLp843Yl3HF/cwHGso77I5qUquBgE2BuuCgX07pFgVgDfEheirpubmXAOanC",
+	"h1JPgJr2V6xa6/DJSp9taUsA29TeUYxFz8f1y+L7KfpExmYijZvxP/Lwp+Vhuw2O2DS7dIccd+LbJmW+",
+	"WCZ0U/d410MLF+y31X2H1R5OlMDC9RgkeAwSPAYJ9rHLsef7v83Z9SWf2r93En86PmuYzIIitlzHJY80",
+	"30Qmzn66y0/yVPm9u8oG058hHnza6n1kLuZyZeimnotpAtcFAEaSm9tWevqit8GLy9TpRNiCjzEPxfB6",
+	"LkFhAXLTScM3zmh6J62VwdvPXQ8NC76NTGP9qtDzMQ/Z2R1j1XnrHFQ7oRtB1T0yPeadNG5PFtfVuqfM",
+	"LY8fE3DYL9bToYT45IeN+2BRyl8k8tM9OnPbuI8fh4TjDmvhn8PR4QcHu080hg1liZMkoQ2NvQzKBYSY",
+	"shQreIpILB0ze02eO1Q85tmjWv6oGs5tgpApidzqBxjp7E+ggvE2gYW/gbHnhrQgKHPChTaCEhV3fGLO",
+	"N/JqdEr7ArUxj+Jx97nMDf0FN1/z0d9oMA9nwca801KQ6dDGNdUvcHOfwI9l3ITtO7JVNCnzJvFOy8A5",
+	"bauZqLH67kbOoGn3sCUQ2TnY/LDrNDrA9tBhksYeQxcPJPyYEAWdEo3N0YkPR7APKUqRbmXzaaIV27nM",
+	"PknHLh6NnccYxOcegzjxssw1gWoEWLDOvHSzjZtCY2hu/Wh8hB/voMr3UeIPP5/YuGS7pRIjezhOJjan",
+	"+i1K3YmO8lHLPzAt39f4JuTcfJOeYK81jR8sc/hjBBv54GV46T6I2M+235mUZiGPh1KqNjbS5Vrte56y",
+	"j3mEonuZ1O5nKD4MEIGg1gnIP3s8l3GLmqQHfrSiaPY9zz58YYU79utlrq2H2FLvYOvKdbgmfy1W01/V",
+	"cMtyhj9thcKfpjqgubvNnQNEalO+te1gviVSFDfBTWyvSYD8awly1WRAwgn3Hf3gRPfj+7FH45l3tUkR",
+	"X6natr+KGH5wdP8Lc2lHm6dca8DZabqeIP+D93NmKzgx49ifIv7Nu6ymlGzRdH7ueIFM2SOoDoDo5yW3",
+	"R1eHpHesMcfBWtmpeLDw+0yAIoLnpuk0fmvC3T5/5ZPLLMoNh6djHnLDycOvOF2LNfYV6j+yjx4TbrNu",
+	"L6uaoIPD3+frHv7I0mGh+4zyzA218f9siJnyVS0kfKJ8VGAUZ8HMWZMOXFDXwKOYQ3GhPKR5eFFpbKHq",
+	"FvJgjUlTH0FbgojiKcZ2h/nNEs82JNxcFuMFmJh2EGSFWd4WTNFbTIVeiBgZY9rlz106XUIFtO8EvgXs",
+	"sxJDodX+oyD6dILoAfKxpYsUJzf3QVgu7mR4DzTbEho7ZfcVFktcQ7BrlJeZCG+rJMAJEZv5Ni/YnkZc",
+	"kCoM/mjyhvo0jyGTamjnWDdQzsF7/M/NpqrwxLbuK4ZPTarjIxenxenK+8uZpoj+MWH6WYfwTvorGboS",
+	"jEZZvp0SehsyeR8gztK9uOFPlfN7DLI8DI0TbY+5atM2z2zS22uXzbSp/+C9/9Oc2zcvwWbvoym89ne6",
+	"+vt2JqtWfZ+pX9dzyj00pkVzc3sMdlo5NqvgNnqi5xLv0RrzcHuo9weVC9E3lYhxfbsv1/QHhNPl+Qmn",
+	"5oWF/LTpy7+nJg24+7hezc6l0+ZuZr8d9iIz38n+8y069ov/1I5OFCJseTs+BvhA4i9bgi6GbFrxFlPZ",
+	"63zmUOixdjHEmOOH5kBNIWSJ9Be9/oW5hDhxS/mXD7v810mIOAC8l0S1p4v6m6C8Mc8/LwEU7sR4FDkP",
+	"SuQ8TA6zHLCJwXIXmGR81g1ZWt7zdzr0JutDQZLtGK0A2ndBmA4EFrxyuGYl/Dfod36G+7DQ3WS7Guh+",
+	"FZ+uwcfDtY4DZfQ220UMuhqNcBgy+tIcaWxa4iP5YUt8qkxrwpzEDcTxob3vwzXZPf/HwO3l4ITNONVL",
+	"Cee+soMpcn755L/Wb6KfwzX58dcXLwcnP74wfbutNRsNdspqUJrWi/Mxd6N98Zaza5xd8FJhd21Siqb8",
+	"FRv7D8kPtv181JCeynBOyS6Bcrx0yWweo5U5jSqm09xY5JzUVF6YAWg5JO+ii1SKNn/Fd161GoswubFw",
+	"xVbjvAv3DHy8CjM3xycqMAsMvs7Q7pEXRnlEVIQF9Lr7Yri4eizw/3gL/Y3DWhRJC9T5bLrCNP6y/0Dj",
+	"nzymtpwgItC3N+JOiw4vt4uXvJQ9eO/+cm2IS6hAw7rR+8r83vD5fjbvOz9HyuR9lriX17GUheYztkr9",
+	"Qu/aOu7h0aMlGEIbrVwJPmu0bqPS+gnyIHppQ8i3fbULu0VTuIg+8w8SJ+65N+d+wsWdyXc1SluXnP1l",
+	"w8YPgf8f7KGUcAlb1AIvcPhOfHzw3o9xbEIt7l+bw9dhWqbcrW/upiVrAJOpBDUnCmwRobsSKscCPpP7",
+	"DnEwf02TkNYgTtizbzxEXSa6m0jZ8vKrgJOUgjz80FZsIxk2SgLbB8Ai/PNnQSGbdX/qGFFM8LRCjlw9",
+	"9Eo6R1PGHTV9JQ2HZjebp12fxg5rTmpYPjP3X5prno4ODipR0GoulD76ZvTN6IAuWHbzx83/DADlkQph",
+	"m7oAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// canAccessAccount reports whether the caller may see and modify the account. Handlers answer as if the
+// accounts of other customers didn't exist, so their ids can't be probed.
+func canAccessAccount(ctx context.Context, account entities.Account) bool {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.CanAccessAccount(account.OwnerId)
+}
+
+// accountFilterFromContext restricts the listed accounts to the ones of the caller. It returns false when
+// the caller can't see any account.
+func accountFilterFromContext(ctx context.Context) (store.AccountFilter, bool) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return store.AccountFilter{}, false
+	}
+	if principal.IsBackOffice() {
+		return store.AccountFilter{}, true
+	}
+	if principal.CustomerId == nil {
+		return store.AccountFilter{}, false
+	}
+	return store.AccountFilter{OwnerId: principal.CustomerId}, true
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/problem"
+)
+
+// operationRoles lists the roles allowed to call each operation, by operationId. It is deny by default:
+// nobody can call an operation missing from it. Customers are further restricted to their own accounts by
+// the handlers.
+var operationRoles = map[string][]string{
+	"GetAccounts":         {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"GetAccount":          {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"GetAccountChanges":   {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"StreamAccountEvents": {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"CreateAccount":       {auth.RoleCustomer, auth.RoleAdmin},
+	"UpdateAccount":       {auth.RoleCustomer, auth.RoleAdmin},
+	"TransferMoney":       {auth.RoleCustomer, auth.RoleAdmin},
+	// deposits credit money from outside the bank, only back-office users record them
+	"AddBalanceToAccount": {auth.RoleOperator, auth.RoleAdmin},
+	"SetAccountStatus":    {auth.RoleOperator, auth.RoleAdmin},
+	"GetCustomers":        {auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"CreateCustomer":      {auth.RoleAdmin},
+
+	"GetAccountTransferLimits": {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleAdmin},
+	"SetAccountTransferLimits": {auth.RoleAdmin},
+	"GetTiers":                 {auth.RoleSupport, auth.RoleOperator, auth.RoleAdmin},
+	"SetTierTransferLimits":    {auth.RoleAdmin},
+
+	// the risk evaluations of the transfers are kept from customers
+	"GetAccountTransfers": {auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"GetTransfers":        {auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+	"StreamEvents":        {auth.RoleSupport, auth.RoleOperator, auth.RoleCompliance, auth.RoleAdmin},
+
+	// customers request transfers, back-office users approve them
+	"ApproveTransfer": {auth.RoleOperator, auth.RoleAdmin},
+	"RejectTransfer":  {auth.RoleOperator, auth.RoleAdmin},
+
+	// compliance staff decide on the names matching the sanctions list
+	"GetScreeningHits":    {auth.RoleCompliance, auth.RoleAdmin},
+	"ClearScreeningHit":   {auth.RoleCompliance, auth.RoleAdmin},
+	"ConfirmScreeningHit": {auth.RoleCompliance, auth.RoleAdmin},
+
+	// partners subscribe to the events of their accounts, operators redeliver the failed deliveries
+	"GetWebhooks":              {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleAdmin},
+	"CreateWebhook":            {auth.RoleCustomer, auth.RoleAdmin},
+	"DeleteWebhook":            {auth.RoleCustomer, auth.RoleAdmin},
+	"GetWebhookDeliveries":     {auth.RoleCustomer, auth.RoleSupport, auth.RoleOperator, auth.RoleAdmin},
+	"RedeliverWebhookDelivery": {auth.RoleCustomer, auth.RoleOperator, auth.RoleAdmin},
+}
+
+type RoleStore interface {
+	GetRoles(ctx context.Context, subject string) ([]string, error)
+}
+
+var (
+	// ErrMissingCredentials is returned by AuthorizeOperation when no principal was authenticated.
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrRoleNotAllowed is returned by AuthorizeOperation when the roles of the principal don't allow the
+	// operation.
+	ErrRoleNotAllowed = errors.New("your roles don't allow")
+)
+
+// Authorize enforces operationRoles before the strict handlers run. It loads the roles assigned to the
+// principal authenticated by the auth middlewares, so the handlers can rely on them too.
+func Authorize(roles RoleStore) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			ctx, err := AuthorizeOperation(ctx, roles, operationID)
+			switch {
+			case errors.Is(err, ErrMissingCredentials):
+				problem.Write(ctx, w, http.StatusUnauthorized, problem.CodeUnauthorized, err.Error())
+				return nil, nil
+			case errors.Is(err, ErrRoleNotAllowed):
+				problem.Write(ctx, w, http.StatusForbidden, problem.CodeForbidden, err.Error())
+				return nil, nil
+			case err != nil:
+				return nil, err
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+// AuthorizeOperation checks operationRoles for the principal of ctx, for the APIs not going through the
+// strict handler. The returned context has the principal with its roles loaded.
+func AuthorizeOperation(ctx context.Context, roles RoleStore, operationID string) (context.Context, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ctx, ErrMissingCredentials
+	}
+
+	assigned, err := roles.GetRoles(ctx, principal.Subject)
+	if err != nil {
+		return ctx, err
+	}
+	principal.Roles = assigned
+
+	if !principal.HasAnyRole(operationRoles[operationID]) {
+		return ctx, fmt.Errorf("%w %s", ErrRoleNotAllowed, specOperationId(operationID))
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// specOperationId turns the name of a generated operation back into its operationId in the spec.
+func specOperationId(operationID string) string {
+	if operationID == "" {
+		return operationID
+	}
+	return strings.ToLower(operationID[:1]) + operationID[1:]
+}
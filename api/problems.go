@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/store"
+)
+
+// The helpers below build the problems of the handlers, the status must match the one of the response
+// they are converted to.
+
+func invalidRequest(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusBadRequest, problem.CodeInvalidRequest, detail)
+}
+
+// accountNotFound is the problem of the operations on accounts that don't exist, for the operations that
+// involve several accounts.
+func accountNotFound(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusNotFound, problem.CodeAccountNotFound, detail)
+}
+
+// transferRefused is the problem of the transfers the balance, the status or the limits of the accounts
+// don't allow.
+func transferRefused(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusUnprocessableEntity, problem.CodeTransferRefused, detail)
+}
+
+// transferDeclined is the problem of the transfers declined by the risk rules.
+func transferDeclined(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusUnprocessableEntity, problem.CodeTransferDeclined, detail)
+}
+
+func idempotencyKeyReused(ctx context.Context) Problem {
+	return problem.New(ctx, http.StatusUnprocessableEntity, problem.CodeIdempotencyKeyReused, idempotencyKeyReusedMessage)
+}
+
+func forbidden(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusForbidden, problem.CodeForbidden, detail)
+}
+
+func sanctionsBlocked(ctx context.Context) Problem {
+	return problem.New(ctx, http.StatusForbidden, problem.CodeSanctionsBlocked, sanctionsBlockedMessage)
+}
+
+func notFound(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusNotFound, problem.CodeNotFound, detail)
+}
+
+func conflict(ctx context.Context, detail string) Problem {
+	return problem.New(ctx, http.StatusConflict, problem.CodeConflict, detail)
+}
+
+func preconditionFailed(ctx context.Context) Problem {
+	return problem.New(ctx, http.StatusPreconditionFailed, problem.CodePreconditionFailed, preconditionFailedMessage)
+}
+
+// HandleRequestError answers the requests the strict handlers can't decode. The validation rejects most of
+// them before, but not the requests of the routes it doesn't know.
+func HandleRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	problem.Write(r.Context(), w, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+}
+
+// HandleResponseError answers the requests whose handler failed. The error is only logged, as it may leak
+// the internals of the service. Units of work still conflicting with concurrent ones after their retries
+// get a 409, the request can be sent again.
+func HandleResponseError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, store.ErrTxConflict) {
+		slog.Warn("Request conflicted with concurrent ones.", "error", err, "path", r.URL.Path, "method", r.Method)
+		problem.Write(r.Context(), w, http.StatusConflict, problem.CodeConflict, "the resources were modified by concurrent requests, retry the request")
+		return
+	}
+	slog.Error("Failed to handle request.", "error", err, "path", r.URL.Path, "method", r.Method)
+
+	problem.Write(r.Context(), w, http.StatusInternalServerError, problem.CodeInternalError, "the server failed to handle the request")
+}
+
+// NotFound answers the requests of the paths the API doesn't have.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	problem.Write(r.Context(), w, http.StatusNotFound, problem.CodeNotFound, "no operation at "+r.URL.Path)
+}
+
+// MethodNotAllowed answers the requests of the paths the API has with a method they don't support.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	problem.Write(r.Context(), w, http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, r.Method+" isn't supported by "+r.URL.Path)
+}
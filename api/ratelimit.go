@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/pkg/ratelimit"
+)
+
+// RateLimits are the limits of each client, identified both by its principal and by its IP address.
+// Operations moving money have their own buckets, so reads can't starve them and the other way round.
+type RateLimits struct {
+	PrincipalRead  ratelimit.Limit
+	PrincipalMoney ratelimit.Limit
+	IPRead         ratelimit.Limit
+	IPMoney        ratelimit.Limit
+}
+
+// moneyOperations are the operations limited by the money limits, by operationId.
+var moneyOperations = map[string]bool{
+	"TransferMoney":       true,
+	"AddBalanceToAccount": true,
+}
+
+type rateLimitBucket struct {
+	key   string
+	limit ratelimit.Limit
+}
+
+// RateLimit rejects the requests of the clients going over their limits with a 429, and tells every client
+// where it stands with the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers. Requests go
+// through when the limiter fails, as rejecting everything would be worse than not limiting for a while.
+func RateLimit(limiter ratelimit.Limiter, limits RateLimits) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			reported := ApplyRateLimits(ctx, limiter, limits, operationID, clientIP(r))
+			if reported == nil {
+				return f(ctx, w, r, request)
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(reported.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(reported.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reported.Reset.Seconds())))
+			if !reported.Allowed {
+				retryAfter := ceilSeconds(reported.RetryAfter.Seconds())
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				problem.Write(ctx, w, http.StatusTooManyRequests, problem.CodeRateLimited, fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfter))
+				return nil, nil
+			}
+
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+// ApplyRateLimits takes a request of the operation from the buckets of the principal of ctx and of the
+// client IP, and returns the result of the most restrictive bucket, nil when no bucket applies or the
+// limiter failed. A rejected request is refunded to the buckets charged before the rejecting one, so it
+// doesn't count against them. The APIs not going through the strict handler use it to share the buckets of
+// RateLimit.
+func ApplyRateLimits(ctx context.Context, limiter ratelimit.Limiter, limits RateLimits, operationID, clientIP string) *ratelimit.Result {
+	class, principalLimit, ipLimit := "read", limits.PrincipalRead, limits.IPRead
+	if moneyOperations[operationID] {
+		class, principalLimit, ipLimit = "money", limits.PrincipalMoney, limits.IPMoney
+	}
+
+	var buckets []rateLimitBucket
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && !principalLimit.Unlimited() {
+		buckets = append(buckets, rateLimitBucket{"principal:" + principal.Subject + ":" + class, principalLimit})
+	}
+	if !ipLimit.Unlimited() {
+		buckets = append(buckets, rateLimitBucket{"ip:" + clientIP + ":" + class, ipLimit})
+	}
+
+	// the most restrictive bucket is the one reported to the client
+	var reported *ratelimit.Result
+	var charged []rateLimitBucket
+	for _, bucket := range buckets {
+		result, err := limiter.Allow(ctx, bucket.key, bucket.limit)
+		if err != nil {
+			slog.Error("Failed to apply rate limit", "error", err, "key", bucket.key)
+			continue
+		}
+		if reported == nil || !result.Allowed || (reported.Allowed && result.Remaining < reported.Remaining) {
+			reported = &result
+		}
+		if !result.Allowed {
+			break
+		}
+		charged = append(charged, bucket)
+	}
+
+	if reported != nil && !reported.Allowed {
+		for _, bucket := range charged {
+			if err := limiter.Refund(ctx, bucket.key, bucket.limit); err != nil {
+				slog.Error("Failed to refund rate limit", "error", err, "key", bucket.key)
+			}
+		}
+	}
+	return reported
+}
+
+// clientIP is the address the request comes from. X-Forwarded-For is ignored as it can be forged, unless
+// chi's RealIP middleware is used to trust it.
+func clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+func ceilSeconds(seconds float64) int {
+	return int(math.Ceil(seconds))
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+const sanctionsBlockedMessage = "blocked by the sanctions screening"
+
+// screenNames screens the names of an operation against the sanctions list. The hits are pending review,
+// or all blocked when one of them is close enough to refuse the operation.
+func (s API) screenNames(subjectType entities.ScreeningSubject, operation string, names ...string) ([]entities.ScreeningHit, bool) {
+	var hits []entities.ScreeningHit
+	blocked := false
+	for _, name := range names {
+		for _, match := range s.opts.Screener.Screen(name) {
+			blocked = blocked || match.Block
+			hits = append(hits, entities.ScreeningHit{
+				SubjectType:  subjectType,
+				Operation:    operation,
+				ScreenedName: name,
+				EntryUid:     match.Entry.Uid,
+				EntryName:    match.Entry.Name,
+				MatchedName:  match.MatchedName,
+				Score:        match.Score,
+				Status:       entities.ScreeningHitStatusPending,
+				CreatedAt:    time.Now(),
+			})
+		}
+	}
+	if blocked {
+		for i := range hits {
+			hits[i].Status = entities.ScreeningHitStatusBlocked
+		}
+	}
+	return hits, blocked
+}
+
+// recordScreeningHits saves the hits of the account or transfer subjectId in the unit of work tx.
+func recordScreeningHits(ctx context.Context, tx store.Tx, hits []entities.ScreeningHit, subjectId *int64) error {
+	for _, hit := range hits {
+		hit.SubjectId = subjectId
+		created, err := tx.CreateScreeningHit(ctx, hit)
+		if err != nil {
+			return err
+		}
+		if err := appendAuditEvent(ctx, tx, nil, toScreeningHit(created)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refuseBlockedOperation records the hits of an operation refused by the screening, outside of the unit of
+// work of the operation which is not committed.
+func (s API) refuseBlockedOperation(ctx context.Context, hits []entities.ScreeningHit, subjectId *int64) error {
+	return s.store.RunInTx(ctx, func(tx store.Tx) error {
+		return recordScreeningHits(ctx, tx, hits, subjectId)
+	})
+}
+
+// freezeOnRename freezes the account when diff renames it, for the renames matching the sanctions list.
+func freezeOnRename(diff accountDiff, actor string) accountDiff {
+	return func(account entities.Account) (store.AccountUpdate, []entities.AccountChange) {
+		update, changes := diff(account)
+		if update.Name == nil {
+			return update, changes
+		}
+		freeze, freezeChanges := setAccountStatus(entities.AccountStatusFrozen, actor)(account)
+		update.Status = freeze.Status
+		return update, append(changes, freezeChanges...)
+	}
+}
+
+func (s API) GetScreeningHits(ctx context.Context, request GetScreeningHitsRequestObject) (GetScreeningHitsResponseObject, error) {
+	var filter store.ScreeningHitFilter
+	if request.Params.Status != nil {
+		switch *request.Params.Status {
+		case ScreeningHitStatusPending, ScreeningHitStatusCleared, ScreeningHitStatusConfirmed, ScreeningHitStatusBlocked:
+		default:
+			return GetScreeningHits400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "status must be one of pending, cleared, confirmed, blocked")), nil
+		}
+		status := entities.ScreeningHitStatus(*request.Params.Status)
+		filter.Status = &status
+	}
+
+	hits, err := s.store.GetScreeningHits(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetScreeningHits200JSONResponse, 0, len(hits))
+	for _, hit := range hits {
+		response = append(response, toScreeningHit(hit))
+	}
+
+	return response, nil
+}
+
+func (s API) ClearScreeningHit(ctx context.Context, request ClearScreeningHitRequestObject) (ClearScreeningHitResponseObject, error) {
+	var response ClearScreeningHitResponseObject
+	// the transfer released by the hit, if any, counted once committed
+	var executed *entities.Transfer
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		executed = nil
+		hit, err := tx.GetScreeningHitById(ctx, request.HitId)
+		if err != nil {
+			if errors.Is(err, store.ErrScreeningHitNotFound) {
+				response = ClearScreeningHit404ApplicationProblemPlusJSONResponse(notFound(ctx, "screening hit not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if hit.Status != entities.ScreeningHitStatusPending {
+			response = ClearScreeningHit409ApplicationProblemPlusJSONResponse(conflict(ctx, notPendingHitMessage(hit)))
+			return errAbortTx
+		}
+		hit, err = decideScreeningHit(ctx, tx, hit, entities.ScreeningHitStatusCleared)
+		if err != nil {
+			return err
+		}
+
+		// the subject goes on once none of its hits is pending anymore
+		pending := entities.ScreeningHitStatusPending
+		others, err := tx.GetScreeningHits(ctx, store.ScreeningHitFilter{
+			Status:      &pending,
+			SubjectType: &hit.SubjectType,
+			SubjectId:   hit.SubjectId,
+		})
+		if err != nil {
+			return err
+		}
+		if len(others) == 0 {
+			var refused string
+			executed, refused, err = s.releaseScreenedSubject(ctx, tx, hit)
+			if err != nil {
+				return err
+			}
+			if refused != "" {
+				// the hit stays pending, it may be cleared once the balance or the limits allow the transfer
+				response = ClearScreeningHit422ApplicationProblemPlusJSONResponse(transferRefused(ctx, refused))
+				return errAbortTx
+			}
+		}
+
+		response = ClearScreeningHit200JSONResponse(toScreeningHit(hit))
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+	if executed != nil && executed.Status == entities.TransferStatusCompleted {
+		s.opts.Metrics.TransferCompleted(executed.Amount)
+	}
+
+	return response, nil
+}
+
+// releaseScreenedSubject lets the account or transfer of a cleared hit go on. Accounts are unfrozen, and
+// transfers wait for approval or are executed with fresh checks, in which case it returns the executed
+// transfer, or why the transfer is refused.
+func (s API) releaseScreenedSubject(ctx context.Context, tx store.Tx, hit entities.ScreeningHit) (*entities.Transfer, string, error) {
+	if hit.SubjectId == nil {
+		return nil, "", nil
+	}
+	actor := actorFromContext(ctx)
+
+	if hit.SubjectType == entities.ScreeningSubjectAccount {
+		account, err := tx.GetAccountById(ctx, *hit.SubjectId)
+		if err != nil {
+			return nil, "", err
+		}
+		update, changes := setAccountStatus(entities.AccountStatusActive, actor)(account)
+		if len(changes) == 0 {
+			return nil, "", nil
+		}
+		updated, err := tx.UpdateAccount(ctx, *hit.SubjectId, &account.Version, update)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := tx.AddAccountChanges(ctx, changes); err != nil {
+			return nil, "", err
+		}
+		return nil, "", appendAuditEvent(ctx, tx, toAccount(account), toAccount(updated))
+	}
+
+	transfer, err := tx.GetTransferById(ctx, *hit.SubjectId)
+	if err != nil {
+		return nil, "", err
+	}
+	if transfer.Status != entities.TransferStatusPendingReview {
+		return nil, "", nil
+	}
+	source, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return nil, "", err
+	}
+	before := toTransfer(transfer)
+	now := time.Now()
+
+	if s.needsApproval(transfer.Amount) || transfer.RiskDecision == entities.RiskDecisionReview {
+		// the amount stays held while the transfer waits for approval
+		s.awaitApproval(&transfer, now)
+		if err := tx.UpdateTransfer(ctx, transfer); err != nil {
+			return nil, "", err
+		}
+		after := toTransfer(transfer)
+		return nil, "", appendAuditEvent(ctx, tx,
+			heldTransferSnapshot{Transfer: &before, Source: toAccount(source)},
+			heldTransferSnapshot{Transfer: &after, Source: toAccount(source)},
+		)
+	}
+
+	if err := tx.ReleaseHeldBalance(ctx, transfer.SourceAccountId, transfer.Amount); err != nil {
+		return nil, "", err
+	}
+	releasedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return nil, "", err
+	}
+	target, err := tx.GetAccountById(ctx, transfer.TargetAccountId)
+	if err != nil {
+		return nil, "", err
+	}
+	transfer.DecidedBy = &actor
+	refused, err := s.executeTransfer(ctx, tx, releasedSource, target, &transfer, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if refused != "" && transfer.Status != entities.TransferStatusDeclined {
+		return nil, refused, nil
+	}
+	if err := tx.UpdateTransfer(ctx, transfer); err != nil {
+		return nil, "", err
+	}
+	if err := appendTransferCompleted(ctx, tx, transfer); err != nil {
+		return nil, "", err
+	}
+
+	updatedSource, err := tx.GetAccountById(ctx, transfer.SourceAccountId)
+	if err != nil {
+		return nil, "", err
+	}
+	after := toTransfer(transfer)
+	return &transfer, "", appendAuditEvent(ctx, tx,
+		heldTransferSnapshot{Transfer: &before, Source: toAccount(source)},
+		heldTransferSnapshot{Transfer: &after, Source: toAccount(updatedSource)},
+	)
+}
+
+func (s API) ConfirmScreeningHit(ctx context.Context, request ConfirmScreeningHitRequestObject) (ConfirmScreeningHitResponseObject, error) {
+	var response ConfirmScreeningHitResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		hit, err := tx.GetScreeningHitById(ctx, request.HitId)
+		if err != nil {
+			if errors.Is(err, store.ErrScreeningHitNotFound) {
+				response = ConfirmScreeningHit404ApplicationProblemPlusJSONResponse(notFound(ctx, "screening hit not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if hit.Status != entities.ScreeningHitStatusPending {
+			response = ConfirmScreeningHit409ApplicationProblemPlusJSONResponse(conflict(ctx, notPendingHitMessage(hit)))
+			return errAbortTx
+		}
+		hit, err = decideScreeningHit(ctx, tx, hit, entities.ScreeningHitStatusConfirmed)
+		if err != nil {
+			return err
+		}
+
+		// confirmed accounts stay frozen, confirmed transfers are blocked
+		if hit.SubjectType == entities.ScreeningSubjectTransfer && hit.SubjectId != nil {
+			transfer, err := tx.GetTransferById(ctx, *hit.SubjectId)
+			if err != nil {
+				return err
+			}
+			if transfer.Status == entities.TransferStatusPendingReview {
+				actor := actorFromContext(ctx)
+				if err := closePendingTransfer(ctx, tx, transfer, entities.TransferStatusBlocked, &actor); err != nil {
+					return err
+				}
+			}
+		}
+
+		response = ConfirmScreeningHit200JSONResponse(toScreeningHit(hit))
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func decideScreeningHit(ctx context.Context, tx store.Tx, hit entities.ScreeningHit, status entities.ScreeningHitStatus) (entities.ScreeningHit, error) {
+	before := toScreeningHit(hit)
+	actor := actorFromContext(ctx)
+	now := time.Now()
+	hit.Status = status
+	hit.DecidedBy = &actor
+	hit.DecidedAt = &now
+	if err := tx.UpdateScreeningHit(ctx, hit); err != nil {
+		return entities.ScreeningHit{}, err
+	}
+	return hit, appendAuditEvent(ctx, tx, before, toScreeningHit(hit))
+}
+
+func notPendingHitMessage(hit entities.ScreeningHit) string {
+	return fmt.Sprintf("screening hit is %s, not pending", hit.Status)
+}
+
+func toScreeningHit(hit entities.ScreeningHit) ScreeningHit {
+	return ScreeningHit{
+		Id:           hit.Id,
+		SubjectType:  ScreeningHitSubjectType(hit.SubjectType),
+		SubjectId:    hit.SubjectId,
+		Operation:    hit.Operation,
+		ScreenedName: hit.ScreenedName,
+		EntryUid:     hit.EntryUid,
+		EntryName:    hit.EntryName,
+		MatchedName:  hit.MatchedName,
+		Score:        hit.Score,
+		Status:       ScreeningHitStatus(hit.Status),
+		DecidedBy:    hit.DecidedBy,
+		DecidedAt:    hit.DecidedAt,
+		CreatedAt:    hit.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/problem"
+)
+
+// RequireScopes enforces the security requirements declared on each operation of the spec. The generated
+// wrapper puts the scopes an operation requires in the request context before calling its middlewares.
+// Operations require the same scopes whether the caller uses an API key or a bearer token.
+func RequireScopes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		required, ok := r.Context().Value(ApiKeyAuthScopes).([]string)
+		if !ok {
+			required, ok = r.Context().Value(BearerAuthScopes).([]string)
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			problem.Write(r.Context(), w, http.StatusUnauthorized, problem.CodeUnauthorized, "missing credentials")
+			return
+		}
+		if !principal.HasScopes(required) {
+			problem.Write(r.Context(), w, http.StatusForbidden, problem.CodeForbidden, "missing required scopes: "+strings.Join(required, ", "))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+	"tiny-bank-api/pkg/notify"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+const (
+	// streamBatchSize is the number of events read at once by the event streams.
+	streamBatchSize = 100
+	// streamGapTimeout is how long the stream of every account waits for the events of the ids it skipped,
+	// which may belong to transactions not committed yet. Most are the ids of rolled back transactions.
+	streamGapTimeout = time.Minute
+	// maxStreamGaps caps the number of skipped ids a stream waits for.
+	maxStreamGaps = 1000
+)
+
+func (s API) StreamAccountEvents(ctx context.Context, request StreamAccountEventsRequestObject) (StreamAccountEventsResponseObject, error) {
+	account, err := s.store.GetAccountById(ctx, request.AccountId)
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return StreamAccountEvents404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		return nil, err
+	}
+	if !canAccessAccount(ctx, account) {
+		return StreamAccountEvents404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+	}
+
+	afterId, err := s.streamStart(ctx, request.Params.LastEventID)
+	if err != nil {
+		return nil, err
+	}
+	return eventStream{ctx: ctx, api: s, filter: store.OutboxEventFilter{AccountId: &request.AccountId, AfterId: afterId}}, nil
+}
+
+// StreamEvents streams the events of every account. Unlike the changes of an account, which are serialized
+// by its lock, transactions can commit after a newer event was streamed: their events are streamed late,
+// once found in the ids the stream skipped.
+func (s API) StreamEvents(ctx context.Context, request StreamEventsRequestObject) (StreamEventsResponseObject, error) {
+	afterId, err := s.streamStart(ctx, request.Params.LastEventID)
+	if err != nil {
+		return nil, err
+	}
+	return eventStream{ctx: ctx, api: s, filter: store.OutboxEventFilter{AfterId: afterId}}, nil
+}
+
+// streamStart returns the id of the event after which a stream starts: the Last-Event-ID of the clients
+// resuming it, the latest event otherwise.
+func (s API) streamStart(ctx context.Context, lastEventId *int64) (int64, error) {
+	if lastEventId != nil {
+		return *lastEventId, nil
+	}
+	return s.store.GetLastOutboxEventId(ctx)
+}
+
+// eventStream writes the events of the outbox matching filter as Server-Sent Events, until the client
+// disconnects or the server shuts down.
+type eventStream struct {
+	ctx    context.Context
+	api    API
+	filter store.OutboxEventFilter
+}
+
+func (e eventStream) VisitStreamAccountEventsResponse(w http.ResponseWriter) error {
+	return e.write(w)
+}
+
+func (e eventStream) VisitStreamEventsResponse(w http.ResponseWriter) error {
+	return e.write(w)
+}
+
+func (e eventStream) write(w http.ResponseWriter) error {
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// keeps reverse proxies from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	poll := time.NewTicker(e.api.opts.StreamPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(e.api.opts.StreamHeartbeat)
+	defer heartbeat.Stop()
+	var notifications <-chan notify.Notification
+	if e.api.opts.EventNotifications != nil {
+		sub := e.api.opts.EventNotifications.Subscribe()
+		defer sub.Close()
+		notifications = sub.C
+	}
+
+	filter := e.filter
+	filter.Limit = streamBatchSize
+	gaps := map[int64]time.Time{}
+	for {
+		events, full, err := e.readEvents(&filter, gaps)
+		if err != nil {
+			// the status was already sent, the client reconnects with the id of the last event it received
+			if e.ctx.Err() == nil {
+				e.api.logger.Error("Error reading the events to stream: " + err.Error())
+			}
+			return nil
+		}
+		for _, event := range events {
+			data, err := json.Marshal(toOutboxMessage(event))
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+				return nil
+			}
+		}
+		if len(events) > 0 {
+			if err := rc.Flush(); err != nil {
+				return nil
+			}
+			heartbeat.Reset(e.api.opts.StreamHeartbeat)
+			if full {
+				continue
+			}
+		}
+
+		if !e.wait(w, rc, filter.AccountId, poll, heartbeat, notifications) {
+			return nil
+		}
+	}
+}
+
+// wait blocks until the stream should read the events again, sending the heartbeats meanwhile. It returns
+// false once the stream should end.
+func (e eventStream) wait(w http.ResponseWriter, rc *http.ResponseController, accountId *int64, poll, heartbeat *time.Ticker, notifications <-chan notify.Notification) bool {
+	for {
+		select {
+		case <-e.ctx.Done():
+			return false
+		case <-e.api.opts.StreamsDone:
+			return false
+		case <-poll.C:
+			return true
+		case notification := <-notifications:
+			if accountId == nil || notification.Concerns(*accountId) {
+				return true
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+			if err := rc.Flush(); err != nil {
+				return false
+			}
+		}
+	}
+}
+
+// readEvents reads the events following filter.AfterId and moves it past them. It reports whether a full
+// batch was read, in which case more events may follow right away. For the stream of every account, it
+// also returns the late events found in the gaps of the ids streamed so far.
+func (e eventStream) readEvents(filter *store.OutboxEventFilter, gaps map[int64]time.Time) ([]entities.OutboxEvent, bool, error) {
+	events, err := e.api.store.GetOutboxEvents(e.ctx, *filter)
+	if err != nil {
+		return nil, false, err
+	}
+	full := len(events) == filter.Limit
+	if filter.AccountId != nil {
+		if len(events) > 0 {
+			filter.AfterId = events[len(events)-1].Id
+		}
+		return events, full, nil
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		for id := filter.AfterId + 1; id < event.Id && len(gaps) < maxStreamGaps; id++ {
+			gaps[id] = now
+		}
+		filter.AfterId = event.Id
+	}
+	if len(gaps) == 0 {
+		return events, full, nil
+	}
+
+	late, err := e.api.store.GetOutboxEvents(e.ctx, store.OutboxEventFilter{Ids: slices.Sorted(maps.Keys(gaps))})
+	if err != nil {
+		return nil, false, err
+	}
+	for _, event := range late {
+		delete(gaps, event.Id)
+	}
+	for id, skippedAt := range gaps {
+		if now.Sub(skippedAt) > streamGapTimeout {
+			delete(gaps, id)
+		}
+	}
+	return append(late, events...), full, nil
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+var tierPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+func (s API) GetAccountTransferLimits(ctx context.Context, request GetAccountTransferLimitsRequestObject) (GetAccountTransferLimitsResponseObject, error) {
+	account, err := s.store.GetAccountById(ctx, request.AccountId)
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return GetAccountTransferLimits404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		return nil, err
+	}
+	if !canAccessAccount(ctx, account) {
+		return GetAccountTransferLimits404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+	}
+
+	limits, err := accountTransferLimits(ctx, s.store, account)
+	if err != nil {
+		return nil, err
+	}
+	return GetAccountTransferLimits200JSONResponse(limits), nil
+}
+
+func (s API) SetAccountTransferLimits(ctx context.Context, request SetAccountTransferLimitsRequestObject) (SetAccountTransferLimitsResponseObject, error) {
+	if err := validateTier(request.Body.Tier); err != nil {
+		return SetAccountTransferLimits400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+	if err := validateTransferLimits(request.Body.Overrides); err != nil {
+		return SetAccountTransferLimits400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	var limits AccountTransferLimits
+	diff := setAccountTier(request.Body.Tier, actorFromContext(ctx))
+	_, err := s.updateAccountWith(ctx, request.AccountId, request.Params.IfMatch, diff, func(tx store.Tx, before, updated entities.Account) error {
+		previous, err := accountTransferLimits(ctx, tx, before)
+		if err != nil {
+			return err
+		}
+		if err := tx.SetAccountTransferLimits(ctx, request.AccountId, fromTransferLimits(request.Body.Overrides)); err != nil {
+			return err
+		}
+
+		limits, err = accountTransferLimits(ctx, tx, updated)
+		if err != nil {
+			return err
+		}
+		return appendAuditEvent(ctx, tx, previous, limits)
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrAccountNotFound) {
+			return SetAccountTransferLimits404ApplicationProblemPlusJSONResponse(notFound(ctx, "account not found")), nil
+		}
+		if errors.Is(err, errPreconditionFailed) {
+			return SetAccountTransferLimits412ApplicationProblemPlusJSONResponse(preconditionFailed(ctx)), nil
+		}
+		return nil, err
+	}
+
+	return SetAccountTransferLimits200JSONResponse(limits), nil
+}
+
+func (s API) GetTiers(ctx context.Context, request GetTiersRequestObject) (GetTiersResponseObject, error) {
+	tiers, err := s.store.GetTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetTiers200JSONResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		response = append(response, TierTransferLimits{Tier: tier.Tier, Limits: toTransferLimits(tier.TransferLimits)})
+	}
+
+	return response, nil
+}
+
+func (s API) SetTierTransferLimits(ctx context.Context, request SetTierTransferLimitsRequestObject) (SetTierTransferLimitsResponseObject, error) {
+	if err := validateTier(request.Tier); err != nil {
+		return SetTierTransferLimits400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+	if err := validateTransferLimits(*request.Body); err != nil {
+		return SetTierTransferLimits400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+
+	after := TierTransferLimits{Tier: request.Tier, Limits: *request.Body}
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		previous, err := tx.GetTierTransferLimits(ctx, request.Tier)
+		if err != nil {
+			return err
+		}
+		if err := tx.SetTierTransferLimits(ctx, request.Tier, fromTransferLimits(*request.Body)); err != nil {
+			return err
+		}
+		before := TierTransferLimits{Tier: request.Tier, Limits: toTransferLimits(previous)}
+		return appendAuditEvent(ctx, tx, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SetTierTransferLimits200JSONResponse(after), nil
+}
+
+// accountTransferLimits gathers the limits applying to the account, tx being either the store or a unit
+// of work.
+func accountTransferLimits(ctx context.Context, tx store.Limits, account entities.Account) (AccountTransferLimits, error) {
+	tierLimits, err := tx.GetTierTransferLimits(ctx, account.Tier)
+	if err != nil {
+		return AccountTransferLimits{}, err
+	}
+	overrides, err := tx.GetAccountTransferLimits(ctx, int64(account.Id))
+	if err != nil {
+		return AccountTransferLimits{}, err
+	}
+	return AccountTransferLimits{
+		Tier:      account.Tier,
+		Overrides: toTransferLimits(overrides),
+		Effective: toTransferLimits(tierLimits.Override(overrides)),
+	}, nil
+}
+
+// checkTransferLimits tells which limit of the source account a transfer of amount would exceed, if any.
+// It must run in the unit of work making the transfer, after the source account was read, so concurrent
+// transfers from the same account are counted.
+func checkTransferLimits(ctx context.Context, tx store.Tx, source entities.Account, amount float64, now time.Time) (string, error) {
+	tierLimits, err := tx.GetTierTransferLimits(ctx, source.Tier)
+	if err != nil {
+		return "", err
+	}
+	overrides, err := tx.GetAccountTransferLimits(ctx, int64(source.Id))
+	if err != nil {
+		return "", err
+	}
+	limits := tierLimits.Override(overrides)
+
+	if limits.MaxAmount != nil && cents(amount) > cents(*limits.MaxAmount) {
+		return fmt.Sprintf("amount exceeds the limit of %.2f per transfer", *limits.MaxAmount), nil
+	}
+
+	windows := []struct {
+		name   string
+		period time.Duration
+		amount *float64
+		count  *int64
+	}{
+		{name: "daily", period: 24 * time.Hour, amount: limits.DailyAmount, count: limits.DailyCount},
+		{name: "weekly", period: 7 * 24 * time.Hour, amount: limits.WeeklyAmount},
+		{name: "monthly", period: 30 * 24 * time.Hour, amount: limits.MonthlyAmount},
+	}
+	for _, window := range windows {
+		if window.amount == nil && window.count == nil {
+			continue
+		}
+		totals, err := tx.GetOutgoingTransferTotals(ctx, int64(source.Id), now.Add(-window.period))
+		if err != nil {
+			return "", err
+		}
+		if window.count != nil && totals.Count >= *window.count {
+			return fmt.Sprintf("%s limit of %d transfers reached", window.name, *window.count), nil
+		}
+		if window.amount != nil && cents(totals.Amount)+cents(amount) > cents(*window.amount) {
+			return fmt.Sprintf("amount exceeds the %s limit of %.2f, %.2f was already transferred",
+				window.name, *window.amount, totals.Amount), nil
+		}
+	}
+	return "", nil
+}
+
+// cents compares amounts without floating point errors.
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func validateTier(tier string) error {
+	if !tierPattern.MatchString(tier) {
+		return fmt.Errorf("tier must be 1 to 64 lowercase letters, digits, - or _")
+	}
+	return nil
+}
+
+func validateTransferLimits(limits TransferLimits) error {
+	amounts := []struct {
+		field  string
+		amount *float64
+	}{
+		{"max_amount", limits.MaxAmount},
+		{"daily_amount", limits.DailyAmount},
+		{"weekly_amount", limits.WeeklyAmount},
+		{"monthly_amount", limits.MonthlyAmount},
+	}
+	for _, amount := range amounts {
+		if amount.amount != nil && *amount.amount <= 0 {
+			return fmt.Errorf("%s must be greater than 0", amount.field)
+		}
+	}
+	if limits.DailyCount != nil && *limits.DailyCount < 1 {
+		return fmt.Errorf("daily_count must be at least 1")
+	}
+	return nil
+}
+
+func toTransferLimits(limits entities.TransferLimits) TransferLimits {
+	return TransferLimits{
+		MaxAmount:     limits.MaxAmount,
+		DailyAmount:   limits.DailyAmount,
+		WeeklyAmount:  limits.WeeklyAmount,
+		MonthlyAmount: limits.MonthlyAmount,
+		DailyCount:    limits.DailyCount,
+	}
+}
+
+func fromTransferLimits(limits TransferLimits) entities.TransferLimits {
+	return entities.TransferLimits{
+		MaxAmount:     limits.MaxAmount,
+		DailyAmount:   limits.DailyAmount,
+		WeeklyAmount:  limits.WeeklyAmount,
+		MonthlyAmount: limits.MonthlyAmount,
+		DailyCount:    limits.DailyCount,
+	}
+}
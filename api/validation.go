@@ -0,0 +1,205 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"tiny-bank-api/pkg/problem"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// ValidationOptions configure the validation of the requests against the spec.
+type ValidationOptions struct {
+	// ValidateResponses also validates the responses, replacing the ones not matching the spec with a 500 to
+	// catch the drift between the handlers and the spec during development. It buffers the responses, except
+	// the event streams, so it isn't meant for production.
+	ValidateResponses bool
+}
+
+// Validate returns a middleware rejecting the requests that don't match the embedded spec with a 400
+// listing each violation. Credentials are checked by the auth middlewares, not by the validation, and the
+// requests of unknown routes are left to the router.
+func Validate(opts ValidationOptions) (func(http.Handler) http.Handler, error) {
+	router, err := newSpecRouter()
+	if err != nil {
+		return nil, err
+	}
+	filterOptions := &openapi3filter.Options{
+		MultiError:            true,
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    filterOptions,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				writeValidationError(w, r, err)
+				return
+			}
+
+			if !opts.ValidateResponses || streamsEvents(route) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			validateResponse(r.Context(), w, r, next, input)
+		})
+	}, nil
+}
+
+// newSpecRouter returns a router finding the operations of the embedded spec the requests are for.
+func newSpecRouter() (routers.Router, error) {
+	spec, err := GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("error loading spec: %w", err)
+	}
+	// the API is mounted under /api, whatever the host
+	spec.Servers = openapi3.Servers{{URL: "/api"}}
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error building spec router: %w", err)
+	}
+	return router, nil
+}
+
+// validateResponse serves the request into a buffer, and sends the response only if it matches the spec.
+func validateResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, next http.Handler, input *openapi3filter.RequestValidationInput) {
+	recorder := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+	next.ServeHTTP(recorder, r)
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.status,
+		Header:                 recorder.header,
+		Options:                input.Options,
+	}
+	responseInput.SetBodyBytes(recorder.body.Bytes())
+	if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+		slog.Error("Response doesn't match the spec", "error", err, "path", r.URL.Path, "method", r.Method, "status", recorder.status)
+		problem.Write(ctx, w, http.StatusInternalServerError, problem.CodeInternalError, "response doesn't match the spec: "+err.Error())
+		return
+	}
+
+	for name, values := range recorder.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(recorder.status)
+	_, _ = w.Write(recorder.body.Bytes())
+}
+
+// streamsEvents reports whether the operation of the route answers with an event stream, which can't be
+// buffered.
+func streamsEvents(route *routers.Route) bool {
+	for _, response := range route.Operation.Responses.Map() {
+		if response.Value != nil && response.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleParamError answers the requests whose parameters can't be bound, which the generated router rejects
+// before the validation runs, with the same 400 as the validation.
+func HandleParamError(w http.ResponseWriter, r *http.Request, err error) {
+	var param string
+	switch err := err.(type) {
+	case *InvalidParamFormatError:
+		param = err.ParamName
+	case *RequiredParamError:
+		param = err.ParamName
+	case *RequiredHeaderError:
+		param = err.ParamName
+	case *TooManyValuesForParamError:
+		param = err.ParamName
+	case *UnmarshalingParamError:
+		param = err.ParamName
+	case *UnescapedCookieParamError:
+		param = err.ParamName
+	}
+	writeFieldErrors(w, r, []FieldError{{Field: param, Message: err.Error()}})
+}
+
+// writeValidationError writes a 400 listing the violations of the spec in err.
+func writeValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var fieldErrors []FieldError
+	collectFieldErrors(err, "", &fieldErrors)
+	writeFieldErrors(w, r, fieldErrors)
+}
+
+func writeFieldErrors(w http.ResponseWriter, r *http.Request, fieldErrors []FieldError) {
+	violations := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		violations = append(violations, fieldError.Field+": "+fieldError.Message)
+	}
+	p := invalidRequest(r.Context(), "invalid request: "+strings.Join(violations, ", "))
+	p.Errors = fieldErrors
+	problem.WriteProblem(w, p)
+}
+
+// collectFieldErrors flattens the errors of the validation, field being the parameter or body field they
+// are about.
+func collectFieldErrors(err error, field string, fieldErrors *[]FieldError) {
+	// the errors wrap each other, a type switch keeps errors.As from skipping the levels naming the field
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		for _, item := range err {
+			collectFieldErrors(item, field, fieldErrors)
+		}
+	case *openapi3filter.RequestError:
+		switch {
+		case err.Parameter != nil:
+			field = err.Parameter.Name
+		case err.RequestBody != nil:
+			field = "body"
+		}
+		if err.Err == nil {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: field, Message: err.Reason})
+			return
+		}
+		collectFieldErrors(err.Err, field, fieldErrors)
+	case *openapi3.SchemaError:
+		if pointer := err.JSONPointer(); field == "body" && len(pointer) > 0 {
+			field = strings.Join(pointer, ".")
+		}
+		*fieldErrors = append(*fieldErrors, FieldError{Field: field, Message: err.Reason})
+	default:
+		*fieldErrors = append(*fieldErrors, FieldError{Field: field, Message: err.Error()})
+	}
+}
+
+// responseRecorder buffers a response, for its validation or to save it along with its Idempotency-Key.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
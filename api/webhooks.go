@@ -0,0 +1,442 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/webhook"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// webhookLease is how long a delivery being attempted is kept from the other dispatchers. It outlasts the
+// timeout of the sender, so an attempt is only made again when its dispatcher died during it.
+const webhookLease = 5 * time.Minute
+
+// queueWebhookDeliveries queues the delivery of event to the webhooks subscribed to it for any of the
+// accounts, in the unit of work tx adding the event.
+func queueWebhookDeliveries(ctx context.Context, tx store.Webhooks, event entities.OutboxEvent, accounts []entities.Account) error {
+	webhooks, err := tx.GetWebhooks(ctx, store.WebhookFilter{})
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, hook := range webhooks {
+		if !slices.Contains(hook.EventTypes, string(event.Type)) {
+			continue
+		}
+		if !slices.ContainsFunc(accounts, func(account entities.Account) bool { return webhookMatches(hook, account) }) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(toOutboxMessage(event))
+			if err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		_, err := tx.CreateWebhookDelivery(ctx, entities.WebhookDelivery{
+			WebhookId:     hook.Id,
+			EventId:       event.Id,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        entities.WebhookDeliveryStatusPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// webhookMatches reports whether the webhook receives the events of the account: the webhooks of
+// customers only receive the events of their accounts.
+func webhookMatches(hook entities.Webhook, account entities.Account) bool {
+	if hook.OwnerId != nil && (account.OwnerId == nil || *account.OwnerId != *hook.OwnerId) {
+		return false
+	}
+	return len(hook.AccountIds) == 0 || slices.Contains(hook.AccountIds, int64(account.Id))
+}
+
+// DispatchWebhooks attempts the deliveries due at now and returns how many were attempted. Failed
+// deliveries are attempted again following policy, until they are given up as dead.
+func DispatchWebhooks(ctx context.Context, s store.Store, sender *webhook.Sender, policy webhook.RetryPolicy, now time.Time) (int, error) {
+	pending := entities.WebhookDeliveryStatusPending
+	due, err := s.GetWebhookDeliveries(ctx, store.WebhookDeliveryFilter{Status: &pending, DueBefore: &now, Limit: 100})
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	for _, delivery := range due {
+		delivery, hook, claimed, err := claimWebhookDelivery(ctx, s, delivery.Id, now)
+		if err != nil {
+			return attempted, err
+		}
+		if !claimed {
+			continue
+		}
+
+		// the receiver is called outside of any unit of work, so a slow one doesn't hold the locks
+		status, sendErr := sender.Send(ctx, hook.URL, hook.Secret, strconv.FormatInt(delivery.Id, 10), []byte(delivery.Payload), time.Now())
+		attempted++
+
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+		delivery.LastResponseStatus = nil
+		delivery.LastError = nil
+		if status != 0 {
+			delivery.LastResponseStatus = &status
+		}
+		switch {
+		case sendErr == nil:
+			delivery.Status = entities.WebhookDeliveryStatusDelivered
+			delivery.NextAttemptAt = nil
+			delivery.DeliveredAt = &now
+		case delivery.Attempts >= policy.MaxAttempts:
+			message := sendErr.Error()
+			delivery.LastError = &message
+			delivery.Status = entities.WebhookDeliveryStatusDead
+			delivery.NextAttemptAt = nil
+		default:
+			message := sendErr.Error()
+			delivery.LastError = &message
+			next := now.Add(policy.Delay(delivery.Attempts))
+			delivery.NextAttemptAt = &next
+		}
+		if err := s.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// claimWebhookDelivery leases the delivery to the caller when it is still due, returning it along with its
+// webhook.
+func claimWebhookDelivery(ctx context.Context, s store.Store, deliveryId int64, now time.Time) (entities.WebhookDelivery, entities.Webhook, bool, error) {
+	var delivery entities.WebhookDelivery
+	var hook entities.Webhook
+	claimed := false
+	err := s.RunInTx(ctx, func(tx store.Tx) error {
+		var err error
+		delivery, err = tx.GetWebhookDeliveryById(ctx, deliveryId)
+		if err != nil {
+			if errors.Is(err, store.ErrWebhookDeliveryNotFound) {
+				// the webhook was deleted meanwhile
+				return nil
+			}
+			return err
+		}
+		if delivery.Status != entities.WebhookDeliveryStatusPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			return nil
+		}
+		hook, err = tx.GetWebhookById(ctx, delivery.WebhookId)
+		if err != nil {
+			return err
+		}
+
+		leasedUntil := now.Add(webhookLease)
+		delivery.NextAttemptAt = &leasedUntil
+		if err := tx.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	return delivery, hook, claimed, err
+}
+
+// RunWebhookDispatcher attempts the due webhook deliveries every interval, until ctx is done.
+func RunWebhookDispatcher(ctx context.Context, logger *slog.Logger, s store.Store, sender *webhook.Sender, policy webhook.RetryPolicy, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			attempted, err := DispatchWebhooks(ctx, s, sender, policy, time.Now())
+			if err != nil {
+				logger.Error("Error dispatching webhooks: " + err.Error())
+			}
+			if attempted > 0 {
+				logger.Debug("Attempted webhook deliveries", "count", attempted)
+			}
+		}
+	}
+}
+
+func (s API) GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error) {
+	filter, ok := webhookFilterFromContext(ctx)
+	if !ok {
+		return GetWebhooks200JSONResponse{}, nil
+	}
+	webhooks, err := s.store.GetWebhooks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetWebhooks200JSONResponse, 0, len(webhooks))
+	for _, hook := range webhooks {
+		response = append(response, toWebhook(hook))
+	}
+
+	return response, nil
+}
+
+func (s API) CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error) {
+	if err := webhook.ValidateURL(request.Body.Url); err != nil {
+		return CreateWebhook400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, err.Error())), nil
+	}
+	if len(request.Body.EventTypes) == 0 {
+		return CreateWebhook400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "event_types must not be empty")), nil
+	}
+	var eventTypes entities.StringList
+	for _, eventType := range request.Body.EventTypes {
+		switch eventType {
+		case AccountCreated, AccountFrozen, BalanceAdded, TransferCompleted:
+		default:
+			return CreateWebhook400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "event_types must be among AccountCreated, AccountFrozen, BalanceAdded, TransferCompleted")), nil
+		}
+		if !slices.Contains(eventTypes, string(eventType)) {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+	}
+
+	secret := ""
+	if request.Body.Secret != nil {
+		if len(*request.Body.Secret) < 16 {
+			return CreateWebhook400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "secret must be at least 16 characters long")), nil
+		}
+		secret = *request.Body.Secret
+	} else {
+		generated, err := webhook.NewSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+	var ownerId *int64
+	if !principal.IsBackOffice() {
+		ownerId = principal.CustomerId
+	}
+
+	var response CreateWebhookResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		accountIds := entities.Int64List{}
+		if request.Body.AccountIds != nil {
+			for _, accountId := range *request.Body.AccountIds {
+				account, err := tx.GetAccountById(ctx, accountId)
+				if errors.Is(err, store.ErrAccountNotFound) || (err == nil && !canAccessAccount(ctx, account)) {
+					response = CreateWebhook404ApplicationProblemPlusJSONResponse(accountNotFound(ctx, fmt.Sprintf("account %d not found", accountId)))
+					return errAbortTx
+				}
+				if err != nil {
+					return err
+				}
+				if !slices.Contains(accountIds, accountId) {
+					accountIds = append(accountIds, accountId)
+				}
+			}
+		}
+
+		created, err := tx.CreateWebhook(ctx, entities.Webhook{
+			URL:        request.Body.Url,
+			EventTypes: eventTypes,
+			AccountIds: accountIds,
+			Secret:     secret,
+			OwnerId:    ownerId,
+			CreatedBy:  actorFromContext(ctx),
+			CreatedAt:  time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		// the secret is kept out of the audit log
+		if err := appendAuditEvent(ctx, tx, nil, toWebhook(created)); err != nil {
+			return err
+		}
+
+		result := toWebhook(created)
+		result.Secret = &created.Secret
+		response = CreateWebhook201JSONResponse(result)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s API) DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error) {
+	var response DeleteWebhookResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		hook, err := getAccessibleWebhook(ctx, tx, request.WebhookId)
+		if err != nil {
+			if errors.Is(err, store.ErrWebhookNotFound) {
+				response = DeleteWebhook404ApplicationProblemPlusJSONResponse(notFound(ctx, "webhook not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		if err := tx.DeleteWebhook(ctx, hook.Id); err != nil {
+			return err
+		}
+		response = DeleteWebhook204Response{}
+		return appendAuditEvent(ctx, tx, toWebhook(hook), nil)
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s API) GetWebhookDeliveries(ctx context.Context, request GetWebhookDeliveriesRequestObject) (GetWebhookDeliveriesResponseObject, error) {
+	filter := store.WebhookDeliveryFilter{WebhookId: &request.WebhookId}
+	if request.Params.Status != nil {
+		switch *request.Params.Status {
+		case Pending, Delivered, Dead:
+		default:
+			return GetWebhookDeliveries400ApplicationProblemPlusJSONResponse(invalidRequest(ctx, "status must be one of pending, delivered, dead")), nil
+		}
+		status := entities.WebhookDeliveryStatus(*request.Params.Status)
+		filter.Status = &status
+	}
+
+	if _, err := getAccessibleWebhook(ctx, s.store, request.WebhookId); err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return GetWebhookDeliveries404ApplicationProblemPlusJSONResponse(notFound(ctx, "webhook not found")), nil
+		}
+		return nil, err
+	}
+
+	deliveries, err := s.store.GetWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(GetWebhookDeliveries200JSONResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, toWebhookDelivery(delivery))
+	}
+
+	return response, nil
+}
+
+func (s API) RedeliverWebhookDelivery(ctx context.Context, request RedeliverWebhookDeliveryRequestObject) (RedeliverWebhookDeliveryResponseObject, error) {
+	var response RedeliverWebhookDeliveryResponseObject
+	err := s.store.RunInTx(ctx, func(tx store.Tx) error {
+		if _, err := getAccessibleWebhook(ctx, tx, request.WebhookId); err != nil {
+			if errors.Is(err, store.ErrWebhookNotFound) {
+				response = RedeliverWebhookDelivery404ApplicationProblemPlusJSONResponse(notFound(ctx, "webhook not found"))
+				return errAbortTx
+			}
+			return err
+		}
+		delivery, err := tx.GetWebhookDeliveryById(ctx, request.DeliveryId)
+		if errors.Is(err, store.ErrWebhookDeliveryNotFound) || (err == nil && delivery.WebhookId != request.WebhookId) {
+			response = RedeliverWebhookDelivery404ApplicationProblemPlusJSONResponse(notFound(ctx, "webhook delivery not found"))
+			return errAbortTx
+		}
+		if err != nil {
+			return err
+		}
+		if delivery.Status == entities.WebhookDeliveryStatusPending {
+			response = RedeliverWebhookDelivery409ApplicationProblemPlusJSONResponse(conflict(ctx, "webhook delivery is already pending"))
+			return errAbortTx
+		}
+
+		before := toWebhookDelivery(delivery)
+		now := time.Now()
+		delivery.Status = entities.WebhookDeliveryStatusPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = &now
+		delivery.DeliveredAt = nil
+		if err := tx.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		response = RedeliverWebhookDelivery202JSONResponse(toWebhookDelivery(delivery))
+		return appendAuditEvent(ctx, tx, before, toWebhookDelivery(delivery))
+	})
+	if err != nil && !errors.Is(err, errAbortTx) {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// getAccessibleWebhook returns the webhook, as if it didn't exist when it belongs to another customer.
+func getAccessibleWebhook(ctx context.Context, webhooks store.Webhooks, webhookId int64) (entities.Webhook, error) {
+	hook, err := webhooks.GetWebhookById(ctx, webhookId)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || !principal.CanAccessAccount(hook.OwnerId) {
+		return entities.Webhook{}, store.ErrWebhookNotFound
+	}
+	return hook, nil
+}
+
+// webhookFilterFromContext restricts the listed webhooks to the ones of the caller. It returns false when
+// the caller can't see any webhook.
+func webhookFilterFromContext(ctx context.Context) (store.WebhookFilter, bool) {
+	filter, ok := accountFilterFromContext(ctx)
+	return store.WebhookFilter{OwnerId: filter.OwnerId}, ok
+}
+
+// toWebhook leaves out the secret, which is only returned when the webhook is created.
+func toWebhook(hook entities.Webhook) Webhook {
+	eventTypes := make([]EventType, 0, len(hook.EventTypes))
+	for _, eventType := range hook.EventTypes {
+		eventTypes = append(eventTypes, EventType(eventType))
+	}
+	accountIds := hook.AccountIds
+	if accountIds == nil {
+		accountIds = entities.Int64List{}
+	}
+	return Webhook{
+		Id:         hook.Id,
+		Url:        hook.URL,
+		EventTypes: eventTypes,
+		AccountIds: accountIds,
+		OwnerId:    hook.OwnerId,
+		CreatedBy:  hook.CreatedBy,
+		CreatedAt:  hook.CreatedAt,
+	}
+}
+
+func toWebhookDelivery(delivery entities.WebhookDelivery) WebhookDelivery {
+	var payload map[string]any
+	_ = json.Unmarshal([]byte(delivery.Payload), &payload)
+	return WebhookDelivery{
+		Id:                 delivery.Id,
+		WebhookId:          delivery.WebhookId,
+		EventId:            delivery.EventId,
+		EventType:          EventType(delivery.EventType),
+		Payload:            payload,
+		Status:             WebhookDeliveryStatus(delivery.Status),
+		Attempts:           delivery.Attempts,
+		NextAttemptAt:      delivery.NextAttemptAt,
+		LastAttemptAt:      delivery.LastAttemptAt,
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		DeliveredAt:        delivery.DeliveredAt,
+		CreatedAt:          delivery.CreatedAt,
+	}
+}
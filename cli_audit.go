@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// auditCLIAction records an administrative change made from the command line in the audit log.
+func auditCLIAction(ctx context.Context, s store.Store, operation string, before, after any) error {
+	actor := "cli"
+	if u, err := user.Current(); err == nil {
+		actor = "cli:" + u.Username
+	}
+
+	event, err := entities.NewAuditEvent(actor, operation, before, after)
+	if err != nil {
+		return err
+	}
+	if _, err := s.AppendAuditEvent(ctx, event); err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+	"tiny-bank-api/pkg/client"
+)
+
+type CmdAccounts struct {
+	List   CmdAccountsList   `cmd:"" help:"List the accounts the credentials can access."`
+	Create CmdAccountsCreate `cmd:"" help:"Create an account."`
+}
+
+// ClientFlags configure the client of the commands calling a running server.
+type ClientFlags struct {
+	Server  string        `help:"URL of the API, including its /api prefix." default:"http://localhost:8080/api" env:"TINY_BANK_URL"`
+	APIKey  string        `name:"api-key" help:"API key authenticating the requests." env:"TINY_BANK_API_KEY"`
+	Token   string        `help:"Bearer token authenticating the requests when no API key is set." env:"TINY_BANK_TOKEN"`
+	Output  string        `short:"o" help:"Output format (${enum})." enum:"table,json" default:"table"`
+	Retries int           `help:"Number of times the failed requests are retried." default:"3"`
+	Timeout time.Duration `help:"Timeout of each request." default:"30s"`
+}
+
+func (c ClientFlags) newClient() (*client.BankClient, error) {
+	return client.New(client.Config{
+		BaseURL:     c.Server,
+		APIKey:      c.APIKey,
+		BearerToken: c.Token,
+		HTTPClient:  &http.Client{Timeout: c.Timeout},
+		MaxRetries:  c.Retries,
+	})
+}
+
+// printJSON writes v to the standard output when the output format is json, reporting whether it did.
+func (c ClientFlags) printJSON(v any) (bool, error) {
+	if c.Output != "json" {
+		return false, nil
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return true, encoder.Encode(v)
+}
+
+type CmdAccountsList struct {
+	ClientFlags `embed:""`
+}
+
+func (c CmdAccountsList) Run() error {
+	bank, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	accounts, err := bank.ListAccounts(context.Background())
+	if err != nil {
+		return fmt.Errorf("error listing accounts: %w", err)
+	}
+	if printed, err := c.printJSON(accounts); printed {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tBALANCE\tHELD\tSTATUS\tOWNER\tTIER")
+	for _, account := range accounts {
+		owner := "-"
+		if account.OwnerId != nil {
+			owner = strconv.FormatInt(*account.OwnerId, 10)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%.2f\t%.2f\t%s\t%s\t%s\n", account.Id, account.Name, account.Balance, account.HeldBalance, account.Status, owner, account.Tier)
+	}
+	return tw.Flush()
+}
+
+type CmdAccountsCreate struct {
+	Name        string `arg:"" help:"Name of the account holder."`
+	Owner       *int64 `help:"ID of the customer owning the account, only admins can set it to another customer."`
+	ClientFlags `embed:""`
+}
+
+func (c CmdAccountsCreate) Run() error {
+	bank, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	if err := bank.CreateAccount(context.Background(), c.Name, c.Owner); err != nil {
+		return fmt.Errorf("error creating account: %w", err)
+	}
+	if printed, err := c.printJSON(map[string]string{"name": c.Name}); printed {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Created account %q\n", c.Name)
+	return nil
+}
+
+type CmdTransfer struct {
+	Source      int64   `arg:"" help:"ID of the account the money is taken from."`
+	Target      int64   `arg:"" help:"ID of the account receiving the money."`
+	Amount      float64 `arg:"" help:"Amount to transfer."`
+	ClientFlags `embed:""`
+}
+
+func (c CmdTransfer) Run() error {
+	bank, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	pending, err := bank.Transfer(context.Background(), c.Source, c.Target, c.Amount)
+	if err != nil {
+		return fmt.Errorf("error transferring money: %w", err)
+	}
+
+	if pending == nil {
+		if printed, err := c.printJSON(map[string]any{
+			"source_account_id": c.Source,
+			"target_account_id": c.Target,
+			"amount":            c.Amount,
+			"status":            client.TransferStatusCompleted,
+		}); printed {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Transferred %.2f from account %d to account %d\n", c.Amount, c.Source, c.Target)
+		return nil
+	}
+	if printed, err := c.printJSON(pending); printed {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSOURCE\tTARGET\tAMOUNT\tSTATUS\tEXPIRES")
+	expires := "never"
+	if pending.ExpiresAt != nil {
+		expires = pending.ExpiresAt.Format(time.RFC3339)
+	}
+	fmt.Fprintf(tw, "%d\t%d\t%d\t%.2f\t%s\t%s\n", pending.Id, pending.SourceAccountId, pending.TargetAccountId, pending.Amount, pending.Status, expires)
+	return tw.Flush()
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/store"
+)
+
+type CmdKeys struct {
+	Create CmdKeysCreate `cmd:"" help:"Mint a new API key, the key is only shown once."`
+	Revoke CmdKeysRevoke `cmd:"" help:"Revoke an API key."`
+	List   CmdKeysList   `cmd:"" help:"List API keys."`
+}
+
+type CmdKeysCreate struct {
+	Name      string        `arg:"" help:"Name describing who or what uses the key."`
+	Scopes    []string      `name:"scope" short:"s" help:"Scope granted to the key, can be repeated (${enum})." enum:"accounts:read,accounts:write,transfers:create,admin" required:""`
+	ExpiresIn time.Duration `help:"Lifetime of the key, it never expires when not set."`
+	Customer  *int64        `help:"ID of the customer the key acts for, it can then only access their accounts."`
+	DBFlags   `embed:""`
+}
+
+func (c CmdKeysCreate) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	var expiresAt *time.Time
+	if c.ExpiresIn > 0 {
+		t := time.Now().Add(c.ExpiresIn)
+		expiresAt = &t
+	}
+
+	if c.Customer != nil {
+		if _, err := s.GetCustomerById(ctx, *c.Customer); err != nil {
+			if errors.Is(err, store.ErrCustomerNotFound) {
+				return fmt.Errorf("customer %d doesn't exist", *c.Customer)
+			}
+			return fmt.Errorf("error looking up customer: %w", err)
+		}
+	}
+
+	secret, key, err := auth.NewAPIKey(c.Name, c.Scopes, expiresAt)
+	if err != nil {
+		return fmt.Errorf("error generating api key: %w", err)
+	}
+	key.CustomerId = c.Customer
+	id, err := s.CreateAPIKey(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error storing api key: %w", err)
+	}
+	// the hash is left out of the audit log on purpose
+	err = auditCLIAction(ctx, s, "keys.create", nil, map[string]any{
+		"id":          id,
+		"name":        key.Name,
+		"prefix":      key.Prefix,
+		"scopes":      key.Scopes,
+		"customer_id": key.CustomerId,
+		"expires_at":  key.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Created API key %d with scopes %s, store it safely as it can't be shown again:\n", id, strings.Join(c.Scopes, ", "))
+	fmt.Println(secret)
+	return nil
+}
+
+type CmdKeysRevoke struct {
+	Id      int64 `arg:"" help:"ID of the key to revoke."`
+	DBFlags `embed:""`
+}
+
+func (c CmdKeysRevoke) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if err := s.RevokeAPIKey(ctx, c.Id); err != nil {
+		if errors.Is(err, store.ErrAPIKeyNotFound) {
+			return fmt.Errorf("api key %d doesn't exist", c.Id)
+		}
+		return fmt.Errorf("error revoking api key: %w", err)
+	}
+	if err := auditCLIAction(ctx, s, "keys.revoke", nil, map[string]any{"id": c.Id}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Revoked API key %d\n", c.Id)
+	return nil
+}
+
+type CmdKeysList struct {
+	DBFlags `embed:""`
+}
+
+func (c CmdKeysList) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	keys, err := s.GetAPIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing api keys: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCUSTOMER\tEXPIRES\tSTATUS")
+	for _, key := range keys {
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expires = key.ExpiresAt.Format(time.RFC3339)
+		}
+		customer := "-"
+		if key.CustomerId != nil {
+			customer = strconv.FormatInt(*key.CustomerId, 10)
+		}
+		status := "active"
+		if !key.Usable(time.Now()) {
+			status = "inactive"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", key.Id, key.Name, key.Prefix, strings.Join(key.Scopes, ","), customer, expires, status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+	"tiny-bank-api/store"
+)
+
+type CmdRoles struct {
+	Assign   CmdRolesAssign   `cmd:"" help:"Assign a role to a subject."`
+	Unassign CmdRolesUnassign `cmd:"" help:"Remove a role from a subject."`
+	List     CmdRolesList     `cmd:"" help:"List role assignments."`
+}
+
+type CmdRolesAssign struct {
+	Subject string `arg:"" help:"Subject to assign the role to, apikey:<id> for API keys or jwt:<sub> for bearer tokens."`
+	Role    string `arg:"" help:"Role to assign (${enum})." enum:"support,operator,compliance,admin"`
+	DBFlags `embed:""`
+}
+
+func (c CmdRolesAssign) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if err := s.AssignRole(ctx, c.Subject, c.Role); err != nil {
+		return fmt.Errorf("error assigning role: %w", err)
+	}
+	if err := auditCLIAction(ctx, s, "roles.assign", nil, map[string]any{"subject": c.Subject, "role": c.Role}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Assigned role %s to %s\n", c.Role, c.Subject)
+	return nil
+}
+
+type CmdRolesUnassign struct {
+	Subject string `arg:"" help:"Subject to remove the role from."`
+	Role    string `arg:"" help:"Role to remove (${enum})." enum:"support,operator,compliance,admin"`
+	DBFlags `embed:""`
+}
+
+func (c CmdRolesUnassign) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if err := s.UnassignRole(ctx, c.Subject, c.Role); err != nil {
+		if errors.Is(err, store.ErrRoleAssignmentNotFound) {
+			return fmt.Errorf("%s doesn't have role %s", c.Subject, c.Role)
+		}
+		return fmt.Errorf("error unassigning role: %w", err)
+	}
+	if err := auditCLIAction(ctx, s, "roles.unassign", map[string]any{"subject": c.Subject, "role": c.Role}, nil); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Removed role %s from %s\n", c.Role, c.Subject)
+	return nil
+}
+
+type CmdRolesList struct {
+	DBFlags `embed:""`
+}
+
+func (c CmdRolesList) Run() error {
+	ctx := context.Background()
+	s, closeStore, err := c.openPersistentStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	assignments, err := s.GetRoleAssignments(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing role assignments: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUBJECT\tROLE\tASSIGNED")
+	for _, assignment := range assignments {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", assignment.Subject, assignment.Role, assignment.CreatedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
@@ -5,53 +5,261 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 	"tiny-bank-api/api"
-	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/grpcapi"
+	"tiny-bank-api/pkg/auth"
 	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/metrics"
+	"tiny-bank-api/pkg/notify"
+	"tiny-bank-api/pkg/outbox"
+	"tiny-bank-api/pkg/ratelimit"
+	"tiny-bank-api/pkg/risk"
+	"tiny-bank-api/pkg/sanctions"
+	"tiny-bank-api/pkg/webhook"
 	"tiny-bank-api/store"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 type CmdServe struct {
-	ListenAddress    string `help:"Port to listen on." default:"localhost:8080" env:"LISTEN_PORT"`
-	PostgresUser     string `name:"postgresuser" help:"Username to authenticate with." default:"postgres" env:"POSTGRES_USER"`
-	PostgresPassword string `name:"postgrespassword" help:"Password to authenticate with." default:"postgres" env:"POSTGRES_PASSWORD"`
-	PostgresHost     string `name:"postgreshost" help:"Host of the postgresql database." default:"localhost:5432" env:"POSTGRES_HOST"`
+	ListenAddress            string        `help:"Port to listen on." default:"localhost:8080" env:"LISTEN_PORT"`
+	GRPCListenAddress        string        `name:"grpc-listen-address" help:"Address the gRPC API listens on, the gRPC API is disabled when empty." default:"localhost:9090" env:"GRPC_LISTEN_ADDRESS"`
+	AdminListenAddress       string        `name:"admin-listen-address" help:"Address the admin server serving the Prometheus metrics at /metrics listens on, metrics are disabled when empty." default:"localhost:9100" env:"ADMIN_LISTEN_ADDRESS"`
+	Currency                 string        `name:"currency" help:"ISO 4217 code of the currency of the accounts, labeling the amounts in the metrics." default:"EUR" env:"CURRENCY"`
+	ValidateResponses        bool          `name:"validate-responses" help:"Validate the responses against the OpenAPI spec and answer 500 to the mismatching ones, for development as every response is buffered." env:"VALIDATE_RESPONSES"`
+	JWKS                     string        `name:"jwks" help:"File path or URL of the JWKS used to verify bearer tokens, bearer tokens are rejected when not set." env:"JWKS"`
+	JWKSRefreshInterval      time.Duration `name:"jwks-refresh-interval" help:"How often the JWKS is reloaded to pick up rotated keys." default:"5m" env:"JWKS_REFRESH_INTERVAL"`
+	JWTIssuer                string        `name:"jwt-issuer" help:"Expected iss claim of bearer tokens." env:"JWT_ISSUER"`
+	JWTAudience              string        `name:"jwt-audience" help:"Expected aud claim of bearer tokens." default:"tiny-bank-api" env:"JWT_AUDIENCE"`
+	RiskRules                string        `name:"risk-rules" help:"Path of the YAML file of risk rules evaluated before transfers, every transfer is allowed when not set." type:"existingfile" env:"RISK_RULES"`
+	RiskReloadInterval       time.Duration `name:"risk-rules-reload-interval" help:"How often the risk rules file is checked for changes." default:"10s" env:"RISK_RULES_RELOAD_INTERVAL"`
+	ApprovalThreshold        float64       `name:"approval-threshold" help:"Amount above which transfers wait for the approval of a second user, 0 disables approvals." default:"0" env:"APPROVAL_THRESHOLD"`
+	ApprovalTTL              time.Duration `name:"approval-ttl" help:"How long transfers wait for approval before expiring, 0 keeps them pending until approved or rejected." default:"24h" env:"APPROVAL_TTL"`
+	ApprovalExpiryInterval   time.Duration `name:"approval-expiry-interval" help:"How often the transfers pending approval are checked for expiry." default:"1m" env:"APPROVAL_EXPIRY_INTERVAL"`
+	SanctionsList            string        `name:"sanctions-list" help:"Path of the .csv or .xml sanctions list the account holder names are screened against, nothing is screened when not set." type:"existingfile" env:"SANCTIONS_LIST"`
+	SanctionsReloadInterval  time.Duration `name:"sanctions-list-reload-interval" help:"How often the sanctions list file is checked for changes." default:"1m" env:"SANCTIONS_LIST_RELOAD_INTERVAL"`
+	SanctionsReviewThreshold float64       `name:"sanctions-review-threshold" help:"Similarity from 0 to 1 from which names matching the sanctions list are queued for review." default:"0.9" env:"SANCTIONS_REVIEW_THRESHOLD"`
+	SanctionsBlockThreshold  float64       `name:"sanctions-block-threshold" help:"Similarity from which names matching the sanctions list are refused right away, above 1 to always queue them for review." default:"1" env:"SANCTIONS_BLOCK_THRESHOLD"`
+	EventsSink               string        `name:"events-sink" help:"Where the domain events of the outbox are published: a nats:// URL, a file path or - for the standard output. Events stay in the outbox when not set." env:"EVENTS_SINK"`
+	EventsSubjectPrefix      string        `name:"events-subject-prefix" help:"Prefix of the NATS subjects the events are published to, followed by the account id and the event type." default:"tinybank.accounts" env:"EVENTS_SUBJECT_PREFIX"`
+	EventsRelayInterval      time.Duration `name:"events-relay-interval" help:"How often the outbox is checked for events to publish." default:"1s" env:"EVENTS_RELAY_INTERVAL"`
+	EventsBatchSize          int           `name:"events-batch-size" help:"Maximum number of events published in a single unit of work." default:"100" env:"EVENTS_BATCH_SIZE"`
+	EventsStreamPollInterval time.Duration `name:"events-stream-poll-interval" help:"How often the Server-Sent Events streams check for new events, on top of the notifications of postgres." default:"1s" env:"EVENTS_STREAM_POLL_INTERVAL"`
+	EventsStreamHeartbeat    time.Duration `name:"events-stream-heartbeat" help:"How often the idle Server-Sent Events streams send a heartbeat." default:"15s" env:"EVENTS_STREAM_HEARTBEAT"`
+	WebhookDispatchInterval  time.Duration `name:"webhook-dispatch-interval" help:"How often the webhook deliveries due are attempted." default:"1s" env:"WEBHOOK_DISPATCH_INTERVAL"`
+	WebhookTimeout           time.Duration `name:"webhook-timeout" help:"How long webhook receivers have to answer before the attempt fails." default:"10s" env:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts       int           `name:"webhook-max-attempts" help:"Number of failed attempts after which a webhook delivery is dead." default:"8" env:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryBackoff      time.Duration `name:"webhook-retry-backoff" help:"Delay after the first failed attempt of a webhook delivery, doubled after each following one." default:"30s" env:"WEBHOOK_RETRY_BACKOFF"`
+	WebhookMaxBackoff        time.Duration `name:"webhook-max-backoff" help:"Maximum delay between the attempts of a webhook delivery." default:"1h" env:"WEBHOOK_MAX_BACKOFF"`
+	WebhookAllowPrivate      bool          `name:"webhook-allow-private-networks" help:"Deliver webhooks to loopback, private and link-local addresses, e.g. for local development." env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+	RateLimitFlags           `embed:"" prefix:"rate-limit-"`
+	DBFlags                  `embed:""`
+}
+
+// RateLimitFlags configure the rate limits. Limits are written <requests>/<period>[:<burst>], e.g. 100/1m:20
+// for 100 requests per minute in bursts of up to 20 requests, or off.
+type RateLimitFlags struct {
+	Backend        string          `help:"Where the rate limit buckets are kept (${enum}), postgres makes the limits hold across instances." enum:"off,memory,postgres" default:"memory" env:"RATE_LIMIT_BACKEND"`
+	PrincipalRead  ratelimit.Limit `help:"Limit of each API key or token on the operations not moving money." default:"20/s:40" env:"RATE_LIMIT_PRINCIPAL_READ"`
+	PrincipalMoney ratelimit.Limit `help:"Limit of each API key or token on transfers and deposits." default:"2/s:5" env:"RATE_LIMIT_PRINCIPAL_MONEY"`
+	IPRead         ratelimit.Limit `name:"ip-read" help:"Limit of each client IP on the operations not moving money." default:"50/s:100" env:"RATE_LIMIT_IP_READ"`
+	IPMoney        ratelimit.Limit `name:"ip-money" help:"Limit of each client IP on transfers and deposits." default:"5/s:10" env:"RATE_LIMIT_IP_MONEY"`
+}
+
+// newLimiter creates the limiter selected by --rate-limit-backend, nil when rate limiting is off. The
+// limiter forgets idle buckets in the background until ctx is done.
+func (c RateLimitFlags) newLimiter(ctx context.Context, s store.Store) (ratelimit.Limiter, error) {
+	switch c.Backend {
+	case "off":
+		return nil, nil
+	case "postgres":
+		postgresStore, ok := s.(store.PostgresStore)
+		if !ok {
+			return nil, errors.New("--rate-limit-backend=postgres requires --db-driver=postgres")
+		}
+		limiter := store.NewPostgresRateLimiter(postgresStore)
+		go limiter.Run(ctx, time.Minute, c.slowestRefill()+time.Minute)
+		return limiter, nil
+	default:
+		limiter := ratelimit.NewMemoryLimiter()
+		go limiter.Run(ctx, time.Minute)
+		return limiter, nil
+	}
+}
+
+func (c RateLimitFlags) limits() api.RateLimits {
+	return api.RateLimits{
+		PrincipalRead:  c.PrincipalRead,
+		PrincipalMoney: c.PrincipalMoney,
+		IPRead:         c.IPRead,
+		IPMoney:        c.IPMoney,
+	}
+}
+
+// slowestRefill is the longest time a bucket takes to fill up again.
+func (c RateLimitFlags) slowestRefill() time.Duration {
+	var slowest time.Duration
+	for _, limit := range []ratelimit.Limit{c.PrincipalRead, c.PrincipalMoney, c.IPRead, c.IPMoney} {
+		if limit.Unlimited() {
+			continue
+		}
+		slowest = max(slowest, time.Duration(float64(limit.Burst)/limit.Rate*float64(time.Second)))
+	}
+	return slowest
 }
 
 func (c CmdServe) Run() error {
-	postgresURL := "postgres://" + c.PostgresUser + ":" + c.PostgresPassword + "@" + c.PostgresHost + "/sumup_bank"
 	logger := logging.ProdLogger()
 
 	ctx, cancelFunc := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 	defer cancelFunc()
 
-	db, err := database.NewConnection(ctx, postgresURL)
+	var m *metrics.Metrics
+	if c.AdminListenAddress != "" {
+		m = metrics.New(c.Currency)
+	}
+
+	s, closeStore, err := c.openStore(ctx, logger, m)
 	if err != nil {
-		logger.Error("Error creating database connection: " + err.Error())
-		return fmt.Errorf("error creating database connection: %w", err)
+		logger.Error("Error opening store: " + err.Error())
+		return fmt.Errorf("error opening store: %w", err)
 	}
-	defer func() {
-		if err := db.Close(); err != nil {
-			logger.Error("error closing db conn: " + err.Error())
+	defer closeStore()
+
+	// the event streams only end with their clients, they are closed first for the server to shut down
+	streamsCtx, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
+
+	opts := ServiceOptions{
+		Validation: api.ValidationOptions{ValidateResponses: c.ValidateResponses},
+		API: api.Options{
+			ApprovalThreshold:  c.ApprovalThreshold,
+			ApprovalTTL:        c.ApprovalTTL,
+			StreamPollInterval: c.EventsStreamPollInterval,
+			StreamHeartbeat:    c.EventsStreamHeartbeat,
+			StreamsDone:        streamsCtx.Done(),
+			Metrics:            m,
+		},
+	}
+	if c.DBDriver == "postgres" {
+		// the events committed by every replica wake the streams up, polling only catches up on the missed ones
+		opts.API.EventNotifications = notify.NewHub()
+		go notify.ListenPostgres(ctx, logger, c.postgresURL(), notify.Channel, opts.API.EventNotifications)
+	}
+	if c.JWKS != "" {
+		if c.JWTIssuer == "" {
+			return errors.New("--jwt-issuer is required with --jwks")
 		}
-	}()
-	sqldb := database.LoggingDB{SQLDB: db, Logger: logger}
-	s := store.NewStore(sqldb)
+		jwks, err := auth.NewJWKS(ctx, c.JWKS)
+		if err != nil {
+			logger.Error("Error loading JWKS: " + err.Error())
+			return fmt.Errorf("error loading jwks: %w", err)
+		}
+		go jwks.Run(ctx, c.JWKSRefreshInterval)
+		opts.JWTVerifier = auth.NewJWTVerifier(jwks, c.JWTIssuer, c.JWTAudience)
+	}
 
-	svc := NewService(logger, s)
+	if c.RiskRules != "" {
+		opts.API.RiskEngine, err = risk.NewEngine(c.RiskRules)
+		if err != nil {
+			logger.Error("Error loading risk rules: " + err.Error())
+			return err
+		}
+		go opts.API.RiskEngine.Run(ctx, c.RiskReloadInterval)
+	}
+	if c.SanctionsList != "" {
+		opts.API.Screener, err = sanctions.NewScreener(c.SanctionsList, sanctions.Thresholds{Review: c.SanctionsReviewThreshold, Block: c.SanctionsBlockThreshold})
+		if err != nil {
+			logger.Error("Error loading sanctions list: " + err.Error())
+			return err
+		}
+		go opts.API.Screener.Run(ctx, c.SanctionsReloadInterval)
+	}
+	if c.ApprovalThreshold > 0 && c.ApprovalTTL > 0 {
+		go api.RunTransferExpiry(ctx, logger, s, c.ApprovalExpiryInterval)
+	}
+	if c.EventsSink != "" {
+		sink, err := outbox.Open(c.EventsSink, c.EventsSubjectPrefix)
+		if err != nil {
+			logger.Error("Error opening events sink: " + err.Error())
+			return err
+		}
+		defer func() {
+			if err := sink.Close(); err != nil {
+				logger.Error("Error closing events sink: " + err.Error())
+			}
+		}()
+		go api.RunOutboxRelay(ctx, logger, s, sink, c.EventsRelayInterval, c.EventsBatchSize)
+	}
+	go api.RunWebhookDispatcher(ctx, logger, s, webhook.NewSender(c.WebhookTimeout, c.WebhookAllowPrivate), webhook.RetryPolicy{
+		MaxAttempts: c.WebhookMaxAttempts,
+		Backoff:     c.WebhookRetryBackoff,
+		MaxBackoff:  c.WebhookMaxBackoff,
+	}, c.WebhookDispatchInterval)
+
+	opts.RateLimiter, err = c.newLimiter(ctx, s)
+	if err != nil {
+		return err
+	}
+	opts.RateLimits = c.limits()
+
+	svc, err := NewService(logger, s, opts)
+	if err != nil {
+		return err
+	}
 
 	server := &http.Server{
 		Addr:    c.ListenAddress,
 		Handler: svc,
 	}
+	server.RegisterOnShutdown(closeStreams)
+
+	// the metrics are served apart from the API, so they aren't exposed with it
+	var adminServer *http.Server
+	if m != nil {
+		adminRouter := chi.NewRouter()
+		adminRouter.Handle("/metrics", m.Handler())
+		adminServer = &http.Server{
+			Addr:    c.AdminListenAddress,
+			Handler: adminRouter,
+		}
+		go func() {
+			logger.Info("Starting admin server", "address", c.AdminListenAddress)
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("error starting admin server: " + err.Error())
+			}
+		}()
+	}
+
+	var grpcServer *grpc.Server
+	var grpcHealth *health.Server
+	if c.GRPCListenAddress != "" {
+		listener, err := net.Listen("tcp", c.GRPCListenAddress)
+		if err != nil {
+			logger.Error("Error listening for gRPC: " + err.Error())
+			return err
+		}
+		grpcServer, grpcHealth = grpcapi.NewGRPCServer(logger, s, api.NewAPI(logger, s, opts.API), grpcapi.Options{
+			JWTVerifier: opts.JWTVerifier,
+			RateLimiter: opts.RateLimiter,
+			RateLimits:  opts.RateLimits,
+		})
+		go func() {
+			logger.Info("Starting gRPC server", "address", c.GRPCListenAddress)
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Error("error starting grpc server: " + err.Error())
+			}
+		}()
+	}
 
 	go func() {
 		logger.Info("Starting HTTP server", "address", c.ListenAddress)
@@ -70,19 +278,75 @@ func (c CmdServe) Run() error {
 		logger.Error("error shutting down http server: " + err.Error())
 	}
 
+	if adminServer != nil {
+		if err := adminServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("error shutting down admin server: " + err.Error())
+		}
+	}
+
+	if grpcServer != nil {
+		logger.Info("Shutting down gRPC server...")
+		// health checks report NOT_SERVING while the calls in flight finish
+		grpcHealth.Shutdown()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+		}
+	}
+
 	return nil
 }
 
-func NewService(logger *slog.Logger, store store.Store) *chi.Mux {
+// ServiceOptions are the optional features of the service.
+type ServiceOptions struct {
+	// JWTVerifier enables bearer token authentication.
+	JWTVerifier *auth.JWTVerifier
+	// RateLimiter enables rate limiting with RateLimits.
+	RateLimiter ratelimit.Limiter
+	RateLimits  api.RateLimits
+	// Validation configures the validation of the requests against the spec.
+	Validation api.ValidationOptions
+	// API are the optional features of the API, like risk rules and approvals.
+	API api.Options
+}
+
+func NewService(logger *slog.Logger, store store.Store, opts ServiceOptions) (*chi.Mux, error) {
+	validate, err := api.Validate(opts.Validation)
+	if err != nil {
+		return nil, err
+	}
 
-	apiHandler := api.NewAPI(logger, store)
+	var instrument func(http.Handler) http.Handler
+	if opts.API.Metrics != nil {
+		instrument, err = api.Instrument(opts.API.Metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	apiHandler := api.NewAPI(logger, store, opts.API)
+	// the last middleware runs first, so requests over their limits are rejected before loading roles
+	middlewares := []api.StrictMiddlewareFunc{api.Authorize(store), api.RecordRequestMetadata}
+	if opts.RateLimiter != nil {
+		middlewares = append(middlewares, api.RateLimit(opts.RateLimiter, opts.RateLimits))
+	}
 	apiStrictHandler := api.NewStrictHandlerWithOptions(
 		apiHandler,
-		nil,
-		api.StrictHTTPServerOptions{ResponseErrorHandlerFunc: HandleResponseError},
+		middlewares,
+		api.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  api.HandleRequestError,
+			ResponseErrorHandlerFunc: api.HandleResponseError,
+		},
 	)
 
 	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
 	router.Use(middleware.Recoverer)
 	router.Use(injectRequestIntoContext)
 
@@ -91,6 +355,17 @@ func NewService(logger *slog.Logger, store store.Store) *chi.Mux {
 	})
 
 	router.Route("/api", func(r chi.Router) {
+		if instrument != nil {
+			// first, to measure the requests the auth middlewares reject
+			r.Use(instrument)
+		}
+		r.Use(auth.APIKeyMiddleware(store))
+		if opts.JWTVerifier != nil {
+			r.Use(auth.JWTMiddleware(opts.JWTVerifier, store))
+		}
+		// set before mounting the operations, which inherit them
+		r.NotFound(api.NotFound)
+		r.MethodNotAllowed(api.MethodNotAllowed)
 
 		// Serve Swagger UI documentation
 		r.Get("/openapi.yaml", func(w http.ResponseWriter, req *http.Request) {
@@ -114,15 +389,13 @@ func NewService(logger *slog.Logger, store store.Store) *chi.Mux {
 				</html>`))
 		})
 
-		r.Mount("/", api.HandlerFromMux(apiStrictHandler, nil))
+		r.Mount("/", api.HandlerWithOptions(apiStrictHandler, api.ChiServerOptions{
+			// the last middleware runs first, so requests missing scopes get a 403 before being validated
+			Middlewares:      []api.MiddlewareFunc{validate, api.RequireScopes},
+			ErrorHandlerFunc: api.HandleParamError,
+		}))
 	})
-	return router
-}
-
-func HandleResponseError(w http.ResponseWriter, r *http.Request, err error) {
-	slog.Error("Failed to handle request.", "error", err, "path", r.URL.Path, "method", r.Method)
-
-	w.WriteHeader(http.StatusInternalServerError)
+	return router, nil
 }
 
 type requestKey struct{}
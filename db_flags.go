@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/metrics"
+	"tiny-bank-api/store"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBFlags are the flags selecting the storage backend, shared by the commands needing the store.
+type DBFlags struct {
+	DBDriver         string `name:"db-driver" help:"Storage backend to use (${enum})." enum:"postgres,sqlite,memory" default:"postgres" env:"DB_DRIVER"`
+	SQLitePath       string `name:"sqlite-path" help:"Path of the sqlite database file, used with --db-driver=sqlite." default:"tiny-bank.db" env:"SQLITE_PATH"`
+	PostgresUser     string `name:"postgresuser" help:"Username to authenticate with." default:"postgres" env:"POSTGRES_USER"`
+	PostgresPassword string `name:"postgrespassword" help:"Password to authenticate with." default:"postgres" env:"POSTGRES_PASSWORD"`
+	PostgresHost     string `name:"postgreshost" help:"Host of the postgresql database." default:"localhost:5432" env:"POSTGRES_HOST"`
+}
+
+// openStore creates the store selected by --db-driver, the returned func releases its resources. The
+// queries and the connection pool of the database are measured in m when not nil.
+func (c DBFlags) openStore(ctx context.Context, logger *slog.Logger, m *metrics.Metrics) (store.Store, func(), error) {
+	var db *sqlx.DB
+	var err error
+	switch c.DBDriver {
+	case "memory":
+		return store.NewMemoryStore(), func() {}, nil
+	case "sqlite":
+		db, err = database.NewSQLiteConnection(ctx, c.SQLitePath)
+	default:
+		db, err = database.NewConnection(ctx, c.postgresURL())
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating database connection: %w", err)
+	}
+	closeDB := func() {
+		if err := db.Close(); err != nil {
+			logger.Error("error closing db conn: " + err.Error())
+		}
+	}
+
+	var sqldb database.SQLDB = database.LoggingDB{SQLDB: db, Logger: logger}
+	if m != nil {
+		if err := m.RegisterDB(db.DB, c.DBDriver); err != nil {
+			closeDB()
+			return nil, nil, fmt.Errorf("error registering db metrics: %w", err)
+		}
+		sqldb = database.MetricsDB{SQLDB: sqldb, Queries: m.DBQueryDuration}
+	}
+	if c.DBDriver == "sqlite" {
+		s := store.NewSQLiteStore(sqldb)
+		if err := s.Migrate(ctx); err != nil {
+			closeDB()
+			return nil, nil, fmt.Errorf("error migrating sqlite db: %w", err)
+		}
+		return s, closeDB, nil
+	}
+	return store.NewPostgresStore(sqldb), closeDB, nil
+}
+
+func (c DBFlags) postgresURL() string {
+	return "postgres://" + c.PostgresUser + ":" + c.PostgresPassword + "@" + c.PostgresHost + "/sumup_bank"
+}
+
+// openPersistentStore opens the store for the management commands, for which the in-memory store makes no sense.
+func (c DBFlags) openPersistentStore(ctx context.Context) (store.Store, func(), error) {
+	if c.DBDriver == "memory" {
+		return nil, nil, errors.New("the memory driver can't be managed from the command line")
+	}
+	s, closeStore, err := c.openStore(ctx, logging.ProdLogger(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening store: %w", err)
+	}
+	return s, closeStore, nil
+}
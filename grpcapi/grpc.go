@@ -0,0 +1,28 @@
+package grpcapi
+
+import (
+	"log/slog"
+	"tiny-bank-api/api"
+	"tiny-bank-api/grpcapi/tinybankv1"
+	"tiny-bank-api/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewGRPCServer creates a gRPC server serving the AccountService with the handlers of apiHandler, along
+// with the health checking and server reflection services. The returned health server reports every
+// service as serving, it should be shut down before the gRPC server to drain the clients.
+func NewGRPCServer(logger *slog.Logger, s store.Store, apiHandler *api.API, opts Options) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unaryInterceptor(logger, s, opts)))
+	tinybankv1.RegisterAccountServiceServer(server, NewServer(apiHandler))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(tinybankv1.AccountService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server, healthServer
+}
@@ -0,0 +1,201 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/grpcapi/tinybankv1"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/ratelimit"
+	"tiny-bank-api/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiKeyMetadata        = "x-api-key"
+	authorizationMetadata = "authorization"
+	requestIdMetadata     = "x-request-id"
+)
+
+// operation is the REST counterpart of a method, whose operationId the policy and the rate limits are
+// keyed by, and the scopes the spec requires for it.
+type operation struct {
+	id     string
+	scopes []string
+}
+
+// operations maps the methods of the AccountService to their REST operation. Methods missing from it are
+// denied, like the operations missing from the policy.
+var operations = map[string]operation{
+	tinybankv1.AccountService_ListAccounts_FullMethodName:  {"GetAccounts", []string{"accounts:read"}},
+	tinybankv1.AccountService_GetAccount_FullMethodName:    {"GetAccount", []string{"accounts:read"}},
+	tinybankv1.AccountService_CreateAccount_FullMethodName: {"CreateAccount", []string{"accounts:write"}},
+	tinybankv1.AccountService_AddBalance_FullMethodName:    {"AddBalanceToAccount", []string{"accounts:write"}},
+	tinybankv1.AccountService_TransferMoney_FullMethodName: {"TransferMoney", []string{"transfers:create"}},
+}
+
+// Options are the optional features of the gRPC server, the same as the REST service's.
+type Options struct {
+	// JWTVerifier enables bearer token authentication.
+	JWTVerifier *auth.JWTVerifier
+	// RateLimiter enables rate limiting with RateLimits, sharing the buckets of the REST API.
+	RateLimiter ratelimit.Limiter
+	RateLimits  api.RateLimits
+}
+
+// unaryInterceptor authenticates, rate limits and authorizes the calls of the AccountService in the order
+// of the REST middlewares, and records their metadata for the audit events. The other services, health
+// and reflection, are public.
+func unaryInterceptor(logger *slog.Logger, s store.Store, opts Options) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := info.Server.(*Server); !ok {
+			return handler(ctx, req)
+		}
+		op, ok := operations[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method is not allowed")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		principal, authenticated, err := authenticate(ctx, s, opts.JWTVerifier, md)
+		if err != nil {
+			return nil, toStatus(logger, err)
+		}
+		if !authenticated {
+			return nil, status.Error(codes.Unauthenticated, "missing credentials")
+		}
+		if !principal.HasScopes(op.scopes) {
+			return nil, status.Error(codes.PermissionDenied, "missing required scopes: "+strings.Join(op.scopes, ", "))
+		}
+		ctx = auth.WithPrincipal(ctx, principal)
+
+		clientIP := peerIP(ctx)
+		if opts.RateLimiter != nil {
+			if err := rateLimit(ctx, opts, op.id, clientIP); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx = api.WithRequestMetadata(ctx, op.id, requestId(md), clientIP)
+		ctx, err = api.AuthorizeOperation(ctx, s, op.id)
+		if err != nil {
+			return nil, toStatus(logger, err)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(logger, err)
+		}
+		return resp, nil
+	}
+}
+
+// authenticate returns the principal of the API key or of the bearer token of the call, calls carrying both
+// are rejected like with the REST middlewares.
+func authenticate(ctx context.Context, s store.Store, verifier *auth.JWTVerifier, md metadata.MD) (auth.Principal, bool, error) {
+	var token string
+	hasToken := false
+	for _, value := range md.Get(authorizationMetadata) {
+		if token, hasToken = auth.BearerToken(value); hasToken {
+			break
+		}
+	}
+
+	if secrets := md.Get(apiKeyMetadata); len(secrets) > 0 && secrets[0] != "" {
+		if hasToken {
+			return auth.Principal{}, false, auth.ErrConflictingCredentials
+		}
+		principal, err := auth.AuthenticateAPIKey(ctx, s, secrets[0])
+		return principal, err == nil, err
+	}
+	if verifier == nil || !hasToken {
+		return auth.Principal{}, false, nil
+	}
+	principal, err := auth.AuthenticateBearerToken(ctx, verifier, s, token)
+	return principal, err == nil, err
+}
+
+// rateLimit takes the call from the buckets of the client, sending where it stands in the response headers
+// like the REST API.
+func rateLimit(ctx context.Context, opts Options, operationID, clientIP string) error {
+	reported := api.ApplyRateLimits(ctx, opts.RateLimiter, opts.RateLimits, operationID, clientIP)
+	if reported == nil {
+		return nil
+	}
+
+	header := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(reported.Limit),
+		"ratelimit-remaining", strconv.Itoa(reported.Remaining),
+		"ratelimit-reset", strconv.Itoa(ceilSeconds(reported.Reset)),
+	)
+	if !reported.Allowed {
+		retryAfter := ceilSeconds(reported.RetryAfter)
+		header.Set("retry-after", strconv.Itoa(retryAfter))
+		_ = grpc.SetHeader(ctx, header)
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %d seconds", retryAfter)
+	}
+	_ = grpc.SetHeader(ctx, header)
+	return nil
+}
+
+// toStatus turns the errors of the auth layer and of the handlers into status errors, hiding the internal
+// errors from the client.
+func toStatus(logger *slog.Logger, err error) error {
+	var credentialsErr auth.CredentialsError
+	switch {
+	case errors.As(err, &credentialsErr):
+		return status.Error(codes.Unauthenticated, credentialsErr.Reason)
+	case errors.Is(err, api.ErrMissingCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, api.ErrRoleNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, store.ErrTxConflict):
+		logger.Warn("Call conflicted with concurrent ones.", "error", err)
+		return status.Error(codes.Aborted, "the resources were modified by concurrent calls, retry the call")
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	logger.Error("Failed to handle call.", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// peerIP is the address the call comes from.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	ip, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return ip
+}
+
+// requestId is the x-request-id sent by the client, or a random one.
+func requestId(md metadata.MD) string {
+	if ids := md.Get(requestIdMetadata); len(ids) > 0 && ids[0] != "" {
+		return ids[0]
+	}
+	random := make([]byte, 8)
+	_, _ = rand.Read(random)
+	return hex.EncodeToString(random)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
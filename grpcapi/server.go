@@ -0,0 +1,183 @@
+// Package grpcapi serves the account operations of the REST API over gRPC. The calls go through the same
+// handlers as the REST requests, so both APIs share their business rules, audit events and domain events.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"tiny-bank-api/api"
+	"tiny-bank-api/grpcapi/tinybankv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the AccountService on top of the REST handlers, mapping their responses to messages
+// and their error responses to status codes.
+type Server struct {
+	tinybankv1.UnimplementedAccountServiceServer
+	api *api.API
+}
+
+func NewServer(api *api.API) *Server {
+	return &Server{api: api}
+}
+
+func (s *Server) ListAccounts(ctx context.Context, request *tinybankv1.ListAccountsRequest) (*tinybankv1.ListAccountsResponse, error) {
+	response, err := s.api.GetAccounts(ctx, api.GetAccountsRequestObject{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := response.(type) {
+	case api.GetAccounts200JSONResponse:
+		accounts := make([]*tinybankv1.Account, 0, len(response))
+		for _, account := range response {
+			accounts = append(accounts, toAccount(account))
+		}
+		return &tinybankv1.ListAccountsResponse{Accounts: accounts}, nil
+	default:
+		return nil, unexpectedResponse(response)
+	}
+}
+
+func (s *Server) GetAccount(ctx context.Context, request *tinybankv1.GetAccountRequest) (*tinybankv1.GetAccountResponse, error) {
+	response, err := s.api.GetAccount(ctx, api.GetAccountRequestObject{AccountId: request.GetAccountId()})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := response.(type) {
+	case api.GetAccount200JSONResponse:
+		return &tinybankv1.GetAccountResponse{Account: toAccount(response.Body)}, nil
+	case api.GetAccount404ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.NotFound, response.Detail)
+	default:
+		return nil, unexpectedResponse(response)
+	}
+}
+
+func (s *Server) CreateAccount(ctx context.Context, request *tinybankv1.CreateAccountRequest) (*tinybankv1.CreateAccountResponse, error) {
+	response, err := s.api.CreateAccount(ctx, api.CreateAccountRequestObject{Body: &api.CreateAccountJSONRequestBody{
+		Name:    request.GetName(),
+		OwnerId: request.OwnerId,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := response.(type) {
+	case api.CreateAccount201Response:
+		return &tinybankv1.CreateAccountResponse{}, nil
+	case api.CreateAccount400ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.InvalidArgument, response.Detail)
+	case api.CreateAccount403ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.PermissionDenied, response.Detail)
+	default:
+		return nil, unexpectedResponse(response)
+	}
+}
+
+func (s *Server) AddBalance(ctx context.Context, request *tinybankv1.AddBalanceRequest) (*tinybankv1.AddBalanceResponse, error) {
+	response, err := s.api.AddBalanceToAccount(ctx, api.AddBalanceToAccountRequestObject{
+		AccountId: request.GetAccountId(),
+		Body:      &api.AddBalanceToAccountJSONRequestBody{Amount: request.GetAmount()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := response.(type) {
+	case api.AddBalanceToAccount200Response:
+		return &tinybankv1.AddBalanceResponse{}, nil
+	case api.AddBalanceToAccount400ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.InvalidArgument, response.Detail)
+	case api.AddBalanceToAccount404ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.NotFound, response.Detail)
+	default:
+		return nil, unexpectedResponse(response)
+	}
+}
+
+func (s *Server) TransferMoney(ctx context.Context, request *tinybankv1.TransferMoneyRequest) (*tinybankv1.TransferMoneyResponse, error) {
+	response, err := s.api.TransferMoney(ctx, api.TransferMoneyRequestObject{
+		AccountId: request.GetSourceAccountId(),
+		Body: &api.TransferMoneyJSONRequestBody{
+			TargetAccountId: request.GetTargetAccountId(),
+			Amount:          request.GetAmount(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := response.(type) {
+	case api.TransferMoney200Response:
+		return &tinybankv1.TransferMoneyResponse{}, nil
+	case api.TransferMoney202JSONResponse:
+		return &tinybankv1.TransferMoneyResponse{PendingTransfer: toTransfer(api.Transfer(response))}, nil
+	case api.TransferMoney400ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.InvalidArgument, response.Detail)
+	case api.TransferMoney404ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.NotFound, response.Detail)
+	case api.TransferMoney409ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.Aborted, response.Detail)
+	case api.TransferMoney422ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.FailedPrecondition, response.Detail)
+	case api.TransferMoney403ApplicationProblemPlusJSONResponse:
+		return nil, status.Error(codes.PermissionDenied, response.Detail)
+	default:
+		return nil, unexpectedResponse(response)
+	}
+}
+
+// unexpectedResponse is the error of the responses of the handlers not mapped to a status code, which
+// happens when a response is added to the spec but not to the service.
+func unexpectedResponse(response any) error {
+	return fmt.Errorf("unexpected response %T", response)
+}
+
+func toAccount(account api.Account) *tinybankv1.Account {
+	return &tinybankv1.Account{
+		Id:          account.Id,
+		Name:        account.Name,
+		Balance:     account.Balance,
+		HeldBalance: account.HeldBalance,
+		Status:      string(account.Status),
+		OwnerId:     account.OwnerId,
+		Tier:        account.Tier,
+		Metadata:    account.Metadata,
+		Labels:      account.Labels,
+		Version:     account.Version,
+		CreatedAt:   timestamppb.New(account.CreatedAt),
+		UpdatedAt:   timestamppb.New(account.UpdatedAt),
+	}
+}
+
+func toTransfer(transfer api.Transfer) *tinybankv1.Transfer {
+	evaluations := make([]*tinybankv1.RiskEvaluation, 0, len(transfer.RiskEvaluations))
+	for _, evaluation := range transfer.RiskEvaluations {
+		evaluations = append(evaluations, &tinybankv1.RiskEvaluation{
+			Rule:     evaluation.Rule,
+			Decision: string(evaluation.Decision),
+			Reason:   evaluation.Reason,
+		})
+	}
+	converted := &tinybankv1.Transfer{
+		Id:              transfer.Id,
+		SourceAccountId: transfer.SourceAccountId,
+		TargetAccountId: transfer.TargetAccountId,
+		Amount:          transfer.Amount,
+		Status:          string(transfer.Status),
+		RiskDecision:    string(transfer.RiskDecision),
+		RiskEvaluations: evaluations,
+		RequestedBy:     transfer.RequestedBy,
+		DecidedBy:       transfer.DecidedBy,
+		CreatedAt:       timestamppb.New(transfer.CreatedAt),
+	}
+	if transfer.ExpiresAt != nil {
+		converted.ExpiresAt = timestamppb.New(*transfer.ExpiresAt)
+	}
+	return converted
+}
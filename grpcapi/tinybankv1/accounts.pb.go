@@ -0,0 +1,969 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: tinybank/v1/accounts.proto
+
+package tinybankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Account struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the account holder.
+	Name    string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Balance float64 `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	// The part of the balance held for the transfers pending approval, it can't be spent.
+	HeldBalance float64 `protobuf:"fixed64,4,opt,name=held_balance,json=heldBalance,proto3" json:"held_balance,omitempty"`
+	// active or frozen, frozen accounts can neither send nor receive transfers.
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// The customer owning the account, only admins can access accounts without one.
+	OwnerId *int64 `protobuf:"varint,6,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	// The tier setting the transfer limits of the account.
+	Tier     string            `protobuf:"bytes,7,opt,name=tier,proto3" json:"tier,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels   map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Incremented every time the account is updated.
+	Version       int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Account) GetHeldBalance() float64 {
+	if x != nil {
+		return x.HeldBalance
+	}
+	return 0
+}
+
+func (x *Account) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Account) GetOwnerId() int64 {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return 0
+}
+
+func (x *Account) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *Account) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Account) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Account) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Account) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Transfer struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceAccountId int64                  `protobuf:"varint,2,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
+	TargetAccountId int64                  `protobuf:"varint,3,opt,name=target_account_id,json=targetAccountId,proto3" json:"target_account_id,omitempty"`
+	Amount          float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// completed, declined, pending_approval, pending_review, rejected, expired or blocked, like in the REST API.
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// allow, deny or review.
+	RiskDecision string `protobuf:"bytes,6,opt,name=risk_decision,json=riskDecision,proto3" json:"risk_decision,omitempty"`
+	// The risk rules that matched the transfer, in evaluation order.
+	RiskEvaluations []*RiskEvaluation `protobuf:"bytes,7,rep,name=risk_evaluations,json=riskEvaluations,proto3" json:"risk_evaluations,omitempty"`
+	// The subject of the credentials that requested the transfer.
+	RequestedBy string `protobuf:"bytes,8,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	// The subject of the credentials that approved or rejected the transfer.
+	DecidedBy *string `protobuf:"bytes,9,opt,name=decided_by,json=decidedBy,proto3,oneof" json:"decided_by,omitempty"`
+	// When the transfer expires if it is still pending approval.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Transfer) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transfer) GetSourceAccountId() int64 {
+	if x != nil {
+		return x.SourceAccountId
+	}
+	return 0
+}
+
+func (x *Transfer) GetTargetAccountId() int64 {
+	if x != nil {
+		return x.TargetAccountId
+	}
+	return 0
+}
+
+func (x *Transfer) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transfer) GetRiskDecision() string {
+	if x != nil {
+		return x.RiskDecision
+	}
+	return ""
+}
+
+func (x *Transfer) GetRiskEvaluations() []*RiskEvaluation {
+	if x != nil {
+		return x.RiskEvaluations
+	}
+	return nil
+}
+
+func (x *Transfer) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *Transfer) GetDecidedBy() string {
+	if x != nil && x.DecidedBy != nil {
+		return *x.DecidedBy
+	}
+	return ""
+}
+
+func (x *Transfer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Transfer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RiskEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskEvaluation) Reset() {
+	*x = RiskEvaluation{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskEvaluation) ProtoMessage() {}
+
+func (x *RiskEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskEvaluation.ProtoReflect.Descriptor instead.
+func (*RiskEvaluation) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RiskEvaluation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{3}
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAccountRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type GetAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAccountResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Only admins can set it to another customer than theirs.
+	OwnerId       *int64 `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetOwnerId() int64 {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return 0
+}
+
+type CreateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountResponse) Reset() {
+	*x = CreateAccountResponse{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountResponse) ProtoMessage() {}
+
+func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{8}
+}
+
+type AddBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBalanceRequest) Reset() {
+	*x = AddBalanceRequest{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBalanceRequest) ProtoMessage() {}
+
+func (x *AddBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AddBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddBalanceRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *AddBalanceRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type AddBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBalanceResponse) Reset() {
+	*x = AddBalanceResponse{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBalanceResponse) ProtoMessage() {}
+
+func (x *AddBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBalanceResponse.ProtoReflect.Descriptor instead.
+func (*AddBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{10}
+}
+
+type TransferMoneyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SourceAccountId int64                  `protobuf:"varint,1,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
+	TargetAccountId int64                  `protobuf:"varint,2,opt,name=target_account_id,json=targetAccountId,proto3" json:"target_account_id,omitempty"`
+	Amount          float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferMoneyRequest) Reset() {
+	*x = TransferMoneyRequest{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferMoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferMoneyRequest) ProtoMessage() {}
+
+func (x *TransferMoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferMoneyRequest.ProtoReflect.Descriptor instead.
+func (*TransferMoneyRequest) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransferMoneyRequest) GetSourceAccountId() int64 {
+	if x != nil {
+		return x.SourceAccountId
+	}
+	return 0
+}
+
+func (x *TransferMoneyRequest) GetTargetAccountId() int64 {
+	if x != nil {
+		return x.TargetAccountId
+	}
+	return 0
+}
+
+func (x *TransferMoneyRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type TransferMoneyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Set when the transfer waits for an approval or a sanctions review, the money moved right away otherwise.
+	PendingTransfer *Transfer `protobuf:"bytes,1,opt,name=pending_transfer,json=pendingTransfer,proto3" json:"pending_transfer,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferMoneyResponse) Reset() {
+	*x = TransferMoneyResponse{}
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferMoneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferMoneyResponse) ProtoMessage() {}
+
+func (x *TransferMoneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tinybank_v1_accounts_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferMoneyResponse.ProtoReflect.Descriptor instead.
+func (*TransferMoneyResponse) Descriptor() ([]byte, []int) {
+	return file_tinybank_v1_accounts_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TransferMoneyResponse) GetPendingTransfer() *Transfer {
+	if x != nil {
+		return x.PendingTransfer
+	}
+	return nil
+}
+
+var File_tinybank_v1_accounts_proto protoreflect.FileDescriptor
+
+const file_tinybank_v1_accounts_proto_rawDesc = "" +
+	"\n" +
+	"\x1atinybank/v1/accounts.proto\x12\vtinybank.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x04\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x01R\abalance\x12!\n" +
+	"\fheld_balance\x18\x04 \x01(\x01R\vheldBalance\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1e\n" +
+	"\bowner_id\x18\x06 \x01(\x03H\x00R\aownerId\x88\x01\x01\x12\x12\n" +
+	"\x04tier\x18\a \x01(\tR\x04tier\x12>\n" +
+	"\bmetadata\x18\b \x03(\v2\".tinybank.v1.Account.MetadataEntryR\bmetadata\x128\n" +
+	"\x06labels\x18\t \x03(\v2 .tinybank.v1.Account.LabelsEntryR\x06labels\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_owner_id\"\xdb\x03\n" +
+	"\bTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12*\n" +
+	"\x11source_account_id\x18\x02 \x01(\x03R\x0fsourceAccountId\x12*\n" +
+	"\x11target_account_id\x18\x03 \x01(\x03R\x0ftargetAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12#\n" +
+	"\rrisk_decision\x18\x06 \x01(\tR\friskDecision\x12F\n" +
+	"\x10risk_evaluations\x18\a \x03(\v2\x1b.tinybank.v1.RiskEvaluationR\x0friskEvaluations\x12!\n" +
+	"\frequested_by\x18\b \x01(\tR\vrequestedBy\x12\"\n" +
+	"\n" +
+	"decided_by\x18\t \x01(\tH\x00R\tdecidedBy\x88\x01\x01\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_decided_by\"X\n" +
+	"\x0eRiskEvaluation\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x15\n" +
+	"\x13ListAccountsRequest\"H\n" +
+	"\x14ListAccountsResponse\x120\n" +
+	"\baccounts\x18\x01 \x03(\v2\x14.tinybank.v1.AccountR\baccounts\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\"D\n" +
+	"\x12GetAccountResponse\x12.\n" +
+	"\aaccount\x18\x01 \x01(\v2\x14.tinybank.v1.AccountR\aaccount\"W\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\bowner_id\x18\x02 \x01(\x03H\x00R\aownerId\x88\x01\x01B\v\n" +
+	"\t_owner_id\"\x17\n" +
+	"\x15CreateAccountResponse\"J\n" +
+	"\x11AddBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\x14\n" +
+	"\x12AddBalanceResponse\"\x86\x01\n" +
+	"\x14TransferMoneyRequest\x12*\n" +
+	"\x11source_account_id\x18\x01 \x01(\x03R\x0fsourceAccountId\x12*\n" +
+	"\x11target_account_id\x18\x02 \x01(\x03R\x0ftargetAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\"Y\n" +
+	"\x15TransferMoneyResponse\x12@\n" +
+	"\x10pending_transfer\x18\x01 \x01(\v2\x15.tinybank.v1.TransferR\x0fpendingTransfer2\xb3\x03\n" +
+	"\x0eAccountService\x12S\n" +
+	"\fListAccounts\x12 .tinybank.v1.ListAccountsRequest\x1a!.tinybank.v1.ListAccountsResponse\x12M\n" +
+	"\n" +
+	"GetAccount\x12\x1e.tinybank.v1.GetAccountRequest\x1a\x1f.tinybank.v1.GetAccountResponse\x12V\n" +
+	"\rCreateAccount\x12!.tinybank.v1.CreateAccountRequest\x1a\".tinybank.v1.CreateAccountResponse\x12M\n" +
+	"\n" +
+	"AddBalance\x12\x1e.tinybank.v1.AddBalanceRequest\x1a\x1f.tinybank.v1.AddBalanceResponse\x12V\n" +
+	"\rTransferMoney\x12!.tinybank.v1.TransferMoneyRequest\x1a\".tinybank.v1.TransferMoneyResponseB-Z+tiny-bank-api/grpcapi/tinybankv1;tinybankv1b\x06proto3"
+
+var (
+	file_tinybank_v1_accounts_proto_rawDescOnce sync.Once
+	file_tinybank_v1_accounts_proto_rawDescData []byte
+)
+
+func file_tinybank_v1_accounts_proto_rawDescGZIP() []byte {
+	file_tinybank_v1_accounts_proto_rawDescOnce.Do(func() {
+		file_tinybank_v1_accounts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tinybank_v1_accounts_proto_rawDesc), len(file_tinybank_v1_accounts_proto_rawDesc)))
+	})
+	return file_tinybank_v1_accounts_proto_rawDescData
+}
+
+var file_tinybank_v1_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_tinybank_v1_accounts_proto_goTypes = []any{
+	(*Account)(nil),               // 0: tinybank.v1.Account
+	(*Transfer)(nil),              // 1: tinybank.v1.Transfer
+	(*RiskEvaluation)(nil),        // 2: tinybank.v1.RiskEvaluation
+	(*ListAccountsRequest)(nil),   // 3: tinybank.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),  // 4: tinybank.v1.ListAccountsResponse
+	(*GetAccountRequest)(nil),     // 5: tinybank.v1.GetAccountRequest
+	(*GetAccountResponse)(nil),    // 6: tinybank.v1.GetAccountResponse
+	(*CreateAccountRequest)(nil),  // 7: tinybank.v1.CreateAccountRequest
+	(*CreateAccountResponse)(nil), // 8: tinybank.v1.CreateAccountResponse
+	(*AddBalanceRequest)(nil),     // 9: tinybank.v1.AddBalanceRequest
+	(*AddBalanceResponse)(nil),    // 10: tinybank.v1.AddBalanceResponse
+	(*TransferMoneyRequest)(nil),  // 11: tinybank.v1.TransferMoneyRequest
+	(*TransferMoneyResponse)(nil), // 12: tinybank.v1.TransferMoneyResponse
+	nil,                           // 13: tinybank.v1.Account.MetadataEntry
+	nil,                           // 14: tinybank.v1.Account.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_tinybank_v1_accounts_proto_depIdxs = []int32{
+	13, // 0: tinybank.v1.Account.metadata:type_name -> tinybank.v1.Account.MetadataEntry
+	14, // 1: tinybank.v1.Account.labels:type_name -> tinybank.v1.Account.LabelsEntry
+	15, // 2: tinybank.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	15, // 3: tinybank.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: tinybank.v1.Transfer.risk_evaluations:type_name -> tinybank.v1.RiskEvaluation
+	15, // 5: tinybank.v1.Transfer.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 6: tinybank.v1.Transfer.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: tinybank.v1.ListAccountsResponse.accounts:type_name -> tinybank.v1.Account
+	0,  // 8: tinybank.v1.GetAccountResponse.account:type_name -> tinybank.v1.Account
+	1,  // 9: tinybank.v1.TransferMoneyResponse.pending_transfer:type_name -> tinybank.v1.Transfer
+	3,  // 10: tinybank.v1.AccountService.ListAccounts:input_type -> tinybank.v1.ListAccountsRequest
+	5,  // 11: tinybank.v1.AccountService.GetAccount:input_type -> tinybank.v1.GetAccountRequest
+	7,  // 12: tinybank.v1.AccountService.CreateAccount:input_type -> tinybank.v1.CreateAccountRequest
+	9,  // 13: tinybank.v1.AccountService.AddBalance:input_type -> tinybank.v1.AddBalanceRequest
+	11, // 14: tinybank.v1.AccountService.TransferMoney:input_type -> tinybank.v1.TransferMoneyRequest
+	4,  // 15: tinybank.v1.AccountService.ListAccounts:output_type -> tinybank.v1.ListAccountsResponse
+	6,  // 16: tinybank.v1.AccountService.GetAccount:output_type -> tinybank.v1.GetAccountResponse
+	8,  // 17: tinybank.v1.AccountService.CreateAccount:output_type -> tinybank.v1.CreateAccountResponse
+	10, // 18: tinybank.v1.AccountService.AddBalance:output_type -> tinybank.v1.AddBalanceResponse
+	12, // 19: tinybank.v1.AccountService.TransferMoney:output_type -> tinybank.v1.TransferMoneyResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_tinybank_v1_accounts_proto_init() }
+func file_tinybank_v1_accounts_proto_init() {
+	if File_tinybank_v1_accounts_proto != nil {
+		return
+	}
+	file_tinybank_v1_accounts_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tinybank_v1_accounts_proto_msgTypes[1].OneofWrappers = []any{}
+	file_tinybank_v1_accounts_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tinybank_v1_accounts_proto_rawDesc), len(file_tinybank_v1_accounts_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tinybank_v1_accounts_proto_goTypes,
+		DependencyIndexes: file_tinybank_v1_accounts_proto_depIdxs,
+		MessageInfos:      file_tinybank_v1_accounts_proto_msgTypes,
+	}.Build()
+	File_tinybank_v1_accounts_proto = out.File
+	file_tinybank_v1_accounts_proto_goTypes = nil
+	file_tinybank_v1_accounts_proto_depIdxs = nil
+}
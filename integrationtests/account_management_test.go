@@ -1,10 +1,19 @@
 package integrationtests
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
 )
 
 var testHandler http.Handler
@@ -57,7 +66,7 @@ func TestTransferMoney(t *testing.T) {
 		targetAccount := requireAccountExists(t, testHandler, targetName)
 
 		rec := reqPOSTTransfer(t, testHandler, 99999, targetAccount.Id, 10)
-		requireStatus(t, http.StatusBadRequest, rec)
+		requireStatus(t, http.StatusNotFound, rec)
 		requireErrorMessage(t, "source account not found", rec)
 	})
 
@@ -68,7 +77,7 @@ func TestTransferMoney(t *testing.T) {
 		mustPOSTAddBalance(t, testHandler, sourceAccount.Id, 100)
 
 		rec := reqPOSTTransfer(t, testHandler, sourceAccount.Id, 99999, 10)
-		requireStatus(t, http.StatusBadRequest, rec)
+		requireStatus(t, http.StatusNotFound, rec)
 		requireErrorMessage(t, "target account not found", rec)
 	})
 
@@ -82,11 +91,11 @@ func TestTransferMoney(t *testing.T) {
 
 		rec := reqPOSTTransfer(t, testHandler, sourceAccount.Id, targetAccount.Id, 0)
 		requireStatus(t, http.StatusBadRequest, rec)
-		requireErrorMessage(t, "amount must be greater than 0", rec)
+		requireErrorMessage(t, "invalid request: amount: number must be at least 0.01", rec)
 
 		rec = reqPOSTTransfer(t, testHandler, sourceAccount.Id, targetAccount.Id, -10)
 		requireStatus(t, http.StatusBadRequest, rec)
-		requireErrorMessage(t, "amount must be greater than 0", rec)
+		requireErrorMessage(t, "invalid request: amount: number must be at least 0.01", rec)
 	})
 
 	t.Run(`should fail if transferring to the same account`, func(t *testing.T) {
@@ -109,10 +118,18 @@ func TestTransferMoney(t *testing.T) {
 		mustPOSTAddBalance(t, testHandler, sourceAccount.Id, 50)
 
 		rec := reqPOSTTransfer(t, testHandler, sourceAccount.Id, targetAccount.Id, 100)
-		requireStatus(t, http.StatusBadRequest, rec)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
 		requireErrorMessage(t, "insufficient balance", rec)
 	})
 
+	t.Run(`should not report the store failures as missing accounts`, func(t *testing.T) {
+		handler := newTestService(logging.DevLogger(), failingLookupsStore{testStore}, testJWTVerifier, api.Options{})
+
+		rec := reqPOSTTransfer(t, handler, 1, 2, 10)
+		requireStatus(t, http.StatusInternalServerError, rec)
+		requireProblem(t, rec, problem.CodeInternalError)
+	})
+
 	t.Run(`should transfer money successfully`, func(t *testing.T) {
 		sourceName := fmt.Sprintf("Transfer Source 4 - %d", time.Now().Unix())
 		targetName := fmt.Sprintf("Transfer Target 4 - %d", time.Now().Unix())
@@ -135,3 +152,82 @@ func TestTransferMoney(t *testing.T) {
 		}
 	})
 }
+
+func TestConcurrentTransfers(t *testing.T) {
+	sourceName := fmt.Sprintf("Concurrent Source - %d", time.Now().Unix())
+	targetName := fmt.Sprintf("Concurrent Target - %d", time.Now().Unix())
+	mustPOSTAccount(t, testHandler, sourceName)
+	mustPOSTAccount(t, testHandler, targetName)
+	sourceAccount := requireAccountExists(t, testHandler, sourceName)
+	targetAccount := requireAccountExists(t, testHandler, targetName)
+	mustPOSTAddBalance(t, testHandler, sourceAccount.Id, 100)
+
+	// 20 transfers of 10 racing for a balance of 100, only 10 of them can succeed.
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			reqPOSTTransfer(t, testHandler, sourceAccount.Id, targetAccount.Id, 10)
+		})
+	}
+	wg.Wait()
+
+	updatedSource := requireAccountExists(t, testHandler, sourceName)
+	updatedTarget := requireAccountExists(t, testHandler, targetName)
+	if updatedSource.Balance != 0 {
+		t.Fatalf("expected source balance to be 0 but got %.2f", updatedSource.Balance)
+	}
+	if updatedTarget.Balance != 100 {
+		t.Fatalf("expected target balance to be 100 but got %.2f", updatedTarget.Balance)
+	}
+}
+
+func TestTxConflicts(t *testing.T) {
+	handler := newTestService(logging.DevLogger(), conflictingStore{testStore}, testJWTVerifier, api.Options{})
+	name := fmt.Sprintf("Conflicting - %d", time.Now().UnixNano())
+	mustPOSTAccount(t, testHandler, name)
+	account := requireAccountExists(t, testHandler, name)
+	target := fmt.Sprintf("/api/accounts/%d", account.Id)
+
+	t.Run(`should answer 409 when the unit of work keeps conflicting`, func(t *testing.T) {
+		for _, rec := range []*httptest.ResponseRecorder{
+			reqPOSTAddBalance(t, handler, account.Id, 10),
+			reqPOSTTransfer(t, handler, account.Id, account.Id+1, 10),
+			reqWithAPIKey(t, handler, http.MethodPatch, target, map[string]any{"name": name + " renamed"}, testAPIKey),
+			reqWithAPIKey(t, handler, http.MethodPut, target+"/status", map[string]any{"status": "frozen"}, testAPIKey),
+			reqWithAPIKey(t, handler, http.MethodPost, "/api/transfers/1/approve", nil, testAPIKey),
+			reqWithAPIKey(t, handler, http.MethodPost, "/api/transfers/1/reject", nil, testAPIKey),
+			reqWithAPIKey(t, handler, http.MethodPost, "/api/screening-hits/1/clear", nil, testAPIKey),
+		} {
+			requireStatus(t, http.StatusConflict, rec)
+			requireProblem(t, rec, problem.CodeConflict)
+		}
+	})
+}
+
+// conflictingStore fails its units of work like transactions still conflicting after their retries.
+type conflictingStore struct {
+	store.Store
+}
+
+func (conflictingStore) RunInTx(context.Context, func(tx store.Tx) error) error {
+	return errors.Join(store.ErrTxConflict, errors.New("could not serialize access due to concurrent update"))
+}
+
+// failingLookupsStore fails the account lookups of its units of work, like a database going away would.
+type failingLookupsStore struct {
+	store.Store
+}
+
+func (s failingLookupsStore) RunInTx(ctx context.Context, fn func(tx store.Tx) error) error {
+	return s.Store.RunInTx(ctx, func(tx store.Tx) error {
+		return fn(failingLookups{tx})
+	})
+}
+
+type failingLookups struct {
+	store.Tx
+}
+
+func (failingLookups) GetAccountById(context.Context, int64) (entities.Account, error) {
+	return entities.Account{}, errors.New("connection refused")
+}
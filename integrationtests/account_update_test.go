@@ -0,0 +1,96 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateAccount(t *testing.T) {
+	t.Run(`should fail if account doesn't exist`, func(t *testing.T) {
+		rec := reqPATCHAccount(t, testHandler, 99999, map[string]any{"name": "Nobody"}, "")
+		requireStatus(t, http.StatusNotFound, rec)
+	})
+
+	t.Run(`should fail with an invalid name`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Invalid Rename - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+
+		rec := reqPATCHAccount(t, testHandler, account.Id, map[string]any{"name": ""}, "")
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireErrorMessage(t, "invalid request: name: minimum string length is 1", rec)
+
+		rec = reqPATCHAccount(t, testHandler, account.Id, map[string]any{"name": strings.Repeat("a", 256)}, "")
+		requireStatus(t, http.StatusBadRequest, rec)
+	})
+
+	t.Run(`should merge name, metadata and labels`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Patch Me - %d", time.Now().Unix())
+		newName := fmt.Sprintf("Patched - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+
+		mustPATCHAccount(t, testHandler, account.Id, map[string]any{
+			"metadata": map[string]any{"crm_id": "C-1", "note": "vip"},
+			"labels":   map[string]any{"segment": "retail"},
+		})
+		updated := mustPATCHAccount(t, testHandler, account.Id, map[string]any{
+			"name":     newName,
+			"metadata": map[string]any{"note": nil},
+		})
+
+		if updated.Name != newName {
+			t.Fatalf("expected name %q, got %q", newName, updated.Name)
+		}
+		if len(updated.Metadata) != 1 || updated.Metadata["crm_id"] != "C-1" {
+			t.Fatalf("unexpected metadata %v", updated.Metadata)
+		}
+		if updated.Labels["segment"] != "retail" {
+			t.Fatalf("unexpected labels %v", updated.Labels)
+		}
+		if !updated.UpdatedAt.After(account.UpdatedAt) || updated.Version != account.Version+2 {
+			t.Fatalf("expected updated_at and version to be bumped, got %+v", updated)
+		}
+
+		changes := mustGETAccountChanges(t, testHandler, account.Id)
+		var fields []string
+		for _, change := range changes {
+			fields = append(fields, change.Field)
+		}
+		expected := "metadata.crm_id,metadata.note,labels.segment,name,metadata.note"
+		if strings.Join(fields, ",") != expected {
+			t.Fatalf("expected changes %s, got %s", expected, strings.Join(fields, ","))
+		}
+		last := changes[len(changes)-1]
+		if last.Actor == "" || last.OldValue == nil || *last.OldValue != "vip" || last.NewValue != nil {
+			t.Fatalf("unexpected change %+v", last)
+		}
+	})
+
+	t.Run(`should not bump the version when nothing changes`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Patch Noop - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+
+		updated := mustPATCHAccount(t, testHandler, account.Id, map[string]any{"name": accountName})
+		if updated.Version != account.Version {
+			t.Fatalf("expected version %d, got %d", account.Version, updated.Version)
+		}
+	})
+
+	t.Run(`should reject a stale If-Match`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Patch Stale - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+		_, etag := mustGETAccount(t, testHandler, account.Id)
+
+		rec := reqPATCHAccount(t, testHandler, account.Id, map[string]any{"name": accountName + " 1"}, etag)
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqPATCHAccount(t, testHandler, account.Id, map[string]any{"name": accountName + " 2"}, etag)
+		requireStatus(t, http.StatusPreconditionFailed, rec)
+	})
+}
@@ -0,0 +1,117 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetAccount(t *testing.T) {
+	t.Run(`should fail if account doesn't exist`, func(t *testing.T) {
+		rec := reqGETAccount(t, testHandler, 99999)
+		requireStatus(t, http.StatusNotFound, rec)
+	})
+
+	t.Run(`should return the account with its ETag`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Get Account - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		created := requireAccountExists(t, testHandler, accountName)
+
+		account, etag := mustGETAccount(t, testHandler, created.Id)
+		if account.Name != accountName || account.Status != "active" {
+			t.Fatalf("unexpected account %+v", account)
+		}
+		if etag != fmt.Sprintf(`"%d"`, account.Version) {
+			t.Fatalf("expected ETag to match version %d, got %s", account.Version, etag)
+		}
+	})
+
+	t.Run(`should bump the version when the balance changes`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Versioned Balance - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		created := requireAccountExists(t, testHandler, accountName)
+
+		mustPOSTAddBalance(t, testHandler, created.Id, 10)
+		account, _ := mustGETAccount(t, testHandler, created.Id)
+		if account.Version != created.Version+1 {
+			t.Fatalf("expected version %d, got %d", created.Version+1, account.Version)
+		}
+	})
+}
+
+func TestSetAccountStatus(t *testing.T) {
+	t.Run(`should fail if account doesn't exist`, func(t *testing.T) {
+		rec := reqPUTAccountStatus(t, testHandler, 99999, "frozen", "")
+		requireStatus(t, http.StatusNotFound, rec)
+	})
+
+	t.Run(`should fail with an unknown status`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Unknown Status - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+
+		rec := reqPUTAccountStatus(t, testHandler, account.Id, "closed", "")
+		requireStatus(t, http.StatusBadRequest, rec)
+	})
+
+	t.Run(`should update when If-Match is current`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Freeze Current - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+		_, etag := mustGETAccount(t, testHandler, account.Id)
+
+		rec := reqPUTAccountStatus(t, testHandler, account.Id, "frozen", etag)
+		requireStatus(t, http.StatusOK, rec)
+		if newETag := rec.Header().Get("ETag"); newETag == etag || newETag == "" {
+			t.Fatalf("expected a new ETag, got %q", newETag)
+		}
+
+		updated, _ := mustGETAccount(t, testHandler, account.Id)
+		if updated.Status != "frozen" {
+			t.Fatalf("expected account to be frozen, got %s", updated.Status)
+		}
+	})
+
+	t.Run(`should reject concurrent edits with a stale If-Match`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Freeze Stale - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+		_, etag := mustGETAccount(t, testHandler, account.Id)
+
+		rec := reqPUTAccountStatus(t, testHandler, account.Id, "frozen", etag)
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqPUTAccountStatus(t, testHandler, account.Id, "active", etag)
+		requireStatus(t, http.StatusPreconditionFailed, rec)
+
+		rec = reqPUTAccountStatus(t, testHandler, account.Id, "active", `W/"1"`)
+		requireStatus(t, http.StatusPreconditionFailed, rec)
+
+		updated, _ := mustGETAccount(t, testHandler, account.Id)
+		if updated.Status != "frozen" {
+			t.Fatalf("expected account to still be frozen, got %s", updated.Status)
+		}
+	})
+
+	t.Run(`should block transfers from and to frozen accounts`, func(t *testing.T) {
+		sourceName := fmt.Sprintf("Frozen Source - %d", time.Now().Unix())
+		targetName := fmt.Sprintf("Frozen Target - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, sourceName)
+		mustPOSTAccount(t, testHandler, targetName)
+		sourceAccount := requireAccountExists(t, testHandler, sourceName)
+		targetAccount := requireAccountExists(t, testHandler, targetName)
+		mustPOSTAddBalance(t, testHandler, sourceAccount.Id, 100)
+		mustPOSTAddBalance(t, testHandler, targetAccount.Id, 100)
+
+		requireStatus(t, http.StatusOK, reqPUTAccountStatus(t, testHandler, sourceAccount.Id, "frozen", ""))
+
+		rec := reqPOSTTransfer(t, testHandler, sourceAccount.Id, targetAccount.Id, 10)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "source account is frozen", rec)
+
+		rec = reqPOSTTransfer(t, testHandler, targetAccount.Id, sourceAccount.Id, 10)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "target account is frozen", rec)
+	})
+}
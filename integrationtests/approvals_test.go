@@ -0,0 +1,177 @@
+package integrationtests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+)
+
+func TestTransferApprovals(t *testing.T) {
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{
+		ApprovalThreshold: 500,
+		ApprovalTTL:       time.Hour,
+	})
+	checker := mustCreateRoleAPIKey(t, "operator", "transfers:create", "accounts:write")
+
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string, balance float64) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, handler, name)
+		account := requireAccountExists(t, handler, name)
+		if balance > 0 {
+			mustPOSTAddBalance(t, handler, account.Id, balance)
+		}
+		return account
+	}
+	target := newAccount(t, "Approval Target", 0)
+
+	t.Run(`should hold transfers above the threshold until a different user approves them`, func(t *testing.T) {
+		source := newAccount(t, "Approval Source", 1000)
+
+		pending := mustPOSTPendingTransfer(t, handler, source.Id, target.Id, 600)
+		if pending.ExpiresAt == nil || pending.RequestedBy == "" {
+			t.Fatalf("expected the pending transfer to expire and record its requester, got %+v", pending)
+		}
+		requireBalances(t, handler, source.Id, 1000, 600)
+		requireBalances(t, handler, target.Id, 0, 0)
+
+		// the held amount can't be spent
+		rec := reqPOSTTransfer(t, handler, source.Id, target.Id, 450)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "insufficient balance", rec)
+		mustPOSTTransfer(t, handler, source.Id, target.Id, 100)
+
+		rec = reqPOSTTransferDecision(t, handler, pending.Id, "approve", testAPIKey)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "transfers must be approved by a different user than the one requesting them", rec)
+
+		rec = reqPOSTTransferDecision(t, handler, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusOK, rec)
+		approved := decodeTransfer(t, rec)
+		if approved.Status != api.TransferStatusCompleted || approved.DecidedBy == nil {
+			t.Fatalf("expected the transfer to be completed by the checker, got %+v", approved)
+		}
+		requireBalances(t, handler, source.Id, 300, 0)
+		requireBalances(t, handler, target.Id, 700, 0)
+
+		rec = reqPOSTTransferDecision(t, handler, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusConflict, rec)
+		requireErrorMessage(t, "transfer is completed, not pending approval", rec)
+	})
+
+	t.Run(`should run the checks again when approving`, func(t *testing.T) {
+		source := newAccount(t, "Frozen Approval Source", 1000)
+		pending := mustPOSTPendingTransfer(t, handler, source.Id, target.Id, 800)
+
+		rec := reqWithAPIKey(t, handler, http.MethodPut, fmt.Sprintf("/api/accounts/%d/status", source.Id), map[string]any{"status": "frozen"}, checker)
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqPOSTTransferDecision(t, handler, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "source account is frozen", rec)
+		// the transfer stays pending with its amount held
+		requireBalances(t, handler, source.Id, 1000, 800)
+		requirePendingTransfer(t, handler, pending.Id)
+	})
+
+	t.Run(`should release the held amount of rejected transfers`, func(t *testing.T) {
+		source := newAccount(t, "Rejected Source", 1000)
+		pending := mustPOSTPendingTransfer(t, handler, source.Id, target.Id, 900)
+
+		rec := reqPOSTTransferDecision(t, handler, pending.Id, "reject", checker)
+		requireStatus(t, http.StatusOK, rec)
+		if rejected := decodeTransfer(t, rec); rejected.Status != api.TransferStatusRejected {
+			t.Fatalf("expected the transfer to be rejected, got %+v", rejected)
+		}
+		requireBalances(t, handler, source.Id, 1000, 0)
+
+		rec = reqPOSTTransferDecision(t, handler, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusConflict, rec)
+	})
+
+	t.Run(`should expire the transfers pending for too long`, func(t *testing.T) {
+		source := newAccount(t, "Expired Source", 1000)
+		pending := mustPOSTPendingTransfer(t, handler, source.Id, target.Id, 700)
+
+		expired, err := api.ExpirePendingTransfers(context.Background(), testStore, time.Now().Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("failed to expire transfers: %v", err)
+		}
+		if expired < 1 {
+			t.Fatalf("expected the transfer to expire")
+		}
+		requireBalances(t, handler, source.Id, 1000, 0)
+
+		rec := reqPOSTTransferDecision(t, handler, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusConflict, rec)
+		requireErrorMessage(t, "transfer is expired, not pending approval", rec)
+	})
+
+	t.Run(`should not let customers approve transfers`, func(t *testing.T) {
+		customer := mustPOSTCustomer(t, handler, fmt.Sprintf("Approval Customer - %d", suffix), nil)
+		secret := mustCreateCustomerAPIKey(t, customer.Id, "transfers:create")
+		rec := reqPOSTTransferDecision(t, handler, 1, "approve", secret)
+		requireStatus(t, http.StatusForbidden, rec)
+	})
+
+	t.Run(`should return 404 for unknown transfers`, func(t *testing.T) {
+		rec := reqPOSTTransferDecision(t, handler, 1<<62, "approve", checker)
+		requireStatus(t, http.StatusNotFound, rec)
+	})
+}
+
+func mustPOSTPendingTransfer(t *testing.T, handler http.Handler, sourceAccountId, targetAccountId int64, amount float64) api.Transfer {
+	t.Helper()
+	rec := reqPOSTTransfer(t, handler, sourceAccountId, targetAccountId, amount)
+	requireStatus(t, http.StatusAccepted, rec)
+	transfer := decodeTransfer(t, rec)
+	if transfer.Status != api.TransferStatusPendingApproval {
+		t.Fatalf("expected the transfer to be pending approval, got %+v", transfer)
+	}
+	return transfer
+}
+
+func reqPOSTTransferDecision(t *testing.T, handler http.Handler, transferId int64, decision, apiKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	return reqWithAPIKey(t, handler, http.MethodPost, fmt.Sprintf("/api/transfers/%d/%s", transferId, decision), nil, apiKey)
+}
+
+func decodeTransfer(t *testing.T, rec *httptest.ResponseRecorder) api.Transfer {
+	t.Helper()
+	var transfer api.Transfer
+	if err := json.NewDecoder(rec.Body).Decode(&transfer); err != nil {
+		t.Fatalf("failed to decode transfer response: %v", err)
+	}
+	return transfer
+}
+
+func requireBalances(t *testing.T, handler http.Handler, accountId int64, balance, held float64) {
+	t.Helper()
+	account, _ := mustGETAccount(t, handler, accountId)
+	if account.Balance != balance || account.HeldBalance != held {
+		t.Fatalf("expected a balance of %.2f with %.2f held, got %.2f with %.2f held", balance, held, account.Balance, account.HeldBalance)
+	}
+}
+
+func requirePendingTransfer(t *testing.T, handler http.Handler, transferId int64) {
+	t.Helper()
+	rec := serve(handler, httptest.NewRequest(http.MethodGet, "/api/transfers?status=pending_approval", nil))
+	requireStatus(t, http.StatusOK, rec)
+	var transfers []api.Transfer
+	if err := json.NewDecoder(rec.Body).Decode(&transfers); err != nil {
+		t.Fatalf("failed to decode transfers response: %v", err)
+	}
+	for _, transfer := range transfers {
+		if transfer.Id == transferId {
+			return
+		}
+	}
+	t.Fatalf("expected transfer %d to be pending approval", transferId)
+}
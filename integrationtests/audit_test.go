@@ -0,0 +1,159 @@
+package integrationtests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Run(`should record state-changing requests`, func(t *testing.T) {
+		accountName := fmt.Sprintf("Audited Account - %d", time.Now().UnixNano())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+		events := mustGetAllAuditEvents(t)
+		afterId := events[len(events)-1].Id
+
+		body := strings.NewReader(`{"amount": 25}`)
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/accounts/%d/add-balance", account.Id), body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-Id", "audit-test-request")
+		req.RemoteAddr = "198.51.100.7:52814"
+		requireStatus(t, http.StatusOK, serve(testHandler, req))
+
+		events = mustGetAuditEventsAfter(t, afterId)
+		if len(events) != 1 {
+			t.Fatalf("expected 1 audit event, got %+v", events)
+		}
+		event := events[0]
+		if event.Operation != "addBalanceToAccount" || event.RequestId != "audit-test-request" || event.ClientIP != "198.51.100.7" || !strings.HasPrefix(event.Actor, "apikey:") {
+			t.Fatalf("unexpected audit event %+v", event)
+		}
+
+		var before, after api.Account
+		if err := json.Unmarshal([]byte(*event.Before), &before); err != nil {
+			t.Fatalf("failed to decode before snapshot: %v", err)
+		}
+		if err := json.Unmarshal([]byte(*event.After), &after); err != nil {
+			t.Fatalf("failed to decode after snapshot: %v", err)
+		}
+		if before.Balance != 0 || after.Balance != 25 {
+			t.Fatalf("expected balance to go from 0 to 25, got %f and %f", before.Balance, after.Balance)
+		}
+	})
+
+	t.Run(`should not record rejected requests`, func(t *testing.T) {
+		events := mustGetAllAuditEvents(t)
+		afterId := events[len(events)-1].Id
+
+		requireStatus(t, http.StatusNotFound, reqPOSTAddBalance(t, testHandler, 999999, 10))
+		requireStatus(t, http.StatusNotFound, reqPOSTTransfer(t, testHandler, 999999, 999998, 10))
+
+		if events := mustGetAuditEventsAfter(t, afterId); len(events) != 0 {
+			t.Fatalf("expected no audit event, got %+v", events)
+		}
+	})
+
+	t.Run(`should not make changes whose event can't be recorded`, func(t *testing.T) {
+		handler := newTestService(logging.DevLogger(), failingAuditStore{testStore}, testJWTVerifier, api.Options{})
+		name := fmt.Sprintf("Unaudited Customer - %d", time.Now().UnixNano())
+
+		rec := reqWithAPIKey(t, handler, http.MethodPost, "/api/customers", map[string]any{"name": name}, testAPIKey)
+		requireStatus(t, http.StatusInternalServerError, rec)
+
+		customers, err := testStore.GetCustomers(context.Background())
+		if err != nil {
+			t.Fatalf("failed to get customers: %v", err)
+		}
+		for _, customer := range customers {
+			if customer.Name == name {
+				t.Fatalf("expected the customer not to be created, got %+v", customer)
+			}
+		}
+	})
+
+	t.Run(`should chain the events`, func(t *testing.T) {
+		var verifier store.AuditChainVerifier
+		for _, event := range mustGetAllAuditEvents(t) {
+			if err := verifier.Verify(event); err != nil {
+				t.Fatalf("expected the chain to be intact: %v", err)
+			}
+		}
+	})
+
+	t.Run(`should detect modified and missing events`, func(t *testing.T) {
+		events := mustGetAllAuditEvents(t)
+		if len(events) < 3 {
+			t.Fatalf("expected at least 3 audit events, got %d", len(events))
+		}
+
+		modified := append([]entities.AuditEvent(nil), events...)
+		modified[1].Actor = "somebody else"
+		requireBrokenChain(t, modified, "event 2 was modified")
+
+		missing := append(append([]entities.AuditEvent(nil), events[:1]...), events[2:]...)
+		requireBrokenChain(t, missing, "expected event 2, got event 3")
+
+		rehashed := append([]entities.AuditEvent(nil), events...)
+		rehashed[1].Actor = "somebody else"
+		rehashed[1].Hash = rehashed[1].ComputeHash()
+		requireBrokenChain(t, rehashed, "event 3 doesn't link to the previous event")
+	})
+}
+
+// failingAuditStore fails to append to the audit log in its units of work.
+type failingAuditStore struct {
+	store.Store
+}
+
+func (s failingAuditStore) RunInTx(ctx context.Context, fn func(tx store.Tx) error) error {
+	return s.Store.RunInTx(ctx, func(tx store.Tx) error {
+		return fn(failingAudit{tx})
+	})
+}
+
+type failingAudit struct {
+	store.Tx
+}
+
+func (failingAudit) AppendAuditEvent(context.Context, entities.AuditEvent) (entities.AuditEvent, error) {
+	return entities.AuditEvent{}, errors.New("connection refused")
+}
+
+func requireBrokenChain(t *testing.T, events []entities.AuditEvent, expected string) {
+	t.Helper()
+	var verifier store.AuditChainVerifier
+	for _, event := range events {
+		if err := verifier.Verify(event); err != nil {
+			if !errors.Is(err, store.ErrAuditChainBroken) || !strings.HasSuffix(err.Error(), expected) {
+				t.Fatalf("expected error ending with %q, got %v", expected, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the chain to be broken")
+}
+
+func mustGetAllAuditEvents(t *testing.T) []entities.AuditEvent {
+	t.Helper()
+	return mustGetAuditEventsAfter(t, 0)
+}
+
+func mustGetAuditEventsAfter(t *testing.T, afterId int64) []entities.AuditEvent {
+	t.Helper()
+	events, err := testStore.GetAuditEvents(context.Background(), afterId, 1_000_000)
+	if err != nil {
+		t.Fatalf("failed to get audit events: %v", err)
+	}
+	return events
+}
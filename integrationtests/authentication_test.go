@@ -0,0 +1,201 @@
+package integrationtests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	t.Run(`should reject requests without an api key`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, "")
+		requireStatus(t, http.StatusUnauthorized, rec)
+		requireErrorMessage(t, "missing credentials", rec)
+	})
+
+	t.Run(`should reject unknown api keys`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, "tbk_unknown")
+		requireStatus(t, http.StatusUnauthorized, rec)
+		requireErrorMessage(t, "invalid api key", rec)
+	})
+
+	t.Run(`should reject expired api keys`, func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		secret, _ := mustCreateAPIKey(t, &expiresAt, "accounts:read")
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret)
+		requireStatus(t, http.StatusUnauthorized, rec)
+		requireErrorMessage(t, "api key is expired or revoked", rec)
+	})
+
+	t.Run(`should reject revoked api keys`, func(t *testing.T) {
+		secret, id := mustCreateAPIKey(t, nil, "accounts:read")
+		mustAssignRole(t, fmt.Sprintf("apikey:%d", id), auth.RoleSupport)
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret)
+		requireStatus(t, http.StatusOK, rec)
+
+		if err := testStore.RevokeAPIKey(context.Background(), id); err != nil {
+			t.Fatalf("failed to revoke api key: %v", err)
+		}
+		rec = reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret)
+		requireStatus(t, http.StatusUnauthorized, rec)
+	})
+
+	t.Run(`should enforce the scopes of each operation`, func(t *testing.T) {
+		readOnly := mustCreateRoleAPIKey(t, auth.RoleAdmin, "accounts:read")
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, readOnly)
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": "Read Only"}, readOnly)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "missing required scopes: accounts:write", rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts/1/transfer", map[string]any{"amount": 1, "targetAccountId": 2}, readOnly)
+		requireStatus(t, http.StatusForbidden, rec)
+	})
+
+	t.Run(`should record the key as the actor of changes`, func(t *testing.T) {
+		writer, id := mustCreateAPIKey(t, nil, "admin")
+		accountName := fmt.Sprintf("Audited Rename - %d", time.Now().Unix())
+		mustPOSTAccount(t, testHandler, accountName)
+		account := requireAccountExists(t, testHandler, accountName)
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPatch, fmt.Sprintf("/api/accounts/%d", account.Id), map[string]any{"name": accountName + " renamed"}, writer)
+		requireStatus(t, http.StatusOK, rec)
+
+		changes := mustGETAccountChanges(t, testHandler, account.Id)
+		if len(changes) != 1 || changes[0].Actor != fmt.Sprintf("apikey:%d", id) {
+			t.Fatalf("unexpected changes %+v", changes)
+		}
+	})
+}
+
+func reqWithBearer(t *testing.T, handler http.Handler, method, target string, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestJWTAuthentication(t *testing.T) {
+	t.Run(`should accept RS256 and ES256 tokens`, func(t *testing.T) {
+		for _, kid := range []string{"rsa-1", "ec-1"} {
+			rec := reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", issuer.mustToken(t, kid, nil))
+			requireStatus(t, http.StatusOK, rec)
+		}
+	})
+
+	t.Run(`should reject tokens with invalid claims`, func(t *testing.T) {
+		cases := map[string]jwt.MapClaims{
+			"invalid bearer token: expired":        {"exp": time.Now().Add(-time.Hour).Unix()},
+			"invalid bearer token: wrong issuer":   {"iss": "https://evil.test"},
+			"invalid bearer token: wrong audience": {"aud": "another-api"},
+		}
+		for expected, overrides := range cases {
+			rec := reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", issuer.mustToken(t, "rsa-1", overrides))
+			requireStatus(t, http.StatusUnauthorized, rec)
+			requireErrorMessage(t, expected, rec)
+		}
+	})
+
+	t.Run(`should reject tokens signed by an unknown key`, func(t *testing.T) {
+		token := issuer.mustToken(t, "rsa-1", nil)
+		// tamper with the signature
+		rec := reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", token[:len(token)-4]+"AAAA")
+		requireStatus(t, http.StatusUnauthorized, rec)
+	})
+
+	t.Run(`should enforce the scopes of the token`, func(t *testing.T) {
+		rec := reqWithBearer(t, testHandler, http.MethodPost, "/api/accounts/1/transfer", issuer.mustToken(t, "ec-1", nil))
+		requireStatus(t, http.StatusForbidden, rec)
+
+		token := issuer.mustToken(t, "ec-1", jwt.MapClaims{"scope": nil, "scp": []string{"accounts:read", "accounts:write"}})
+		rec = reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", token)
+		requireStatus(t, http.StatusOK, rec)
+	})
+
+	t.Run(`should act for the customer matching the subject`, func(t *testing.T) {
+		subject := fmt.Sprintf("idp|%d", time.Now().UnixNano())
+		customer := mustPOSTCustomer(t, testHandler, "Token Customer", &subject)
+		accountName := fmt.Sprintf("Token Account - %d", time.Now().UnixNano())
+		rec := reqPOSTAccount(t, testHandler, map[string]any{"name": accountName, "owner_id": customer.Id})
+		requireStatus(t, http.StatusCreated, rec)
+
+		rec = reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", issuer.mustToken(t, "rsa-1", jwt.MapClaims{"sub": subject}))
+		requireStatus(t, http.StatusOK, rec)
+		var accounts []api.Account
+		if err := json.NewDecoder(rec.Body).Decode(&accounts); err != nil {
+			t.Fatalf("failed to decode accounts response: %v", err)
+		}
+		if len(accounts) != 1 || accounts[0].Name != accountName {
+			t.Fatalf("expected only the account of the customer, got %+v", accounts)
+		}
+	})
+
+	t.Run(`should reject requests carrying both an api key and a token`, func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/accounts", nil)
+		req.Header.Set(auth.APIKeyHeader, testAPIKey)
+		req.Header.Set("Authorization", "Bearer "+issuer.mustToken(t, "rsa-1", nil))
+		rec := httptest.NewRecorder()
+		testHandler.ServeHTTP(rec, req)
+		requireStatus(t, http.StatusUnauthorized, rec)
+		requireErrorMessage(t, "send either an api key or a bearer token, not both", rec)
+	})
+
+	t.Run(`should ignore the keys of unsupported curves`, func(t *testing.T) {
+		mixed, err := newTestIssuer(filepath.Join(t.TempDir(), "jwks.json"))
+		if err != nil {
+			t.Fatalf("failed to create token issuer: %v", err)
+		}
+		for kid, curve := range map[string]elliptic.Curve{"ec-384": elliptic.P384(), "ec-521": elliptic.P521()} {
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("failed to generate key: %v", err)
+			}
+			mixed.keys[kid] = key
+		}
+		if err := mixed.publish(); err != nil {
+			t.Fatalf("failed to publish jwks: %v", err)
+		}
+
+		jwks, err := auth.NewJWKS(context.Background(), mixed.jwksPath)
+		if err != nil {
+			t.Fatalf("expected the supported keys to be loaded, got %v", err)
+		}
+		verifier := auth.NewJWTVerifier(jwks, testJWTIssuer, testJWTAudience)
+		for _, kid := range []string{"rsa-1", "ec-1"} {
+			if _, err := verifier.Verify(context.Background(), mixed.mustToken(t, kid, nil)); err != nil {
+				t.Fatalf("expected the token signed by %s to be verified, got %v", kid, err)
+			}
+		}
+	})
+
+	// must run last, it replaces the keys of the issuer
+	t.Run(`should pick up rotated keys without a restart`, func(t *testing.T) {
+		oldToken := issuer.mustToken(t, "rsa-1", nil)
+		if err := issuer.rotate("rsa-2"); err != nil {
+			t.Fatalf("failed to rotate keys: %v", err)
+		}
+
+		rec := reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", issuer.mustToken(t, "rsa-2", nil))
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqWithBearer(t, testHandler, http.MethodGet, "/api/accounts", oldToken)
+		requireStatus(t, http.StatusUnauthorized, rec)
+	})
+}
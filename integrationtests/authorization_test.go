@@ -0,0 +1,82 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"tiny-bank-api/pkg/auth"
+)
+
+func TestRoleAuthorization(t *testing.T) {
+	allScopes := []string{"accounts:read", "accounts:write", "transfers:create"}
+	accountName := fmt.Sprintf("Back Office Account - %d", time.Now().UnixNano())
+	mustPOSTAccount(t, testHandler, accountName)
+	account := requireAccountExists(t, testHandler, accountName)
+	accountPath := fmt.Sprintf("/api/accounts/%d", account.Id)
+
+	t.Run(`should deny operations to keys without a role`, func(t *testing.T) {
+		secret, _ := mustCreateAPIKey(t, nil, allScopes...)
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow getAccounts", rec)
+	})
+
+	t.Run(`should let support look at every account without changing them`, func(t *testing.T) {
+		support := mustCreateRoleAPIKey(t, auth.RoleSupport, allScopes...)
+
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodGet, accountPath, nil, support))
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodGet, "/api/customers", nil, support))
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPut, accountPath+"/status", map[string]any{"status": "frozen"}, support)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow setAccountStatus", rec)
+		requireStatus(t, http.StatusForbidden, reqWithAPIKey(t, testHandler, http.MethodPost, accountPath+"/add-balance", map[string]any{"amount": 10}, support))
+	})
+
+	t.Run(`should let operators freeze, unfreeze and adjust accounts`, func(t *testing.T) {
+		operator := mustCreateRoleAPIKey(t, auth.RoleOperator, allScopes...)
+
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodPut, accountPath+"/status", map[string]any{"status": "frozen"}, operator))
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodPut, accountPath+"/status", map[string]any{"status": "active"}, operator))
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodPost, accountPath+"/add-balance", map[string]any{"amount": 10}, operator))
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/customers", map[string]any{"name": "Not Allowed"}, operator)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow createCustomer", rec)
+	})
+
+	t.Run(`should stop allowing operations once the role is unassigned`, func(t *testing.T) {
+		secret, id := mustCreateAPIKey(t, nil, allScopes...)
+		subject := fmt.Sprintf("apikey:%d", id)
+		mustAssignRole(t, subject, auth.RoleSupport)
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret))
+
+		if err := testStore.UnassignRole(context.Background(), subject, auth.RoleSupport); err != nil {
+			t.Fatalf("failed to unassign role: %v", err)
+		}
+		requireStatus(t, http.StatusForbidden, reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, secret))
+	})
+
+	t.Run(`should deny customers the back-office operations`, func(t *testing.T) {
+		customer := mustPOSTCustomer(t, testHandler, "Self Service", nil)
+		secret := mustCreateCustomerAPIKey(t, customer.Id, allScopes...)
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPut, accountPath+"/status", map[string]any{"status": "frozen"}, secret)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow setAccountStatus", rec)
+	})
+
+	t.Run(`should deny customers crediting their own accounts`, func(t *testing.T) {
+		customer := mustPOSTCustomer(t, testHandler, "Self Credit", nil)
+		secret := mustCreateCustomerAPIKey(t, customer.Id, allScopes...)
+		ownAccountName := fmt.Sprintf("Self Credit Account - %d", time.Now().UnixNano())
+		requireStatus(t, http.StatusCreated, reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": ownAccountName}, secret))
+		ownAccount := requireAccountExists(t, testHandler, ownAccountName)
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/add-balance", ownAccount.Id), map[string]any{"amount": 10}, secret)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow addBalanceToAccount", rec)
+	})
+}
@@ -0,0 +1,179 @@
+package integrationtests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"tiny-bank-api/pkg/client"
+	"tiny-bank-api/pkg/problem"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(testHandler)
+	t.Cleanup(server.Close)
+	bank, err := client.New(client.Config{BaseURL: server.URL + "/api", APIKey: testAPIKey})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string) client.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		if err := bank.CreateAccount(ctx, name, nil); err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		accounts, err := bank.ListAccounts(ctx)
+		if err != nil {
+			t.Fatalf("failed to list accounts: %v", err)
+		}
+		for _, account := range accounts {
+			if account.Name == name {
+				return account
+			}
+		}
+		t.Fatalf("account %q not found", name)
+		return client.Account{}
+	}
+
+	t.Run(`should create accounts, add balance and transfer money`, func(t *testing.T) {
+		source := newAccount(t, "Client Source")
+		target := newAccount(t, "Client Target")
+
+		if err := bank.AddBalance(ctx, source.Id, 50); err != nil {
+			t.Fatalf("failed to add balance: %v", err)
+		}
+		pending, err := bank.Transfer(ctx, source.Id, target.Id, 20)
+		if err != nil || pending != nil {
+			t.Fatalf("expected the transfer to complete, got %+v, %v", pending, err)
+		}
+
+		account, err := bank.GetAccount(ctx, target.Id)
+		if err != nil {
+			t.Fatalf("failed to get account: %v", err)
+		}
+		if account.Balance != 20 {
+			t.Fatalf("expected the target to have received 20, got %f", account.Balance)
+		}
+	})
+
+	t.Run(`should return the error responses as errors`, func(t *testing.T) {
+		source := newAccount(t, "Client Errors")
+		_, err := bank.Transfer(ctx, source.Id, 999999, 10)
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Code != problem.CodeAccountNotFound || apiErr.Message != "target account not found" {
+			t.Fatalf("expected a 404 error, got %v", err)
+		}
+
+		unauthenticated, err := client.New(client.Config{BaseURL: server.URL + "/api"})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		_, err = unauthenticated.ListAccounts(ctx)
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected a 401 error, got %v", err)
+		}
+	})
+
+	t.Run(`should retry the requests the server refused`, func(t *testing.T) {
+		account := newAccount(t, "Client Retried")
+		var attempts atomic.Int64
+		flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			testHandler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(flaky.Close)
+
+		retrying, err := client.New(client.Config{
+			BaseURL:      flaky.URL + "/api",
+			APIKey:       testAPIKey,
+			MaxRetries:   3,
+			RetryBackoff: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if err := retrying.AddBalance(ctx, account.Id, 5); err != nil {
+			t.Fatalf("expected the deposit to succeed after the retries, got %v", err)
+		}
+
+		if attempts.Load() != 3 {
+			t.Fatalf("expected 3 attempts, got %d", attempts.Load())
+		}
+		if got, _ := mustGETAccount(t, testHandler, account.Id); got.Balance != 5 {
+			t.Fatalf("expected a single deposit, got a balance of %f", got.Balance)
+		}
+	})
+
+	t.Run(`should not retry the requests that may have been processed`, func(t *testing.T) {
+		for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable} {
+			var attempts atomic.Int64
+			failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				// no Retry-After, e.g. a proxy whose upstream failed
+				w.WriteHeader(status)
+			}))
+			t.Cleanup(failing.Close)
+
+			retrying, err := client.New(client.Config{BaseURL: failing.URL + "/api", MaxRetries: 3, RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			// without Idempotency-Key, the transfer may have gone through
+			_, _ = retrying.TransferMoney(ctx, 1, nil, client.TransferMoneyJSONRequestBody{TargetAccountId: 2, Amount: 10})
+			if attempts.Load() != 1 {
+				t.Fatalf("expected the transfer failing with %d not to be retried, got %d attempts", status, attempts.Load())
+			}
+
+			attempts.Store(0)
+			_, _ = retrying.ListAccounts(ctx)
+			if attempts.Load() != 4 {
+				t.Fatalf("expected the listing failing with %d to be retried 3 times, got %d attempts", status, attempts.Load())
+			}
+		}
+	})
+	t.Run(`should retry the requests sent with an idempotency key without applying them twice`, func(t *testing.T) {
+		source := newAccount(t, "Client Idempotent Source")
+		target := newAccount(t, "Client Idempotent Target")
+		if err := bank.AddBalance(ctx, source.Id, 100); err != nil {
+			t.Fatalf("failed to add balance: %v", err)
+		}
+
+		var keys []string
+		lossy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			if len(keys) == 1 {
+				// the transfer goes through, but its response is lost on the way back
+				testHandler.ServeHTTP(httptest.NewRecorder(), r)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			testHandler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(lossy.Close)
+
+		retrying, err := client.New(client.Config{BaseURL: lossy.URL + "/api", APIKey: testAPIKey, MaxRetries: 3, RetryBackoff: time.Millisecond})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if _, err := retrying.Transfer(ctx, source.Id, target.Id, 40); err != nil {
+			t.Fatalf("expected the transfer to succeed after the retry, got %v", err)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Fatalf("expected 2 attempts with the same idempotency key, got %q", keys)
+		}
+		if account, err := bank.GetAccount(ctx, source.Id); err != nil || account.Balance != 60 {
+			t.Fatalf("expected a single transfer of 40, got %+v, %v", account, err)
+		}
+	})
+}
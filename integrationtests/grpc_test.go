@@ -0,0 +1,265 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/grpcapi"
+	"tiny-bank-api/grpcapi/tinybankv1"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/ratelimit"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCAPI(t *testing.T) {
+	conn := newTestGRPCConn(t, grpcapi.Options{JWTVerifier: testJWTVerifier})
+	client := tinybankv1.NewAccountServiceClient(conn)
+	ctx := withGRPCAPIKey(testAPIKey)
+
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string) *tinybankv1.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		if _, err := client.CreateAccount(ctx, &tinybankv1.CreateAccountRequest{Name: name}); err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		accounts, err := client.ListAccounts(ctx, &tinybankv1.ListAccountsRequest{})
+		if err != nil {
+			t.Fatalf("failed to list accounts: %v", err)
+		}
+		for _, account := range accounts.GetAccounts() {
+			if account.GetName() == name {
+				return account
+			}
+		}
+		t.Fatalf("account %q not found", name)
+		return nil
+	}
+
+	t.Run(`should serve the health and reflection services`, func(t *testing.T) {
+		health := healthpb.NewHealthClient(conn)
+		for _, service := range []string{"", tinybankv1.AccountService_ServiceDesc.ServiceName} {
+			resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+				t.Fatalf("expected %q to be serving, got %v, %v", service, resp, err)
+			}
+		}
+
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		if err != nil {
+			t.Fatalf("failed to open reflection stream: %v", err)
+		}
+		if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}); err != nil {
+			t.Fatalf("failed to send reflection request: %v", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("failed to receive reflection response: %v", err)
+		}
+		found := false
+		for _, service := range resp.GetListServicesResponse().GetService() {
+			found = found || service.GetName() == tinybankv1.AccountService_ServiceDesc.ServiceName
+		}
+		if !found {
+			t.Fatalf("expected the AccountService to be listed, got %v", resp)
+		}
+	})
+
+	t.Run(`should create accounts, add balance and transfer money`, func(t *testing.T) {
+		source := newAccount(t, "gRPC Source")
+		target := newAccount(t, "gRPC Target")
+
+		if _, err := client.AddBalance(ctx, &tinybankv1.AddBalanceRequest{AccountId: source.GetId(), Amount: 100}); err != nil {
+			t.Fatalf("failed to add balance: %v", err)
+		}
+		resp, err := client.TransferMoney(ctx, &tinybankv1.TransferMoneyRequest{
+			SourceAccountId: source.GetId(),
+			TargetAccountId: target.GetId(),
+			Amount:          40,
+		})
+		if err != nil || resp.GetPendingTransfer() != nil {
+			t.Fatalf("expected the transfer to complete, got %v, %v", resp, err)
+		}
+
+		got, err := client.GetAccount(ctx, &tinybankv1.GetAccountRequest{AccountId: source.GetId()})
+		if err != nil {
+			t.Fatalf("failed to get account: %v", err)
+		}
+		if got.GetAccount().GetBalance() != 60 || got.GetAccount().GetStatus() != "active" {
+			t.Fatalf("unexpected source account %v", got.GetAccount())
+		}
+		// both APIs share the same accounts
+		if account, _ := mustGETAccount(t, testHandler, target.GetId()); account.Balance != 40 {
+			t.Fatalf("expected the target to have received 40, got %f", account.Balance)
+		}
+	})
+
+	t.Run(`should map the error responses to status codes`, func(t *testing.T) {
+		source := newAccount(t, "gRPC Errors")
+
+		_, err := client.GetAccount(ctx, &tinybankv1.GetAccountRequest{AccountId: 999999})
+		requireGRPCCode(t, codes.NotFound, err)
+		_, err = client.AddBalance(ctx, &tinybankv1.AddBalanceRequest{AccountId: source.GetId(), Amount: -1})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		// the constraints of the spec apply to the gRPC API as well
+		_, err = client.AddBalance(ctx, &tinybankv1.AddBalanceRequest{AccountId: source.GetId(), Amount: 0.001})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		_, err = client.CreateAccount(ctx, &tinybankv1.CreateAccountRequest{Name: ""})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		_, err = client.CreateAccount(ctx, &tinybankv1.CreateAccountRequest{Name: strings.Repeat("n", 256)})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		_, err = client.TransferMoney(ctx, &tinybankv1.TransferMoneyRequest{SourceAccountId: source.GetId(), TargetAccountId: source.GetId(), Amount: 1})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		_, err = client.TransferMoney(ctx, &tinybankv1.TransferMoneyRequest{SourceAccountId: source.GetId(), TargetAccountId: 999999, Amount: 1})
+		requireGRPCCode(t, codes.NotFound, err)
+		target := newAccount(t, "gRPC Errors Target")
+		_, err = client.TransferMoney(ctx, &tinybankv1.TransferMoneyRequest{SourceAccountId: source.GetId(), TargetAccountId: target.GetId(), Amount: 0.001})
+		requireGRPCCode(t, codes.InvalidArgument, err)
+		_, err = client.TransferMoney(ctx, &tinybankv1.TransferMoneyRequest{SourceAccountId: source.GetId(), TargetAccountId: target.GetId(), Amount: 1})
+		requireGRPCCode(t, codes.FailedPrecondition, err)
+	})
+
+	t.Run(`should authenticate and authorize the calls like the REST API`, func(t *testing.T) {
+		_, err := client.ListAccounts(context.Background(), &tinybankv1.ListAccountsRequest{})
+		requireGRPCCode(t, codes.Unauthenticated, err)
+		_, err = client.ListAccounts(withGRPCAPIKey("tbk_invalid"), &tinybankv1.ListAccountsRequest{})
+		requireGRPCCode(t, codes.Unauthenticated, err)
+
+		readOnly := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read")
+		if _, err := client.ListAccounts(withGRPCAPIKey(readOnly), &tinybankv1.ListAccountsRequest{}); err != nil {
+			t.Fatalf("expected support to list accounts, got %v", err)
+		}
+		_, err = client.CreateAccount(withGRPCAPIKey(readOnly), &tinybankv1.CreateAccountRequest{Name: "Missing Scope"})
+		requireGRPCCode(t, codes.PermissionDenied, err)
+
+		support := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read", "accounts:write")
+		_, err = client.AddBalance(withGRPCAPIKey(support), &tinybankv1.AddBalanceRequest{AccountId: 1, Amount: 1})
+		requireGRPCCode(t, codes.PermissionDenied, err)
+		if status.Convert(err).Message() != "your roles don't allow addBalanceToAccount" {
+			t.Fatalf("unexpected message %q", status.Convert(err).Message())
+		}
+
+		// the keys may have been rotated by the authentication tests, any current one signs the token
+		var kid string
+		for kid = range issuer.keys {
+			break
+		}
+		token := issuer.mustToken(t, kid, jwt.MapClaims{"sub": "grpc-client"})
+		mustAssignRole(t, "jwt:grpc-client", auth.RoleSupport)
+		bearer := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+		if _, err := client.ListAccounts(bearer, &tinybankv1.ListAccountsRequest{}); err != nil {
+			t.Fatalf("expected the bearer token to be accepted, got %v", err)
+		}
+	})
+
+	t.Run(`should restrict customers to their own accounts`, func(t *testing.T) {
+		other := newAccount(t, "gRPC Other Customer")
+		customer := mustPOSTCustomer(t, testHandler, fmt.Sprintf("gRPC Customer - %d", suffix), nil)
+		customerCtx := withGRPCAPIKey(mustCreateCustomerAPIKey(t, customer.Id, "accounts:read", "accounts:write"))
+
+		if _, err := client.CreateAccount(customerCtx, &tinybankv1.CreateAccountRequest{Name: "Own gRPC Account"}); err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		accounts, err := client.ListAccounts(customerCtx, &tinybankv1.ListAccountsRequest{})
+		if err != nil {
+			t.Fatalf("failed to list accounts: %v", err)
+		}
+		if len(accounts.GetAccounts()) != 1 || accounts.GetAccounts()[0].GetOwnerId() != customer.Id {
+			t.Fatalf("expected only the account of the customer, got %v", accounts.GetAccounts())
+		}
+		_, err = client.GetAccount(customerCtx, &tinybankv1.GetAccountRequest{AccountId: other.GetId()})
+		requireGRPCCode(t, codes.NotFound, err)
+	})
+
+	t.Run(`should record the calls in the audit log`, func(t *testing.T) {
+		account := newAccount(t, "gRPC Audited")
+		events := mustGetAllAuditEvents(t)
+		afterId := events[len(events)-1].Id
+
+		auditCtx := metadata.AppendToOutgoingContext(ctx, "x-request-id", "grpc-audit-request")
+		if _, err := client.AddBalance(auditCtx, &tinybankv1.AddBalanceRequest{AccountId: account.GetId(), Amount: 5}); err != nil {
+			t.Fatalf("failed to add balance: %v", err)
+		}
+
+		events = mustGetAuditEventsAfter(t, afterId)
+		if len(events) != 1 {
+			t.Fatalf("expected 1 audit event, got %+v", events)
+		}
+		if event := events[0]; event.Operation != "addBalanceToAccount" || event.RequestId != "grpc-audit-request" || event.ClientIP != "127.0.0.1" {
+			t.Fatalf("unexpected audit event %+v", event)
+		}
+	})
+}
+
+func TestGRPCRateLimiting(t *testing.T) {
+	conn := newTestGRPCConn(t, grpcapi.Options{
+		RateLimiter: ratelimit.NewMemoryLimiter(),
+		RateLimits:  api.RateLimits{PrincipalRead: ratelimit.Limit{Rate: 1.0 / 3600, Burst: 1}},
+	})
+	client := tinybankv1.NewAccountServiceClient(conn)
+	ctx := withGRPCAPIKey(mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read"))
+
+	var header metadata.MD
+	if _, err := client.ListAccounts(ctx, &tinybankv1.ListAccountsRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("expected the first call to go through, got %v", err)
+	}
+	if remaining := header.Get("ratelimit-remaining"); len(remaining) != 1 || remaining[0] != "0" {
+		t.Fatalf("unexpected ratelimit-remaining %v", remaining)
+	}
+
+	_, err := client.ListAccounts(ctx, &tinybankv1.ListAccountsRequest{}, grpc.Header(&header))
+	requireGRPCCode(t, codes.ResourceExhausted, err)
+	if retryAfter := header.Get("retry-after"); len(retryAfter) != 1 {
+		t.Fatalf("expected a retry-after header, got %v", header)
+	}
+}
+
+// newTestGRPCConn starts a gRPC server on a local port, stopped at the end of the test, and connects to it.
+func newTestGRPCConn(t *testing.T, opts grpcapi.Options) *grpc.ClientConn {
+	t.Helper()
+	logger := logging.DevLogger()
+	server, _ := grpcapi.NewGRPCServer(logger, testStore, api.NewAPI(logger, testStore, api.Options{}), opts)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func withGRPCAPIKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", apiKey)
+}
+
+func requireGRPCCode(t *testing.T, expected codes.Code, err error) {
+	t.Helper()
+	if code := status.Code(err); code != expected {
+		t.Fatalf("expected code %s, got %v", expected, err)
+	}
+}
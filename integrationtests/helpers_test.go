@@ -2,14 +2,29 @@ package integrationtests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/problem"
 )
 
+// serve runs the request authenticated with the admin API key of the suite, unless it already carries
+// credentials.
+func serve(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	if req.Header.Get(auth.APIKeyHeader) == "" {
+		req.Header.Set(auth.APIKeyHeader, testAPIKey)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
 func requireStatus(t *testing.T, expected int, rec *httptest.ResponseRecorder) {
 	t.Helper()
 	if rec.Code != expected {
@@ -21,9 +36,7 @@ func reqGETAccounts(t *testing.T, handler http.Handler) *httptest.ResponseRecord
 	t.Helper()
 	req := httptest.NewRequest(http.MethodGet, "/api/accounts", nil)
 	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-	handler.ServeHTTP(rec, req)
-	return rec
+	return serve(handler, req)
 }
 
 func reqPOSTAccount(t *testing.T, handler http.Handler, body map[string]any) *httptest.ResponseRecorder {
@@ -34,9 +47,7 @@ func reqPOSTAccount(t *testing.T, handler http.Handler, body map[string]any) *ht
 	}
 	req := httptest.NewRequest(http.MethodPost, "/api/accounts", bytes.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-	handler.ServeHTTP(rec, req)
-	return rec
+	return serve(handler, req)
 }
 
 func mustPOSTAccount(t *testing.T, handler http.Handler, name string) {
@@ -81,9 +92,7 @@ func reqPOSTAddBalance(t *testing.T, handler http.Handler, accountId int64, amou
 	}
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/accounts/%d/add-balance", accountId), bytes.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-	handler.ServeHTTP(rec, req)
-	return rec
+	return serve(handler, req)
 }
 
 func mustPOSTAddBalance(t *testing.T, handler http.Handler, accountId int64, amount float64) {
@@ -103,9 +112,7 @@ func reqPOSTTransfer(t *testing.T, handler http.Handler, sourceAccountId int64,
 	}
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/accounts/%d/transfer", sourceAccountId), bytes.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-	handler.ServeHTTP(rec, req)
-	return rec
+	return serve(handler, req)
 }
 
 func mustPOSTTransfer(t *testing.T, handler http.Handler, sourceAccountId int64, targetAccountId int64, amount float64) {
@@ -116,11 +123,189 @@ func mustPOSTTransfer(t *testing.T, handler http.Handler, sourceAccountId int64,
 
 func requireErrorMessage(t *testing.T, expected string, rec *httptest.ResponseRecorder) {
 	t.Helper()
-	var errResp api.ErrorResponse
-	if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
-		t.Fatalf("failed to decode error response: %v", err)
+	var p api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
 	}
-	if errResp.Message != expected {
-		t.Fatalf("expected error message %q, got %q", expected, errResp.Message)
+	if p.Detail != expected {
+		t.Fatalf("expected error detail %q, got %q", expected, p.Detail)
 	}
 }
+
+// requireProblem checks the response is a problem of code, with all the details of the problems.
+func requireProblem(t *testing.T, rec *httptest.ResponseRecorder, code problem.Code) api.Problem {
+	t.Helper()
+	if contentType := rec.Header().Get("Content-Type"); contentType != problem.ContentType {
+		t.Fatalf("expected a problem, got %q: %s", contentType, rec.Body.String())
+	}
+	var p api.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if p.Code != code || p.Type != problem.TypeURI(code) || p.Status != rec.Code || p.Title == "" || p.Detail == "" || p.RequestId == "" {
+		t.Fatalf("expected a complete %s problem, got %+v", code, p)
+	}
+	return p
+}
+
+func reqGETAccount(t *testing.T, handler http.Handler, accountId int64) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/accounts/%d", accountId), nil)
+	return serve(handler, req)
+}
+
+func mustGETAccount(t *testing.T, handler http.Handler, accountId int64) (api.Account, string) {
+	t.Helper()
+	rec := reqGETAccount(t, handler, accountId)
+	requireStatus(t, http.StatusOK, rec)
+
+	var account api.Account
+	if err := json.NewDecoder(rec.Body).Decode(&account); err != nil {
+		t.Fatalf("failed to decode account response: %v", err)
+	}
+	return account, rec.Header().Get("ETag")
+}
+
+func reqPUTAccountStatus(t *testing.T, handler http.Handler, accountId int64, status string, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]any{
+		"status": status,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/accounts/%d/status", accountId), bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return serve(handler, req)
+}
+
+func reqPATCHAccount(t *testing.T, handler http.Handler, accountId int64, patch map[string]any, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonBody, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/accounts/%d", accountId), bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return serve(handler, req)
+}
+
+func mustPATCHAccount(t *testing.T, handler http.Handler, accountId int64, patch map[string]any) api.Account {
+	t.Helper()
+	rec := reqPATCHAccount(t, handler, accountId, patch, "")
+	requireStatus(t, http.StatusOK, rec)
+
+	var account api.Account
+	if err := json.NewDecoder(rec.Body).Decode(&account); err != nil {
+		t.Fatalf("failed to decode account response: %v", err)
+	}
+	return account
+}
+
+func mustGETAccountChanges(t *testing.T, handler http.Handler, accountId int64) []api.AccountChange {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/accounts/%d/changes", accountId), nil)
+	rec := serve(handler, req)
+	requireStatus(t, http.StatusOK, rec)
+
+	var changes []api.AccountChange
+	if err := json.NewDecoder(rec.Body).Decode(&changes); err != nil {
+		t.Fatalf("failed to decode changes response: %v", err)
+	}
+	return changes
+}
+
+func mustCreateAPIKey(t *testing.T, expiresAt *time.Time, scopes ...string) (string, int64) {
+	t.Helper()
+	secret, key, err := auth.NewAPIKey(t.Name(), scopes, expiresAt)
+	if err != nil {
+		t.Fatalf("failed to generate api key: %v", err)
+	}
+	id, err := testStore.CreateAPIKey(context.Background(), key)
+	if err != nil {
+		t.Fatalf("failed to store api key: %v", err)
+	}
+	return secret, id
+}
+
+func mustAssignRole(t *testing.T, subject, role string) {
+	t.Helper()
+	if err := testStore.AssignRole(context.Background(), subject, role); err != nil {
+		t.Fatalf("failed to assign role: %v", err)
+	}
+}
+
+// mustCreateRoleAPIKey creates an API key for a back-office user with the role.
+func mustCreateRoleAPIKey(t *testing.T, role string, scopes ...string) string {
+	t.Helper()
+	secret, id := mustCreateAPIKey(t, nil, scopes...)
+	mustAssignRole(t, fmt.Sprintf("apikey:%d", id), role)
+	return secret
+}
+
+// mustCreateCustomerAPIKey creates an API key acting for the customer.
+func mustCreateCustomerAPIKey(t *testing.T, customerId int64, scopes ...string) string {
+	t.Helper()
+	secret, key, err := auth.NewAPIKey(t.Name(), scopes, nil)
+	if err != nil {
+		t.Fatalf("failed to generate api key: %v", err)
+	}
+	key.CustomerId = &customerId
+	if _, err := testStore.CreateAPIKey(context.Background(), key); err != nil {
+		t.Fatalf("failed to store api key: %v", err)
+	}
+	return secret
+}
+
+func mustPOSTCustomer(t *testing.T, handler http.Handler, name string, externalId *string) api.Customer {
+	t.Helper()
+	body := map[string]any{"name": name}
+	if externalId != nil {
+		body["external_id"] = *externalId
+	}
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/customers", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := serve(handler, req)
+	requireStatus(t, http.StatusCreated, rec)
+
+	var customer api.Customer
+	if err := json.NewDecoder(rec.Body).Decode(&customer); err != nil {
+		t.Fatalf("failed to decode customer response: %v", err)
+	}
+	return customer
+}
+
+func reqWithAPIKey(t *testing.T, handler http.Handler, method, target string, body any, apiKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if method == http.MethodPatch {
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+	}
+	rec := httptest.NewRecorder()
+	if apiKey != "" {
+		req.Header.Set(auth.APIKeyHeader, apiKey)
+	}
+	handler.ServeHTTP(rec, req)
+	return rec
+}
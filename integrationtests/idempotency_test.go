@@ -0,0 +1,137 @@
+package integrationtests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/problem"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	newKey := func(name string) string {
+		return fmt.Sprintf("%s-%d", name, suffix)
+	}
+	newAccount := func(t *testing.T, name string) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, testHandler, name)
+		return requireAccountExists(t, testHandler, name)
+	}
+	source := newAccount(t, "Idempotent Source")
+	target := newAccount(t, "Idempotent Target")
+
+	t.Run(`should replay the response of the retries instead of processing them again`, func(t *testing.T) {
+		key := newKey("add-balance")
+		addBalance := fmt.Sprintf("/api/accounts/%d/add-balance", source.Id)
+		for range 2 {
+			requireStatus(t, http.StatusOK, reqWithIdempotencyKey(t, testHandler, http.MethodPost, addBalance, map[string]any{"amount": 100}, testAPIKey, key))
+		}
+		requireBalances(t, testHandler, source.Id, 100, 0)
+
+		key = newKey("transfer")
+		transfer := fmt.Sprintf("/api/accounts/%d/transfer", source.Id)
+		body := map[string]any{"amount": 30, "targetAccountId": target.Id}
+		for range 2 {
+			requireStatus(t, http.StatusOK, reqWithIdempotencyKey(t, testHandler, http.MethodPost, transfer, body, testAPIKey, key))
+		}
+		requireBalances(t, testHandler, source.Id, 70, 0)
+		requireBalances(t, testHandler, target.Id, 30, 0)
+		if transfers := mustGETAccountTransfers(t, testHandler, source.Id); len(transfers) != 1 {
+			t.Fatalf("expected a single transfer, got %+v", transfers)
+		}
+
+		key = newKey("create-account")
+		name := fmt.Sprintf("Idempotent Account - %d", suffix)
+		for range 2 {
+			requireStatus(t, http.StatusCreated, reqWithIdempotencyKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": name}, testAPIKey, key))
+		}
+		created := 0
+		for _, account := range mustGETAccounts(t, testHandler) {
+			if account.Name == name {
+				created++
+			}
+		}
+		if created != 1 {
+			t.Fatalf("expected a single account named %q, got %d", name, created)
+		}
+	})
+
+	t.Run(`should process again the retries of the refused requests`, func(t *testing.T) {
+		key := newKey("overdraft")
+		transfer := fmt.Sprintf("/api/accounts/%d/transfer", target.Id)
+		body := map[string]any{"amount": 1000, "targetAccountId": source.Id}
+		// nothing is committed by the refusal, its response isn't saved
+		for range 2 {
+			rec := reqWithIdempotencyKey(t, testHandler, http.MethodPost, transfer, body, testAPIKey, key)
+			requireStatus(t, http.StatusUnprocessableEntity, rec)
+			requireProblem(t, rec, problem.CodeTransferRefused)
+		}
+		mustPOSTAddBalance(t, testHandler, target.Id, 1000)
+		requireStatus(t, http.StatusOK, reqWithIdempotencyKey(t, testHandler, http.MethodPost, transfer, body, testAPIKey, key))
+		requireBalances(t, testHandler, target.Id, 30, 0)
+		requireBalances(t, testHandler, source.Id, 1070, 0)
+	})
+
+	t.Run(`should refuse a key reused for a different request`, func(t *testing.T) {
+		key := newKey("reused")
+		addBalance := fmt.Sprintf("/api/accounts/%d/add-balance", source.Id)
+		requireStatus(t, http.StatusOK, reqWithIdempotencyKey(t, testHandler, http.MethodPost, addBalance, map[string]any{"amount": 5}, testAPIKey, key))
+
+		rec := reqWithIdempotencyKey(t, testHandler, http.MethodPost, addBalance, map[string]any{"amount": 50}, testAPIKey, key)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		if p := requireProblem(t, rec, problem.CodeIdempotencyKeyReused); p.Detail != "Idempotency-Key was already used for a different request" {
+			t.Fatalf("unexpected detail %q", p.Detail)
+		}
+		// another operation or account is a different request too
+		rec = reqWithIdempotencyKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/add-balance", target.Id), map[string]any{"amount": 5}, testAPIKey, key)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		rec = reqWithIdempotencyKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/transfer", source.Id),
+			map[string]any{"amount": 5, "targetAccountId": target.Id}, testAPIKey, key)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireBalances(t, testHandler, source.Id, 1075, 0)
+
+		// the keys of other credentials don't collide
+		other := mustCreateRoleAPIKey(t, auth.RoleAdmin, "accounts:read", "accounts:write")
+		requireStatus(t, http.StatusOK, reqWithIdempotencyKey(t, testHandler, http.MethodPost, addBalance, map[string]any{"amount": 50}, other, key))
+		requireBalances(t, testHandler, source.Id, 1125, 0)
+	})
+
+	t.Run(`should replay the transfers held for approval`, func(t *testing.T) {
+		approvals := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{ApprovalThreshold: 500})
+		source := newAccount(t, "Idempotent Held Source")
+		mustPOSTAddBalance(t, testHandler, source.Id, 1000)
+
+		transfer := fmt.Sprintf("/api/accounts/%d/transfer", source.Id)
+		body := map[string]any{"amount": 600, "targetAccountId": target.Id}
+		key := newKey("held")
+		first := reqWithIdempotencyKey(t, approvals, http.MethodPost, transfer, body, testAPIKey, key)
+		requireStatus(t, http.StatusAccepted, first)
+		retry := reqWithIdempotencyKey(t, approvals, http.MethodPost, transfer, body, testAPIKey, key)
+		requireStatus(t, http.StatusAccepted, retry)
+		if first.Body.String() != retry.Body.String() {
+			t.Fatalf("expected the retry to get the pending transfer back, got %s and %s", first.Body, retry.Body)
+		}
+		requireBalances(t, testHandler, source.Id, 1000, 600)
+	})
+}
+
+func reqWithIdempotencyKey(t *testing.T, handler http.Handler, method, target string, body any, apiKey, key string) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(method, target, bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(auth.APIKeyHeader, apiKey)
+	req.Header.Set("Idempotency-Key", key)
+	return serve(handler, req)
+}
@@ -0,0 +1,113 @@
+package integrationtests
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testJWTIssuer   = "https://issuer.test"
+	testJWTAudience = "tiny-bank-api"
+)
+
+// testIssuer plays the identity provider: it signs tokens and publishes its keys in a JWKS file.
+type testIssuer struct {
+	jwksPath string
+	keys     map[string]crypto.Signer
+}
+
+var issuer *testIssuer
+
+func newTestIssuer(jwksPath string) (*testIssuer, error) {
+	i := &testIssuer{jwksPath: jwksPath, keys: map[string]crypto.Signer{}}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	i.keys["rsa-1"] = rsaKey
+	i.keys["ec-1"] = ecKey
+	return i, i.publish()
+}
+
+// rotate replaces all the keys with a new RSA key and republishes the JWKS.
+func (i *testIssuer) rotate(kid string) error {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	i.keys = map[string]crypto.Signer{kid: rsaKey}
+	return i.publish()
+}
+
+func (i *testIssuer) publish() error {
+	var keys []map[string]string
+	for kid, key := range i.keys {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			keys = append(keys, map[string]string{
+				"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+				"n": base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+			})
+		case *ecdsa.PrivateKey:
+			raw, err := k.PublicKey.Bytes()
+			if err != nil {
+				return err
+			}
+			size := (len(raw) - 1) / 2
+			keys = append(keys, map[string]string{
+				"kty": "EC", "kid": kid, "use": "sig", "crv": k.Curve.Params().Name,
+				"x": base64.RawURLEncoding.EncodeToString(raw[1 : 1+size]),
+				"y": base64.RawURLEncoding.EncodeToString(raw[1+size:]),
+			})
+		}
+	}
+	raw, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(i.jwksPath, raw, 0o600)
+}
+
+// mustToken signs a token with the key kid, with valid claims unless overridden.
+func (i *testIssuer) mustToken(t *testing.T, kid string, overrides jwt.MapClaims) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"iss":   testJWTIssuer,
+		"aud":   testJWTAudience,
+		"sub":   "svc-reporting",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"scope": "accounts:read",
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if _, ok := i.keys[kid].(*ecdsa.PrivateKey); ok {
+		method = jwt.SigningMethodES256
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(i.keys[kid])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
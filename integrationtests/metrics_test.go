@@ -0,0 +1,110 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/metrics"
+	"tiny-bank-api/store"
+)
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New("EUR")
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{Metrics: m})
+
+	name := fmt.Sprintf("Metrics - %d", time.Now().UnixNano())
+	mustPOSTAccount(t, handler, name+" source")
+	mustPOSTAccount(t, handler, name+" target")
+	source := requireAccountExists(t, handler, name+" source")
+	target := requireAccountExists(t, handler, name+" target")
+	mustPOSTAddBalance(t, handler, source.Id, 100)
+	mustPOSTTransfer(t, handler, source.Id, target.Id, 30)
+	requireStatus(t, http.StatusUnprocessableEntity, reqPOSTTransfer(t, handler, source.Id, target.Id, 1000))
+	requireStatus(t, http.StatusNotFound, reqPOSTTransfer(t, handler, source.Id, 999999, 10))
+	requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, handler, http.MethodGet, "/api/nowhere", nil, testAPIKey))
+
+	scraped := scrapeMetrics(t, m)
+
+	t.Run(`should count the requests by operation and status`, func(t *testing.T) {
+		requireMetric(t, scraped, `tinybank_http_requests_total{operation="CreateAccount",status="201"} 2`)
+		requireMetric(t, scraped, `tinybank_http_requests_total{operation="TransferMoney",status="200"} 1`)
+		requireMetric(t, scraped, `tinybank_http_requests_total{operation="unknown",status="404"} 1`)
+		requireMetric(t, scraped, `tinybank_http_request_duration_seconds_count{operation="TransferMoney",status="422"} 1`)
+	})
+
+	t.Run(`should count the business events`, func(t *testing.T) {
+		requireMetric(t, scraped, `tinybank_accounts_created_total 2`)
+		requireMetric(t, scraped, `tinybank_transfers_created_total{status="completed"} 1`)
+		requireMetric(t, scraped, `tinybank_transfers_failed_total{reason="transfer_refused"} 1`)
+		requireMetric(t, scraped, `tinybank_transfers_failed_total{reason="account_not_found"} 1`)
+		requireMetric(t, scraped, `tinybank_transferred_amount_total{currency="EUR"} 30`)
+	})
+
+	t.Run(`should measure the queries and the pool of the database`, func(t *testing.T) {
+		db, err := database.NewSQLiteConnection(context.Background(), filepath.Join(t.TempDir(), "metrics.db"))
+		if err != nil {
+			t.Fatalf("failed to connect to database: %v", err)
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+		m := metrics.New("EUR")
+		if err := m.RegisterDB(db.DB, "sqlite"); err != nil {
+			t.Fatalf("failed to register db metrics: %v", err)
+		}
+		s := store.NewSQLiteStore(database.MetricsDB{SQLDB: db, Queries: m.DBQueryDuration})
+		if err := s.Migrate(context.Background()); err != nil {
+			t.Fatalf("failed to migrate database: %v", err)
+		}
+		secret, key, err := auth.NewAPIKey(t.Name(), []string{auth.ScopeAdmin}, nil)
+		if err != nil {
+			t.Fatalf("failed to generate api key: %v", err)
+		}
+		if _, err := s.CreateAPIKey(context.Background(), key); err != nil {
+			t.Fatalf("failed to store api key: %v", err)
+		}
+		handler := newTestService(logging.DevLogger(), s, testJWTVerifier, api.Options{Metrics: m})
+
+		// the account is created in a transaction, whose queries are measured too
+		requireStatus(t, http.StatusCreated, reqWithAPIKey(t, handler, http.MethodPost, "/api/accounts", map[string]any{"name": "Metrics"}, secret))
+
+		scraped := scrapeMetrics(t, m)
+		requireMetric(t, scraped, `tinybank_db_query_duration_seconds_count{statement="insert"}`)
+		requireMetric(t, scraped, `tinybank_db_query_duration_seconds_count{statement="select"}`)
+		requireMetric(t, scraped, `go_sql_open_connections{db_name="sqlite"}`)
+	})
+}
+
+// scrapeMetrics returns the metrics of m in the exposition format.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	requireStatus(t, http.StatusOK, rec)
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+// requireMetric checks a line of the scraped metrics starts with sample.
+func requireMetric(t *testing.T, scraped, sample string) {
+	t.Helper()
+	for _, line := range strings.Split(scraped, "\n") {
+		if strings.HasPrefix(line, sample) {
+			return
+		}
+	}
+	t.Fatalf("expected metric %s, got:\n%s", sample, scraped)
+}
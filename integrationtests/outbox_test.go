@@ -0,0 +1,275 @@
+package integrationtests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/outbox"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// recordingSink keeps the published messages, failing the ones fail returns an error for.
+type recordingSink struct {
+	messages []outbox.Message
+	fail     func(message outbox.Message) error
+}
+
+func (s *recordingSink) Publish(_ context.Context, message outbox.Message) error {
+	if s.fail != nil {
+		if err := s.fail(message); err != nil {
+			return err
+		}
+	}
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+// types returns the types of the messages published about the account, in order.
+func (s *recordingSink) types(accountId int64) []string {
+	var types []string
+	for _, message := range s.messages {
+		if message.AccountId == accountId {
+			types = append(types, message.Type)
+		}
+	}
+	return types
+}
+
+func TestOutboxEvents(t *testing.T) {
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{})
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, handler, name)
+		return requireAccountExists(t, handler, name)
+	}
+
+	t.Run(`should publish the events of the committed changes in order`, func(t *testing.T) {
+		source := newAccount(t, "Outbox Source")
+		target := newAccount(t, "Outbox Target")
+		mustPOSTAddBalance(t, handler, source.Id, 100)
+		mustPOSTTransfer(t, handler, source.Id, target.Id, 40)
+		// refused transfers are rolled back along with their events
+		rec := reqPOSTTransfer(t, handler, source.Id, target.Id, 1000)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		rec = reqWithAPIKey(t, handler, http.MethodPut, fmt.Sprintf("/api/accounts/%d/status", source.Id), map[string]any{"status": "frozen"}, testAPIKey)
+		requireStatus(t, http.StatusOK, rec)
+
+		sink := &recordingSink{}
+		relayAllOutboxEvents(t, sink)
+
+		expected := []string{"AccountCreated", "BalanceAdded", "TransferCompleted", "AccountFrozen"}
+		if types := sink.types(source.Id); !slices.Equal(types, expected) {
+			t.Fatalf("expected the events %v, got %v", expected, types)
+		}
+		if types := sink.types(target.Id); !slices.Equal(types, []string{"AccountCreated"}) {
+			t.Fatalf("expected only the creation of the target, got %v", types)
+		}
+
+		// published events aren't published again
+		sink = &recordingSink{}
+		relayAllOutboxEvents(t, sink)
+		if types := sink.types(source.Id); len(types) > 0 {
+			t.Fatalf("expected no event to be published again, got %v", types)
+		}
+	})
+
+	t.Run(`should hold back the events of an account until the failed one is published`, func(t *testing.T) {
+		failing := newAccount(t, "Outbox Failing")
+		other := newAccount(t, "Outbox Other")
+		mustPOSTAddBalance(t, handler, failing.Id, 10)
+		mustPOSTAddBalance(t, handler, other.Id, 10)
+
+		sink := &recordingSink{fail: func(message outbox.Message) error {
+			if message.AccountId == failing.Id {
+				return errors.New("sink unavailable")
+			}
+			return nil
+		}}
+		if _, err := api.RelayOutboxEvents(context.Background(), testStore, sink, 1000, time.Now()); err == nil {
+			t.Fatalf("expected the failure of the sink to be returned")
+		}
+		if types := sink.types(failing.Id); len(types) > 0 {
+			t.Fatalf("expected no event of the failing account, got %v", types)
+		}
+		if types := sink.types(other.Id); !slices.Equal(types, []string{"AccountCreated", "BalanceAdded"}) {
+			t.Fatalf("expected the events of the other account to be published, got %v", types)
+		}
+
+		sink.fail = nil
+		relayAllOutboxEvents(t, sink)
+		if types := sink.types(failing.Id); !slices.Equal(types, []string{"AccountCreated", "BalanceAdded"}) {
+			t.Fatalf("expected the held back events in order, got %v", types)
+		}
+	})
+
+	t.Run(`should back off the failing account without holding back the others`, func(t *testing.T) {
+		relayAllOutboxEvents(t, &recordingSink{})
+		failing := newAccount(t, "Outbox Backoff Failing")
+		for range 3 {
+			mustPOSTAddBalance(t, handler, failing.Id, 10)
+		}
+		other := newAccount(t, "Outbox Backoff Other")
+		mustPOSTAddBalance(t, handler, other.Id, 10)
+
+		attempts := 0
+		sink := &recordingSink{fail: func(message outbox.Message) error {
+			if message.AccountId == failing.Id {
+				attempts++
+				return errors.New("sink unavailable")
+			}
+			return nil
+		}}
+		// the batches are smaller than the events of the failing account
+		now := time.Now()
+		for range 3 {
+			_, _ = api.RelayOutboxEvents(context.Background(), testStore, sink, 2, now)
+		}
+		if types := sink.types(other.Id); !slices.Equal(types, []string{"AccountCreated", "BalanceAdded"}) {
+			t.Fatalf("expected the events of the other account to be published, got %v", types)
+		}
+		if attempts != 1 {
+			t.Fatalf("expected the failing account to be backed off after 1 attempt, got %d", attempts)
+		}
+
+		sink.fail = nil
+		relayAllOutboxEvents(t, sink)
+		expected := []string{"AccountCreated", "BalanceAdded", "BalanceAdded", "BalanceAdded"}
+		if types := sink.types(failing.Id); !slices.Equal(types, expected) {
+			t.Fatalf("expected the held back events in order, got %v", types)
+		}
+	})
+
+	t.Run(`should publish the events to NATS`, func(t *testing.T) {
+		ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+		if err != nil {
+			t.Fatalf("failed to create NATS server: %v", err)
+		}
+		ns.Start()
+		t.Cleanup(ns.Shutdown)
+		if !ns.ReadyForConnections(5 * time.Second) {
+			t.Fatalf("NATS server not ready")
+		}
+
+		prefix := fmt.Sprintf("test%d", suffix)
+		conn, err := nats.Connect(ns.ClientURL())
+		if err != nil {
+			t.Fatalf("failed to connect to NATS: %v", err)
+		}
+		t.Cleanup(conn.Close)
+		subscription, err := conn.SubscribeSync(prefix + ".>")
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		if err := conn.Flush(); err != nil {
+			t.Fatalf("failed to flush subscription: %v", err)
+		}
+
+		sink, err := outbox.Open(ns.ClientURL(), prefix)
+		if err != nil {
+			t.Fatalf("failed to open NATS sink: %v", err)
+		}
+		t.Cleanup(func() { _ = sink.Close() })
+
+		account := newAccount(t, "Outbox NATS")
+		mustPOSTAddBalance(t, handler, account.Id, 25)
+		relayAllOutboxEvents(t, sink)
+
+		var types []string
+		for {
+			msg, err := subscription.NextMsg(time.Second)
+			if errors.Is(err, nats.ErrTimeout) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to receive message: %v", err)
+			}
+			var message outbox.Message
+			if err := json.Unmarshal(msg.Data, &message); err != nil {
+				t.Fatalf("failed to decode message: %v", err)
+			}
+			if message.AccountId != account.Id {
+				continue
+			}
+			if msg.Subject != fmt.Sprintf("%s.%d.%s", prefix, account.Id, message.Type) {
+				t.Fatalf("unexpected subject %s", msg.Subject)
+			}
+			if msg.Header.Get(nats.MsgIdHdr) != strconv.FormatInt(message.Id, 10) {
+				t.Fatalf("expected the message id in the header, got %q", msg.Header.Get(nats.MsgIdHdr))
+			}
+			types = append(types, message.Type)
+		}
+		if !slices.Equal(types, []string{"AccountCreated", "BalanceAdded"}) {
+			t.Fatalf("expected the events of the account, got %v", types)
+		}
+	})
+
+	t.Run(`should append the events to a file`, func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		sink, err := outbox.Open(path, "")
+		if err != nil {
+			t.Fatalf("failed to open file sink: %v", err)
+		}
+
+		account := newAccount(t, "Outbox File")
+		relayAllOutboxEvents(t, sink)
+		if err := sink.Close(); err != nil {
+			t.Fatalf("failed to close file sink: %v", err)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("failed to open events file: %v", err)
+		}
+		defer f.Close()
+		found := false
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var message outbox.Message
+			if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+				t.Fatalf("failed to decode line %q: %v", scanner.Text(), err)
+			}
+			var created api.Account
+			if message.AccountId == account.Id && message.Type == "AccountCreated" && json.Unmarshal(message.Data, &created) == nil {
+				found = created.Name == account.Name
+			}
+		}
+		if !found {
+			t.Fatalf("expected the creation of account %d in the events file", account.Id)
+		}
+	})
+}
+
+// relayAllOutboxEvents publishes the whole outbox, including the events left by the other tests and the
+// events backed off after failing to be published.
+func relayAllOutboxEvents(t *testing.T, sink outbox.Sink) {
+	t.Helper()
+	later := time.Now().Add(time.Hour)
+	for {
+		published, err := api.RelayOutboxEvents(context.Background(), testStore, sink, 1000, later)
+		if err != nil {
+			t.Fatalf("failed to relay outbox events: %v", err)
+		}
+		if published < 1000 {
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccountOwnership(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	alice := mustPOSTCustomer(t, testHandler, "Alice", nil)
+	bob := mustPOSTCustomer(t, testHandler, "Bob", nil)
+	aliceKey := mustCreateCustomerAPIKey(t, alice.Id, "accounts:read", "accounts:write", "transfers:create")
+	bobKey := mustCreateCustomerAPIKey(t, bob.Id, "accounts:read", "accounts:write", "transfers:create")
+
+	aliceAccountName := fmt.Sprintf("Alice Account - %d", suffix)
+	rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": aliceAccountName}, aliceKey)
+	requireStatus(t, http.StatusCreated, rec)
+	aliceAccount := requireAccountExists(t, testHandler, aliceAccountName)
+
+	bobAccountName := fmt.Sprintf("Bob Account - %d", suffix)
+	rec = reqPOSTAccount(t, testHandler, map[string]any{"name": bobAccountName, "owner_id": bob.Id})
+	requireStatus(t, http.StatusCreated, rec)
+	bobAccount := requireAccountExists(t, testHandler, bobAccountName)
+
+	t.Run(`should own the accounts created by a customer`, func(t *testing.T) {
+		if aliceAccount.OwnerId == nil || *aliceAccount.OwnerId != alice.Id {
+			t.Fatalf("expected account to be owned by customer %d, got %v", alice.Id, aliceAccount.OwnerId)
+		}
+	})
+
+	t.Run(`should only list the accounts of the customer`, func(t *testing.T) {
+		accounts := mustGETAccounts(t, testHandler)
+		if len(accounts) < 2 {
+			t.Fatalf("expected admins to see every account, got %d", len(accounts))
+		}
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, bobKey)
+		requireStatus(t, http.StatusOK, rec)
+		if body := rec.Body.String(); !containsAccount(body, bobAccountName) || containsAccount(body, aliceAccountName) {
+			t.Fatalf("expected only the accounts of bob, got %s", body)
+		}
+	})
+
+	t.Run(`should hide the accounts of other customers`, func(t *testing.T) {
+		target := fmt.Sprintf("/api/accounts/%d", bobAccount.Id)
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodGet, target, nil, aliceKey))
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodGet, target+"/changes", nil, aliceKey))
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodPatch, target, map[string]any{"name": "Stolen"}, aliceKey))
+
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodGet, target, nil, bobKey))
+	})
+
+	t.Run(`should only create accounts for other customers as admin`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": "Not Mine", "owner_id": bob.Id}, aliceKey)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "only admins can create accounts for other customers", rec)
+
+		unbound, _ := mustCreateAPIKey(t, nil, "accounts:write")
+		rec = reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": "Nobody's"}, unbound)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow createAccount", rec)
+
+		rec = reqPOSTAccount(t, testHandler, map[string]any{"name": "Ghost's", "owner_id": 999999})
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireErrorMessage(t, "owner not found", rec)
+	})
+
+	t.Run(`should transfer from own accounts to any account`, func(t *testing.T) {
+		mustPOSTAddBalance(t, testHandler, aliceAccount.Id, 100)
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/transfer", aliceAccount.Id),
+			map[string]any{"amount": 40, "targetAccountId": bobAccount.Id}, aliceKey)
+		requireStatus(t, http.StatusOK, rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/transfer", bobAccount.Id),
+			map[string]any{"amount": 40, "targetAccountId": aliceAccount.Id}, aliceKey)
+		requireStatus(t, http.StatusNotFound, rec)
+		requireErrorMessage(t, "source account not found", rec)
+
+		account, _ := mustGETAccount(t, testHandler, bobAccount.Id)
+		if account.Balance != 40 {
+			t.Fatalf("expected bob's balance to be 40, got %f", account.Balance)
+		}
+	})
+
+	t.Run(`should reserve customers management to admins`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/customers", nil, aliceKey)
+		requireStatus(t, http.StatusForbidden, rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodGet, "/api/customers", nil, testAPIKey)
+		requireStatus(t, http.StatusOK, rec)
+	})
+}
+
+func containsAccount(body, name string) bool {
+	return strings.Contains(body, fmt.Sprintf("%q", name))
+}
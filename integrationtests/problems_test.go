@@ -0,0 +1,65 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/pkg/problem"
+)
+
+func TestProblems(t *testing.T) {
+	t.Run(`should answer the missing resources with a problem`, func(t *testing.T) {
+		rec := reqGETAccount(t, testHandler, 999999)
+		requireStatus(t, http.StatusNotFound, rec)
+		requireProblem(t, rec, problem.CodeNotFound)
+	})
+
+	t.Run(`should answer the rejected credentials with a problem`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts", nil, "not-a-key")
+		requireStatus(t, http.StatusUnauthorized, rec)
+		requireProblem(t, rec, problem.CodeUnauthorized)
+
+		readOnly, _ := mustCreateAPIKey(t, nil, "accounts:read")
+		rec = reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": "Denied"}, readOnly)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireProblem(t, rec, problem.CodeForbidden)
+	})
+
+	t.Run(`should answer the refused transfers with their own codes`, func(t *testing.T) {
+		name := fmt.Sprintf("Problem Source - %d", time.Now().UnixNano())
+		mustPOSTAccount(t, testHandler, name)
+		source := requireAccountExists(t, testHandler, name)
+
+		rec := reqPOSTTransfer(t, testHandler, source.Id, 999999, 10)
+		requireStatus(t, http.StatusNotFound, rec)
+		requireProblem(t, rec, problem.CodeAccountNotFound)
+
+		name = fmt.Sprintf("Problem Target - %d", time.Now().UnixNano())
+		mustPOSTAccount(t, testHandler, name)
+		target := requireAccountExists(t, testHandler, name)
+		rec = reqPOSTTransfer(t, testHandler, source.Id, target.Id, 10)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireProblem(t, rec, problem.CodeTransferRefused)
+	})
+
+	t.Run(`should answer the malformed bodies with a problem`, func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/accounts", strings.NewReader(`{"name":`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := serve(testHandler, req)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireProblem(t, rec, problem.CodeInvalidRequest)
+	})
+
+	t.Run(`should answer the unknown routes with a problem`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/nothing-here", nil, testAPIKey)
+		requireStatus(t, http.StatusNotFound, rec)
+		requireProblem(t, rec, problem.CodeNotFound)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodDelete, "/api/accounts", nil, testAPIKey)
+		requireStatus(t, http.StatusMethodNotAllowed, rec)
+		requireProblem(t, rec, problem.CodeMethodNotAllowed)
+	})
+}
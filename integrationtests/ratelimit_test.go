@@ -0,0 +1,148 @@
+package integrationtests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/pkg/ratelimit"
+	"tiny-bank-api/store"
+)
+
+func TestRateLimiting(t *testing.T) {
+	hour := func(requests int) ratelimit.Limit {
+		return ratelimit.Limit{Rate: float64(requests) / 3600, Burst: requests}
+	}
+	accountName := fmt.Sprintf("Rate Limited Account - %d", time.Now().UnixNano())
+	mustPOSTAccount(t, testHandler, accountName)
+	account := requireAccountExists(t, testHandler, accountName)
+	addBalancePath := fmt.Sprintf("/api/accounts/%d/add-balance", account.Id)
+
+	t.Run(`should limit money-moving operations per principal`, func(t *testing.T) {
+		handler := newRateLimitedTestService(t, api.RateLimits{PrincipalMoney: hour(2)})
+		secret := mustCreateRoleAPIKey(t, auth.RoleAdmin, "accounts:read", "accounts:write")
+		ip := uniqueClientAddr()
+
+		for remaining := 1; remaining >= 0; remaining-- {
+			rec := reqRateLimited(t, handler, http.MethodPost, addBalancePath, `{"amount": 1}`, secret, ip)
+			requireStatus(t, http.StatusOK, rec)
+			requireHeader(t, rec, "RateLimit-Limit", "2")
+			requireHeader(t, rec, "RateLimit-Remaining", fmt.Sprint(remaining))
+		}
+
+		rec := reqRateLimited(t, handler, http.MethodPost, addBalancePath, `{"amount": 1}`, secret, ip)
+		requireStatus(t, http.StatusTooManyRequests, rec)
+		requireHeader(t, rec, "RateLimit-Remaining", "0")
+		if rec.Header().Get("Retry-After") == "" {
+			t.Fatalf("expected a Retry-After header")
+		}
+		if p := requireProblem(t, rec, problem.CodeRateLimited); !strings.HasPrefix(p.Detail, "rate limit exceeded, retry in ") {
+			t.Fatalf("unexpected detail %q", p.Detail)
+		}
+
+		// reads and other clients have their own buckets
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		other := mustCreateRoleAPIKey(t, auth.RoleAdmin, "accounts:read", "accounts:write")
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodPost, addBalancePath, `{"amount": 1}`, other, ip))
+	})
+
+	t.Run(`should limit each client IP across principals`, func(t *testing.T) {
+		handler := newRateLimitedTestService(t, api.RateLimits{IPRead: hour(3)})
+		ip := uniqueClientAddr()
+
+		for range 3 {
+			secret := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read")
+			requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		}
+		secret := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read")
+		requireStatus(t, http.StatusTooManyRequests, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, uniqueClientAddr()))
+	})
+
+	t.Run(`should not charge the principal for the requests its IP can't make`, func(t *testing.T) {
+		handler := newRateLimitedTestService(t, api.RateLimits{PrincipalRead: hour(2), IPRead: hour(1)})
+		secret := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read")
+		ip := uniqueClientAddr()
+
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		for range 3 {
+			requireStatus(t, http.StatusTooManyRequests, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		}
+		// the principal still has the token the rejected requests took back
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, uniqueClientAddr()))
+		requireStatus(t, http.StatusTooManyRequests, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, uniqueClientAddr()))
+	})
+
+	t.Run(`should refill the buckets over time`, func(t *testing.T) {
+		handler := newRateLimitedTestService(t, api.RateLimits{PrincipalRead: ratelimit.Limit{Rate: 20, Burst: 1}})
+		secret := mustCreateRoleAPIKey(t, auth.RoleSupport, "accounts:read")
+		ip := uniqueClientAddr()
+
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		requireStatus(t, http.StatusTooManyRequests, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+		time.Sleep(100 * time.Millisecond)
+		requireStatus(t, http.StatusOK, reqRateLimited(t, handler, http.MethodGet, "/api/accounts", "", secret, ip))
+	})
+}
+
+func TestParseRateLimit(t *testing.T) {
+	cases := map[string]ratelimit.Limit{
+		"10/s":      {Rate: 10, Burst: 10},
+		"100/1m:20": {Rate: 100.0 / 60, Burst: 20},
+		"off":       {},
+	}
+	for text, expected := range cases {
+		var limit ratelimit.Limit
+		if err := limit.UnmarshalText([]byte(text)); err != nil {
+			t.Fatalf("failed to parse %q: %v", text, err)
+		}
+		if limit != expected {
+			t.Fatalf("expected %q to parse as %+v, got %+v", text, expected, limit)
+		}
+	}
+
+	for _, text := range []string{"10", "0/s", "10/x", "10/s:0"} {
+		var limit ratelimit.Limit
+		if err := limit.UnmarshalText([]byte(text)); err == nil {
+			t.Fatalf("expected %q to be rejected", text)
+		}
+	}
+}
+
+// newRateLimitedTestService uses the postgres limiter when the suite runs against postgres.
+func newRateLimitedTestService(t *testing.T, limits api.RateLimits) http.Handler {
+	t.Helper()
+	var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	if postgresStore, ok := testStore.(store.PostgresStore); ok {
+		limiter = store.NewPostgresRateLimiter(postgresStore)
+	}
+	return newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{}, api.RateLimit(limiter, limits))
+}
+
+// uniqueClientAddr returns an address no other test uses, as the postgres buckets outlive the test runs.
+func uniqueClientAddr() string {
+	n := time.Now().UnixNano()
+	return fmt.Sprintf("[2001:db8::%x:%x:%x]:4242", (n>>32)&0xffff, (n>>16)&0xffff, n&0xffff)
+}
+
+func reqRateLimited(t *testing.T, handler http.Handler, method, target, body, apiKey, remoteAddr string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	req.Header.Set(auth.APIKeyHeader, apiKey)
+	return serve(handler, req)
+}
+
+func requireHeader(t *testing.T, rec *httptest.ResponseRecorder, name, expected string) {
+	t.Helper()
+	if actual := rec.Header().Get(name); actual != expected {
+		t.Fatalf("expected header %s to be %q, got %q", name, expected, actual)
+	}
+}
@@ -0,0 +1,216 @@
+package integrationtests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/pkg/risk"
+)
+
+const testRiskRules = `
+rules:
+  - name: small-amounts
+    type: amount
+    max: 1
+    decision: allow
+  - name: round-amounts
+    type: round_amount
+    multiple: 100
+    min: 300
+    decision: review
+  - name: large-amount
+    type: amount
+    min: 900
+    decision: deny
+  - name: fan-out
+    type: fan_out
+    window: 1h
+    max_new_targets: 2
+    decision: deny
+`
+
+func TestRiskRules(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "risk-rules.yaml")
+	if err := os.WriteFile(rulesPath, []byte(testRiskRules), 0o600); err != nil {
+		t.Fatalf("failed to write risk rules: %v", err)
+	}
+	engine, err := risk.NewEngine(rulesPath)
+	if err != nil {
+		t.Fatalf("failed to load risk rules: %v", err)
+	}
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{RiskEngine: engine})
+
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string, balance float64) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, handler, name)
+		account := requireAccountExists(t, handler, name)
+		if balance > 0 {
+			mustPOSTAddBalance(t, handler, account.Id, balance)
+		}
+		return account
+	}
+	target := newAccount(t, "Risk Target", 0)
+
+	t.Run(`should flag, deny and record the transfers matching the rules`, func(t *testing.T) {
+		source := newAccount(t, "Risky Source", 5000)
+
+		mustPOSTTransfer(t, handler, source.Id, target.Id, 50)
+		// the transfer flagged for review is held until it is decided
+		reviewed := mustPOSTPendingTransfer(t, handler, source.Id, target.Id, 300)
+		requireBalances(t, handler, source.Id, 4950, 300)
+		rec := reqPOSTTransfer(t, handler, source.Id, target.Id, 1000)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "transfer declined by the risk checks", rec)
+		requireBalances(t, handler, source.Id, 4950, 300)
+
+		transfers := mustGETAccountTransfers(t, handler, source.Id)
+		if len(transfers) != 3 {
+			t.Fatalf("expected 3 transfers, got %+v", transfers)
+		}
+		requireTransfer(t, transfers[0], api.TransferStatusCompleted, api.Allow)
+		requireTransfer(t, transfers[1], api.TransferStatusPendingApproval, api.Review, "round-amounts")
+		// the deny ends the evaluation, after the review flag of the round amount
+		requireTransfer(t, transfers[2], api.TransferStatusDeclined, api.Deny, "round-amounts", "large-amount")
+
+		// once approved, the reviewed transfer goes through
+		checker := mustCreateRoleAPIKey(t, "operator", "transfers:create", "accounts:write")
+		rec = reqPOSTTransferDecision(t, handler, reviewed.Id, "approve", checker)
+		requireStatus(t, http.StatusOK, rec)
+		requireTransfer(t, decodeTransfer(t, rec), api.TransferStatusCompleted, api.Review, "round-amounts")
+		requireBalances(t, handler, source.Id, 4650, 0)
+	})
+
+	t.Run(`should stop evaluating at the first allowing rule`, func(t *testing.T) {
+		source := newAccount(t, "Small Source", 10)
+		mustPOSTTransfer(t, handler, source.Id, target.Id, 1)
+		requireTransfer(t, mustGETAccountTransfers(t, handler, source.Id)[0], api.TransferStatusCompleted, api.Allow, "small-amounts")
+	})
+
+	t.Run(`should deny fanning out to many new targets`, func(t *testing.T) {
+		source := newAccount(t, "Fan Out Source", 1000)
+		targets := []api.Account{target, newAccount(t, "Fan Out Target 1", 0), newAccount(t, "Fan Out Target 2", 0)}
+
+		mustPOSTTransfer(t, handler, source.Id, targets[0].Id, 10)
+		mustPOSTTransfer(t, handler, source.Id, targets[1].Id, 10)
+		rec := reqPOSTTransfer(t, handler, source.Id, targets[2].Id, 10)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		// known targets are still fine
+		mustPOSTTransfer(t, handler, source.Id, targets[0].Id, 10)
+
+		declined := mustGETAccountTransfers(t, handler, source.Id)[2]
+		requireTransfer(t, declined, api.TransferStatusDeclined, api.Deny, "fan-out")
+		if declined.RiskEvaluations[0].Reason != "3 new targets within 1h0m0s" {
+			t.Fatalf("unexpected reason %q", declined.RiskEvaluations[0].Reason)
+		}
+	})
+
+	t.Run(`should hide the transfers from customers`, func(t *testing.T) {
+		customer := mustPOSTCustomer(t, handler, fmt.Sprintf("Risk Customer - %d", suffix), nil)
+		secret := mustCreateCustomerAPIKey(t, customer.Id, "accounts:read")
+		rec := reqWithAPIKey(t, handler, http.MethodGet, fmt.Sprintf("/api/accounts/%d/transfers", target.Id), nil, secret)
+		requireStatus(t, http.StatusForbidden, rec)
+	})
+
+	t.Run(`should decline the approved transfers the rules deny by now`, func(t *testing.T) {
+		approvals := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{
+			RiskEngine:        engine,
+			ApprovalThreshold: 500,
+			ApprovalTTL:       time.Hour,
+		})
+		checker := mustCreateRoleAPIKey(t, "operator", "transfers:create", "accounts:write")
+		source := newAccount(t, "Approved Fan Out Source", 1000)
+
+		pending := mustPOSTPendingTransfer(t, approvals, source.Id, newAccount(t, "Approved Fan Out Target 1", 0).Id, 650)
+		// the pending transfer doesn't count as a target yet, the two next ones do
+		mustPOSTTransfer(t, approvals, source.Id, newAccount(t, "Approved Fan Out Target 2", 0).Id, 10)
+		mustPOSTTransfer(t, approvals, source.Id, newAccount(t, "Approved Fan Out Target 3", 0).Id, 10)
+
+		rec := reqPOSTTransferDecision(t, approvals, pending.Id, "approve", checker)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		if p := requireProblem(t, rec, problem.CodeTransferDeclined); p.Detail != "transfer declined by the risk checks" {
+			t.Fatalf("unexpected detail %q", p.Detail)
+		}
+
+		// the decline is committed, releasing the held amount without moving it
+		requireBalances(t, approvals, source.Id, 980, 0)
+		declined := mustGETAccountTransfers(t, approvals, source.Id)[0]
+		requireTransfer(t, declined, api.TransferStatusDeclined, api.Deny, "fan-out")
+		if declined.DecidedBy == nil {
+			t.Fatalf("expected the checker to be recorded, got %+v", declined)
+		}
+		requireStatus(t, http.StatusConflict, reqPOSTTransferDecision(t, approvals, pending.Id, "approve", checker))
+	})
+
+	t.Run(`should reload the rules when the file changes`, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go engine.Run(ctx, 10*time.Millisecond)
+
+		source := newAccount(t, "Cooling Off Source", 100)
+		rules := "rules:\n  - name: cooling-off\n    type: new_account\n    min_age: 1h\n    decision: deny\n"
+		if err := os.WriteFile(rulesPath, []byte(rules), 0o600); err != nil {
+			t.Fatalf("failed to write risk rules: %v", err)
+		}
+		// make sure the modification time changes on file systems with a coarse resolution
+		future := time.Now().Add(time.Minute)
+		if err := os.Chtimes(rulesPath, future, future); err != nil {
+			t.Fatalf("failed to touch risk rules: %v", err)
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			rec := reqPOSTTransfer(t, handler, source.Id, target.Id, 1)
+			if rec.Code == http.StatusUnprocessableEntity {
+				break
+			}
+			requireStatus(t, http.StatusOK, rec)
+			if time.Now().After(deadline) {
+				t.Fatalf("the rules weren't reloaded")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// invalid rules are ignored, the previous ones stay in place
+		if err := os.WriteFile(rulesPath, []byte("rules:\n  - name: broken\n    type: nope\n"), 0o600); err != nil {
+			t.Fatalf("failed to write risk rules: %v", err)
+		}
+		if err := engine.Reload(); err == nil {
+			t.Fatalf("expected invalid rules to be rejected")
+		}
+		requireStatus(t, http.StatusUnprocessableEntity, reqPOSTTransfer(t, handler, source.Id, target.Id, 1))
+	})
+}
+
+func mustGETAccountTransfers(t *testing.T, handler http.Handler, accountId int64) []api.Transfer {
+	t.Helper()
+	rec := reqWithAPIKey(t, handler, http.MethodGet, fmt.Sprintf("/api/accounts/%d/transfers", accountId), nil, testAPIKey)
+	requireStatus(t, http.StatusOK, rec)
+
+	var transfers []api.Transfer
+	if err := json.NewDecoder(rec.Body).Decode(&transfers); err != nil {
+		t.Fatalf("failed to decode transfers response: %v", err)
+	}
+	return transfers
+}
+
+func requireTransfer(t *testing.T, transfer api.Transfer, status api.TransferStatus, decision api.RiskDecision, rules ...string) {
+	t.Helper()
+	if transfer.Status != status || transfer.RiskDecision != decision || len(transfer.RiskEvaluations) != len(rules) {
+		t.Fatalf("expected a %s transfer with decision %s by %v, got %+v", status, decision, rules, transfer)
+	}
+	for i, rule := range rules {
+		if transfer.RiskEvaluations[i].Rule != rule {
+			t.Fatalf("expected rule %s to match in position %d, got %+v", rule, i, transfer.RiskEvaluations)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/sanctions"
+)
+
+const testSanctionsList = `uid,name,type,programs,aliases
+9001,"PETROV, Ivan",individual,TEST,Иван Петров
+`
+
+func TestSanctionsScreening(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), "sanctions.csv")
+	if err := os.WriteFile(listPath, []byte(testSanctionsList), 0o600); err != nil {
+		t.Fatalf("failed to write sanctions list: %v", err)
+	}
+	screener, err := sanctions.NewScreener(listPath, sanctions.Thresholds{Review: 0.9, Block: 0.99})
+	if err != nil {
+		t.Fatalf("failed to load sanctions list: %v", err)
+	}
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{Screener: screener})
+	compliance := mustCreateRoleAPIKey(t, "compliance", "accounts:read", "accounts:write")
+
+	suffix := time.Now().UnixNano()
+
+	t.Run(`should freeze the accounts matching the list until their hit is cleared`, func(t *testing.T) {
+		// the name is transliterated before being compared to the list
+		mustPOSTAccount(t, handler, "ИВАН ПЕТРОФФ")
+		account := requireNewestAccount(t, handler, "ИВАН ПЕТРОФФ")
+		if account.Status != api.Frozen {
+			t.Fatalf("expected the account to be frozen, got %s", account.Status)
+		}
+
+		hit := requireScreeningHit(t, handler, compliance, api.ScreeningHitStatusPending, account.Id)
+		if hit.EntryUid != "9001" || hit.Operation != "createAccount" || hit.Score >= 0.99 {
+			t.Fatalf("unexpected hit %+v", hit)
+		}
+
+		rec := reqPOSTScreeningDecision(t, handler, hit.Id, "clear", compliance)
+		requireStatus(t, http.StatusOK, rec)
+		if account, _ := mustGETAccount(t, handler, account.Id); account.Status != api.Active {
+			t.Fatalf("expected the cleared account to be active, got %s", account.Status)
+		}
+	})
+
+	t.Run(`should refuse the names closely matching the list`, func(t *testing.T) {
+		rec := reqPOSTAccount(t, handler, map[string]any{"name": "Ivan Petrov"})
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "blocked by the sanctions screening", rec)
+
+		hits := mustGETScreeningHits(t, handler, compliance, api.ScreeningHitStatusBlocked)
+		blocked := hits[len(hits)-1]
+		if blocked.ScreenedName != "Ivan Petrov" || blocked.SubjectId != nil {
+			t.Fatalf("expected the refused creation to be recorded, got %+v", blocked)
+		}
+	})
+
+	t.Run(`should freeze the accounts renamed to a matching name`, func(t *testing.T) {
+		name := fmt.Sprintf("Renamed Holder - %d", suffix)
+		mustPOSTAccount(t, handler, name)
+		account := requireAccountExists(t, handler, name)
+
+		renamed := mustPATCHAccount(t, handler, account.Id, map[string]any{"name": "Ivan Petroff"})
+		if renamed.Status != api.Frozen {
+			t.Fatalf("expected the renamed account to be frozen, got %s", renamed.Status)
+		}
+
+		hit := requireScreeningHit(t, handler, compliance, api.ScreeningHitStatusPending, account.Id)
+		rec := reqPOSTScreeningDecision(t, handler, hit.Id, "confirm", compliance)
+		requireStatus(t, http.StatusOK, rec)
+		if account, _ := mustGETAccount(t, handler, account.Id); account.Status != api.Frozen {
+			t.Fatalf("expected the confirmed account to stay frozen, got %s", account.Status)
+		}
+
+		rec = reqPOSTScreeningDecision(t, handler, hit.Id, "clear", compliance)
+		requireStatus(t, http.StatusConflict, rec)
+		requireErrorMessage(t, "screening hit is confirmed, not pending", rec)
+	})
+
+	t.Run(`should hold the transfers matching the list until their hit is decided`, func(t *testing.T) {
+		mustPOSTAccount(t, handler, "Boris Ivanoff")
+		target := requireNewestAccount(t, handler, "Boris Ivanoff")
+		sourceName := fmt.Sprintf("Screened Source - %d", suffix)
+		mustPOSTAccount(t, handler, sourceName)
+		source := requireAccountExists(t, handler, sourceName)
+		mustPOSTAddBalance(t, handler, source.Id, 1000)
+
+		// the target only matches the list once it is updated
+		updated := testSanctionsList + "9002,\"IVANOV, Boris\",individual,TEST,\n"
+		if err := os.WriteFile(listPath, []byte(updated), 0o600); err != nil {
+			t.Fatalf("failed to write sanctions list: %v", err)
+		}
+		if err := screener.Reload(); err != nil {
+			t.Fatalf("failed to reload sanctions list: %v", err)
+		}
+
+		rec := reqPOSTTransfer(t, handler, source.Id, target.Id, 100)
+		requireStatus(t, http.StatusAccepted, rec)
+		held := decodeTransfer(t, rec)
+		if held.Status != api.TransferStatusPendingReview {
+			t.Fatalf("expected the transfer to be pending review, got %+v", held)
+		}
+		requireBalances(t, handler, source.Id, 1000, 100)
+
+		hit := requireScreeningHit(t, handler, compliance, api.ScreeningHitStatusPending, held.Id)
+		if hit.SubjectType != api.ScreeningHitSubjectTypeTransfer || hit.EntryUid != "9002" {
+			t.Fatalf("unexpected hit %+v", hit)
+		}
+		rec = reqPOSTScreeningDecision(t, handler, hit.Id, "clear", compliance)
+		requireStatus(t, http.StatusOK, rec)
+		requireBalances(t, handler, source.Id, 900, 0)
+		requireBalances(t, handler, target.Id, 100, 0)
+
+		rec = reqPOSTTransfer(t, handler, source.Id, target.Id, 200)
+		requireStatus(t, http.StatusAccepted, rec)
+		held = decodeTransfer(t, rec)
+		hit = requireScreeningHit(t, handler, compliance, api.ScreeningHitStatusPending, held.Id)
+		rec = reqPOSTScreeningDecision(t, handler, hit.Id, "confirm", compliance)
+		requireStatus(t, http.StatusOK, rec)
+		requireBalances(t, handler, source.Id, 900, 0)
+
+		transfers := mustGETAccountTransfers(t, handler, source.Id)
+		if transfers[0].Status != api.TransferStatusCompleted || transfers[1].Status != api.TransferStatusBlocked {
+			t.Fatalf("expected a completed and a blocked transfer, got %+v", transfers)
+		}
+	})
+
+	t.Run(`should keep the hits from the other roles`, func(t *testing.T) {
+		operator := mustCreateRoleAPIKey(t, "operator", "accounts:read")
+		rec := reqWithAPIKey(t, handler, http.MethodGet, "/api/screening-hits", nil, operator)
+		requireStatus(t, http.StatusForbidden, rec)
+	})
+}
+
+func TestParseSanctionsList(t *testing.T) {
+	entries, err := sanctions.ParseXML(strings.NewReader(`<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="http://tempuri.org/sdnList.xsd">
+  <sdnEntry>
+    <uid>9001</uid>
+    <lastName>PETROV</lastName>
+    <firstName>Ivan</firstName>
+    <sdnType>Individual</sdnType>
+    <programList><program>TEST</program></programList>
+    <akaList>
+      <aka><uid>1</uid><type>a.k.a.</type><lastName>Петров</lastName><firstName>Иван</firstName></aka>
+    </akaList>
+  </sdnEntry>
+</sdnList>`))
+	if err != nil {
+		t.Fatalf("failed to parse sanctions list: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Ivan PETROV" || !slices.Equal(entries[0].Aliases, []string{"Иван Петров"}) ||
+		!slices.Equal(entries[0].Programs, []string{"TEST"}) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	if _, err := sanctions.ParseCSV(strings.NewReader("uid,program\n1,TEST\n")); err == nil {
+		t.Fatalf("expected lists without a name column to be refused")
+	}
+
+	if sanctions.Normalize("Jöhn  O'Brien-Smith") != "john o brien smith" {
+		t.Fatalf("unexpected normalized name %q", sanctions.Normalize("Jöhn  O'Brien-Smith"))
+	}
+	if score := sanctions.Similarity("petrov ivan", "ivan petrov"); score != 1 {
+		t.Fatalf("expected the order of the words not to matter, got %f", score)
+	}
+}
+
+// requireNewestAccount returns the latest account with the name, for the names that can't be made unique.
+func requireNewestAccount(t *testing.T, handler http.Handler, name string) api.Account {
+	t.Helper()
+	var newest *api.Account
+	for _, account := range mustGETAccounts(t, handler) {
+		if account.Name == name && (newest == nil || account.Id > newest.Id) {
+			newest = &account
+		}
+	}
+	if newest == nil {
+		t.Fatalf("account with name %q not found", name)
+	}
+	return *newest
+}
+
+func mustGETScreeningHits(t *testing.T, handler http.Handler, apiKey string, status api.ScreeningHitStatus) []api.ScreeningHit {
+	t.Helper()
+	rec := reqWithAPIKey(t, handler, http.MethodGet, "/api/screening-hits?status="+string(status), nil, apiKey)
+	requireStatus(t, http.StatusOK, rec)
+	var hits []api.ScreeningHit
+	if err := json.NewDecoder(rec.Body).Decode(&hits); err != nil {
+		t.Fatalf("failed to decode screening hits response: %v", err)
+	}
+	return hits
+}
+
+func requireScreeningHit(t *testing.T, handler http.Handler, apiKey string, status api.ScreeningHitStatus, subjectId int64) api.ScreeningHit {
+	t.Helper()
+	for _, hit := range mustGETScreeningHits(t, handler, apiKey, status) {
+		if hit.SubjectId != nil && *hit.SubjectId == subjectId {
+			return hit
+		}
+	}
+	t.Fatalf("no %s screening hit for %d", status, subjectId)
+	return api.ScreeningHit{}
+}
+
+func reqPOSTScreeningDecision(t *testing.T, handler http.Handler, hitId int64, decision, apiKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	return reqWithAPIKey(t, handler, http.MethodPost, fmt.Sprintf("/api/screening-hits/%d/%s", hitId, decision), nil, apiKey)
+}
@@ -0,0 +1,313 @@
+package integrationtests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/auth"
+	"tiny-bank-api/pkg/logging"
+	"tiny-bank-api/pkg/notify"
+	"tiny-bank-api/pkg/outbox"
+	"tiny-bank-api/store"
+	"tiny-bank-api/store/entities"
+)
+
+// sseFrame is an event, or a comment when event is empty, read from a Server-Sent Events stream.
+type sseFrame struct {
+	id      int64
+	event   string
+	data    string
+	comment string
+}
+
+func TestEventStreams(t *testing.T) {
+	done := make(chan struct{})
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{
+		StreamPollInterval: 10 * time.Millisecond,
+		StreamHeartbeat:    50 * time.Millisecond,
+		StreamsDone:        done,
+	})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	suffix := time.Now().UnixNano()
+	newAccount := func(t *testing.T, name string) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, handler, name)
+		return requireAccountExists(t, handler, name)
+	}
+	source := newAccount(t, "Stream Source")
+	target := newAccount(t, "Stream Target")
+	other := newAccount(t, "Stream Other")
+
+	var lastSeen int64
+	t.Run(`should stream the new events of the account and heartbeats`, func(t *testing.T) {
+		sourceFrames := openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", source.Id), testAPIKey, "")
+		targetFrames := openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", target.Id), testAPIKey, "")
+
+		mustPOSTAddBalance(t, handler, other.Id, 5)
+		mustPOSTAddBalance(t, handler, source.Id, 100)
+		mustPOSTTransfer(t, handler, source.Id, target.Id, 30)
+
+		balanceAdded := requireNextEvent(t, sourceFrames)
+		if balanceAdded.event != "BalanceAdded" {
+			t.Fatalf("expected the creation of the account to be skipped, got %+v", balanceAdded)
+		}
+		var message outbox.Message
+		if err := json.Unmarshal([]byte(balanceAdded.data), &message); err != nil {
+			t.Fatalf("failed to decode event data: %v", err)
+		}
+		if message.Id != balanceAdded.id || message.AccountId != source.Id {
+			t.Fatalf("unexpected message %+v", message)
+		}
+		if transfer := requireNextEvent(t, sourceFrames); transfer.event != "TransferCompleted" {
+			t.Fatalf("expected the transfer, got %+v", transfer)
+		}
+
+		received := requireNextEvent(t, targetFrames)
+		if received.event != "TransferCompleted" {
+			t.Fatalf("expected the target to receive the transfer, got %+v", received)
+		}
+		lastSeen = balanceAdded.id
+
+		// only heartbeats follow while the accounts are idle
+		for range 2 {
+			select {
+			case frame, ok := <-sourceFrames:
+				if !ok || frame.comment != "heartbeat" {
+					t.Fatalf("expected a heartbeat, got %+v", frame)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("expected heartbeats")
+			}
+		}
+	})
+
+	t.Run(`should resume after the Last-Event-ID`, func(t *testing.T) {
+		frames := openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", source.Id), testAPIKey, strconv.FormatInt(lastSeen, 10))
+		if transfer := requireNextEvent(t, frames); transfer.event != "TransferCompleted" {
+			t.Fatalf("expected the transfer after the last seen event, got %+v", transfer)
+		}
+
+		frames = openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", source.Id), testAPIKey, "0")
+		if created := requireNextEvent(t, frames); created.event != "AccountCreated" {
+			t.Fatalf("expected the stream to be replayed from the start, got %+v", created)
+		}
+	})
+
+	t.Run(`should stream every account to back-office users only`, func(t *testing.T) {
+		frames := openEventStream(t, server, "/api/events", testAPIKey, "")
+		mustPOSTAddBalance(t, handler, other.Id, 1)
+		if event := requireNextEvent(t, frames); event.event != "BalanceAdded" {
+			t.Fatalf("expected the deposit, got %+v", event)
+		}
+
+		customer := mustPOSTCustomer(t, handler, "Streamer", nil)
+		customerKey := mustCreateCustomerAPIKey(t, customer.Id, "accounts:read")
+		requireStatus(t, http.StatusForbidden, reqWithAPIKey(t, handler, http.MethodGet, "/api/events", nil, customerKey))
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, handler, http.MethodGet, fmt.Sprintf("/api/accounts/%d/events", source.Id), nil, customerKey))
+	})
+
+	t.Run(`should end the streams when the server shuts down`, func(t *testing.T) {
+		frames := openEventStream(t, server, "/api/events", testAPIKey, "")
+		close(done)
+		deadline := time.After(time.Second)
+		for {
+			select {
+			case _, ok := <-frames:
+				if !ok {
+					return
+				}
+			case <-deadline:
+				t.Fatalf("expected the stream to end")
+			}
+		}
+	})
+}
+
+func TestEventStreamNotifications(t *testing.T) {
+	hub := notify.NewHub()
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{
+		// only the notifications wake the streams up
+		StreamPollInterval: time.Hour,
+		EventNotifications: hub,
+	})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	suffix := time.Now().UnixNano()
+	name := fmt.Sprintf("Notified Account - %d", suffix)
+	mustPOSTAccount(t, handler, name)
+	account := requireAccountExists(t, handler, name)
+	frames := openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", account.Id), testAPIKey, "")
+
+	mustPOSTAddBalance(t, handler, account.Id, 10)
+	// the notifications about other accounts don't wake the stream up
+	hub.Publish(notify.Notification{EventId: 1, AccountId: account.Id + 1})
+	select {
+	case frame := <-frames:
+		t.Fatalf("expected no event before the notification, got %+v", frame)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	hub.Publish(notify.Notification{EventId: 1, AccountId: account.Id})
+	if event := requireNextEvent(t, frames); event.event != "BalanceAdded" {
+		t.Fatalf("expected the deposit, got %+v", event)
+	}
+}
+
+func TestPostgresEventNotifications(t *testing.T) {
+	if getEnvOrDefault("TEST_STORE", "memory") != "postgres" {
+		t.Skip("LISTEN/NOTIFY needs TEST_STORE=postgres")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	hub := notify.NewHub()
+	go notify.ListenPostgres(ctx, logging.DevLogger(), testPostgresURL(), notify.Channel, hub)
+
+	handler := newTestService(logging.DevLogger(), testStore, testJWTVerifier, api.Options{
+		StreamPollInterval: time.Hour,
+		EventNotifications: hub,
+	})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	name := fmt.Sprintf("Listened Account - %d", time.Now().UnixNano())
+	mustPOSTAccount(t, handler, name)
+	account := requireAccountExists(t, handler, name)
+
+	// deposits are made until the listener is ready and notifies them
+	sub := hub.Subscribe()
+	defer sub.Close()
+	deadline := time.After(5 * time.Second)
+	for ready := false; !ready; {
+		mustPOSTAddBalance(t, handler, account.Id, 1)
+		select {
+		case <-sub.C:
+			ready = true
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("no notification received")
+		}
+	}
+
+	t.Run(`should stream the events notified by postgres`, func(t *testing.T) {
+		frames := openEventStream(t, server, fmt.Sprintf("/api/accounts/%d/events", account.Id), testAPIKey, "")
+		mustPOSTAddBalance(t, handler, account.Id, 1)
+		if event := requireNextEvent(t, frames); event.event != "BalanceAdded" {
+			t.Fatalf("expected the deposit, got %+v", event)
+		}
+	})
+
+	t.Run(`should stream the events committed after newer ones`, func(t *testing.T) {
+		frames := openEventStream(t, server, "/api/events", testAPIKey, "")
+
+		added, commit := make(chan int64), make(chan struct{})
+		go func() {
+			_ = testStore.RunInTx(context.Background(), func(tx store.Tx) error {
+				event, err := entities.NewOutboxEvent(entities.OutboxEventAccountFrozen, account.Id, map[string]any{})
+				if err != nil {
+					return err
+				}
+				event, err = tx.AddOutboxEvent(context.Background(), event)
+				if err != nil {
+					return err
+				}
+				added <- event.Id
+				<-commit
+				return nil
+			})
+		}()
+		lateId := <-added
+
+		mustPOSTAddBalance(t, handler, account.Id, 1)
+		if event := requireNextEvent(t, frames); event.event != "BalanceAdded" || event.id < lateId {
+			t.Fatalf("expected the newer deposit first, got %+v", event)
+		}
+		close(commit)
+		if event := requireNextEvent(t, frames); event.id != lateId {
+			t.Fatalf("expected the late event %d, got %+v", lateId, event)
+		}
+	})
+}
+
+// openEventStream connects to an event stream, which is disconnected at the end of the test. The returned
+// channel is closed when the stream ends.
+func openEventStream(t *testing.T, server *httptest.Server, path, apiKey, lastEventId string) <-chan sseFrame {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set(auth.APIKeyHeader, apiKey)
+	if lastEventId != "" {
+		req.Header.Set("Last-Event-ID", lastEventId)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to open event stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected an event stream, got %d: %s", resp.StatusCode, body)
+	}
+
+	frames := make(chan sseFrame, 100)
+	go func() {
+		defer close(frames)
+		defer resp.Body.Close()
+		var frame sseFrame
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "":
+				if value == "" {
+					frames <- frame
+					frame = sseFrame{}
+				} else {
+					frame.comment = value
+				}
+			case "id":
+				frame.id, _ = strconv.ParseInt(value, 10, 64)
+			case "event":
+				frame.event = value
+			case "data":
+				frame.data = value
+			}
+		}
+	}()
+	return frames
+}
+
+// requireNextEvent skips the heartbeats until the next event of the stream.
+func requireNextEvent(t *testing.T, frames <-chan sseFrame) sseFrame {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				t.Fatalf("stream ended before the next event")
+			}
+			if frame.event != "" {
+				return frame
+			}
+		case <-deadline:
+			t.Fatalf("no event received")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package integrationtests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/store/entities"
+)
+
+func TestTransferLimits(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	tier := fmt.Sprintf("test-%d", suffix)
+	newAccount := func(t *testing.T, name string, balance float64) api.Account {
+		t.Helper()
+		name = fmt.Sprintf("%s - %d", name, suffix)
+		mustPOSTAccount(t, testHandler, name)
+		account := requireAccountExists(t, testHandler, name)
+		if balance > 0 {
+			mustPOSTAddBalance(t, testHandler, account.Id, balance)
+		}
+		return account
+	}
+	target := newAccount(t, "Transfer Limits Target", 0)
+
+	rec := reqWithAPIKey(t, testHandler, http.MethodPut, "/api/transfer-limits/tiers/"+tier,
+		map[string]any{"max_amount": 100, "daily_amount": 150, "daily_count": 3}, testAPIKey)
+	requireStatus(t, http.StatusOK, rec)
+
+	t.Run(`should reject transfers over the limits of the tier`, func(t *testing.T) {
+		source := newAccount(t, "Tier Limited Source", 1000)
+		mustPUTAccountTransferLimits(t, source.Id, tier, map[string]any{})
+
+		rec := reqPOSTTransfer(t, testHandler, source.Id, target.Id, 150)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "amount exceeds the limit of 100.00 per transfer", rec)
+
+		mustPOSTTransfer(t, testHandler, source.Id, target.Id, 100)
+		rec = reqPOSTTransfer(t, testHandler, source.Id, target.Id, 60)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "amount exceeds the daily limit of 150.00, 100.00 was already transferred", rec)
+
+		mustPOSTTransfer(t, testHandler, source.Id, target.Id, 50)
+		account, _ := mustGETAccount(t, testHandler, source.Id)
+		if account.Balance != 850 || account.Tier != tier {
+			t.Fatalf("expected balance 850 in tier %s, got %.2f in tier %s", tier, account.Balance, account.Tier)
+		}
+	})
+
+	t.Run(`should let account overrides replace the limits of the tier`, func(t *testing.T) {
+		source := newAccount(t, "Overridden Source", 1000)
+		limits := mustPUTAccountTransferLimits(t, source.Id, tier, map[string]any{"daily_amount": 1000})
+		if *limits.Effective.DailyAmount != 1000 || *limits.Effective.MaxAmount != 100 || *limits.Effective.DailyCount != 3 {
+			t.Fatalf("unexpected effective limits %+v", limits.Effective)
+		}
+
+		for range 3 {
+			mustPOSTTransfer(t, testHandler, source.Id, target.Id, 100)
+		}
+		rec := reqPOSTTransfer(t, testHandler, source.Id, target.Id, 1)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "daily limit of 3 transfers reached", rec)
+	})
+
+	t.Run(`should count the transfers over rolling windows`, func(t *testing.T) {
+		source := newAccount(t, "Weekly Limited Source", 1000)
+		mustPUTAccountTransferLimits(t, source.Id, entities.DefaultAccountTier, map[string]any{"weekly_amount": 500})
+
+		// a transfer 3 days ago counts towards the weekly limit, one 10 days ago doesn't
+		for _, transfer := range []struct {
+			amount float64
+			age    time.Duration
+		}{{450, 3 * 24 * time.Hour}, {1000, 10 * 24 * time.Hour}} {
+			_, err := testStore.CreateTransfer(context.Background(), entities.Transfer{
+				SourceAccountId: source.Id,
+				TargetAccountId: target.Id,
+				Amount:          transfer.amount,
+				Status:          entities.TransferStatusCompleted,
+				CreatedAt:       time.Now().Add(-transfer.age),
+			})
+			if err != nil {
+				t.Fatalf("failed to create transfer: %v", err)
+			}
+		}
+
+		mustPOSTTransfer(t, testHandler, source.Id, target.Id, 40)
+		rec := reqPOSTTransfer(t, testHandler, source.Id, target.Id, 20)
+		requireStatus(t, http.StatusUnprocessableEntity, rec)
+		requireErrorMessage(t, "amount exceeds the weekly limit of 500.00, 490.00 was already transferred", rec)
+	})
+
+	t.Run(`should only let admins change the limits`, func(t *testing.T) {
+		customer := mustPOSTCustomer(t, testHandler, fmt.Sprintf("Limited Customer - %d", suffix), nil)
+		requireStatus(t, http.StatusCreated, reqPOSTAccount(t, testHandler, map[string]any{
+			"name":     fmt.Sprintf("Customer Limited Account - %d", suffix),
+			"owner_id": customer.Id,
+		}))
+		account := requireAccountExists(t, testHandler, fmt.Sprintf("Customer Limited Account - %d", suffix))
+		secret := mustCreateCustomerAPIKey(t, customer.Id, "accounts:read", "accounts:write")
+		limitsPath := fmt.Sprintf("/api/accounts/%d/transfer-limits", account.Id)
+
+		requireStatus(t, http.StatusOK, reqWithAPIKey(t, testHandler, http.MethodGet, limitsPath, nil, secret))
+		rec := reqWithAPIKey(t, testHandler, http.MethodPut, limitsPath, map[string]any{"tier": "vip", "overrides": map[string]any{}}, secret)
+		requireStatus(t, http.StatusForbidden, rec)
+		requireErrorMessage(t, "your roles don't allow setAccountTransferLimits", rec)
+	})
+
+	t.Run(`should reject a stale If-Match`, func(t *testing.T) {
+		source := newAccount(t, "Concurrently Limited Source", 0)
+		_, etag := mustGETAccount(t, testHandler, source.Id)
+
+		requireStatus(t, http.StatusOK, reqPUTAccountTransferLimits(t, source.Id, tier, map[string]any{}, etag))
+		rec := reqPUTAccountTransferLimits(t, source.Id, entities.DefaultAccountTier, map[string]any{"max_amount": 10}, etag)
+		requireStatus(t, http.StatusPreconditionFailed, rec)
+		requireProblem(t, rec, problem.CodePreconditionFailed)
+
+		account, _ := mustGETAccount(t, testHandler, source.Id)
+		if account.Tier != tier {
+			t.Fatalf("expected the stale update to be ignored, got tier %s", account.Tier)
+		}
+	})
+
+	t.Run(`should validate the limits`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodPut, "/api/transfer-limits/tiers/Not%20A%20Tier", map[string]any{}, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireErrorMessage(t, `invalid request: tier: string doesn't match the regular expression "^[a-z0-9_-]{1,64}$"`, rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodPut, "/api/transfer-limits/tiers/"+tier, map[string]any{"daily_amount": -5}, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireErrorMessage(t, "invalid request: daily_amount: number must be at least 0.01", rec)
+	})
+
+	t.Run(`should list the tiers`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/transfer-limits/tiers", nil, testAPIKey)
+		requireStatus(t, http.StatusOK, rec)
+		var tiers []api.TierTransferLimits
+		if err := json.NewDecoder(rec.Body).Decode(&tiers); err != nil {
+			t.Fatalf("failed to decode tiers response: %v", err)
+		}
+		for _, listed := range tiers {
+			if listed.Tier == tier {
+				if *listed.Limits.MaxAmount != 100 || listed.Limits.WeeklyAmount != nil {
+					t.Fatalf("unexpected limits %+v", listed.Limits)
+				}
+				return
+			}
+		}
+		t.Fatalf("tier %s not listed in %+v", tier, tiers)
+	})
+}
+
+func reqPUTAccountTransferLimits(t *testing.T, accountId int64, tier string, overrides map[string]any, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]any{"tier": tier, "overrides": overrides})
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/accounts/%d/transfer-limits", accountId), bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return serve(testHandler, req)
+}
+
+func mustPUTAccountTransferLimits(t *testing.T, accountId int64, tier string, overrides map[string]any) api.AccountTransferLimits {
+	t.Helper()
+	rec := reqPUTAccountTransferLimits(t, accountId, tier, overrides, "")
+	requireStatus(t, http.StatusOK, rec)
+
+	var limits api.AccountTransferLimits
+	if err := json.NewDecoder(rec.Body).Decode(&limits); err != nil {
+		t.Fatalf("failed to decode limits response: %v", err)
+	}
+	return limits
+}
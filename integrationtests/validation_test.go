@@ -0,0 +1,63 @@
+package integrationtests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/problem"
+)
+
+func TestValidation(t *testing.T) {
+	t.Run(`should list each violated field`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts/1/transfer", map[string]any{"amount": 0}, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireFieldErrors(t, rec, "amount", "targetAccountId")
+	})
+
+	t.Run(`should validate the length of the strings`, func(t *testing.T) {
+		rec := reqPOSTAccount(t, testHandler, map[string]any{"name": ""})
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireFieldErrors(t, rec, "name")
+	})
+
+	t.Run(`should validate the path parameters`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/accounts/not-a-number", nil, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireFieldErrors(t, rec, "accountId")
+	})
+
+	t.Run(`should authenticate before validating`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts/1/transfer", map[string]any{"amount": 0}, "")
+		requireStatus(t, http.StatusUnauthorized, rec)
+	})
+
+	t.Run(`should reject the responses not matching the spec`, func(t *testing.T) {
+		validate, err := api.Validate(api.ValidationOptions{ValidateResponses: true})
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+		drifting := validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id": "not-a-number"}]`))
+		}))
+
+		rec := httptest.NewRecorder()
+		drifting.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/accounts", nil))
+		requireStatus(t, http.StatusInternalServerError, rec)
+	})
+}
+
+// requireFieldErrors checks the validation errors of the response are about the given fields, in order.
+func requireFieldErrors(t *testing.T, rec *httptest.ResponseRecorder, fields ...string) {
+	t.Helper()
+	p := requireProblem(t, rec, problem.CodeInvalidRequest)
+	if len(p.Errors) != len(fields) {
+		t.Fatalf("expected errors about %q, got %+v", fields, p)
+	}
+	for i, fieldError := range p.Errors {
+		if fieldError.Field != fields[i] || fieldError.Message == "" {
+			t.Fatalf("expected errors about %q, got %+v", fields, p.Errors)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package integrationtests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"tiny-bank-api/api"
+	"tiny-bank-api/pkg/outbox"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/pkg/webhook"
+)
+
+// testReceiver records the webhook deliveries it receives, answering them with status.
+type testReceiver struct {
+	*httptest.Server
+	mu         sync.Mutex
+	status     int
+	deliveries []receivedDelivery
+}
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestReceiver(t *testing.T) *testReceiver {
+	r := &testReceiver{status: http.StatusOK}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.deliveries = append(r.deliveries, receivedDelivery{header: req.Header.Clone(), body: body})
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *testReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *testReceiver) received() []receivedDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedDelivery(nil), r.deliveries...)
+}
+
+func TestWebhooks(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	// the receivers of the tests listen on the loopback interface
+	sender := webhook.NewSender(5*time.Second, true)
+	policy := webhook.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+
+	alice := mustPOSTCustomer(t, testHandler, "Alice", nil)
+	bob := mustPOSTCustomer(t, testHandler, "Bob", nil)
+	aliceKey := mustCreateCustomerAPIKey(t, alice.Id, "accounts:read", "accounts:write", "transfers:create")
+	bobKey := mustCreateCustomerAPIKey(t, bob.Id, "accounts:read", "accounts:write")
+
+	aliceAccountName := fmt.Sprintf("Alice Webhooks - %d", suffix)
+	rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": aliceAccountName}, aliceKey)
+	requireStatus(t, http.StatusCreated, rec)
+	aliceAccount := requireAccountExists(t, testHandler, aliceAccountName)
+	bobAccountName := fmt.Sprintf("Bob Webhooks - %d", suffix)
+	rec = reqWithAPIKey(t, testHandler, http.MethodPost, "/api/accounts", map[string]any{"name": bobAccountName}, bobKey)
+	requireStatus(t, http.StatusCreated, rec)
+	bobAccount := requireAccountExists(t, testHandler, bobAccountName)
+
+	t.Run(`should deliver signed events to the webhooks of the receiving account`, func(t *testing.T) {
+		receiver := newTestReceiver(t)
+		hook := mustPOSTWebhook(t, bobKey, map[string]any{"url": receiver.URL, "event_types": []string{"TransferCompleted"}})
+		if hook.Secret == nil || hook.OwnerId == nil || *hook.OwnerId != bob.Id {
+			t.Fatalf("expected a generated secret and bob as owner, got %+v", hook)
+		}
+
+		mustPOSTAddBalance(t, testHandler, aliceAccount.Id, 100)
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, fmt.Sprintf("/api/accounts/%d/transfer", aliceAccount.Id),
+			map[string]any{"amount": 40, "targetAccountId": bobAccount.Id}, aliceKey)
+		requireStatus(t, http.StatusOK, rec)
+		mustDispatchWebhooks(t, sender, policy, time.Now())
+
+		received := receiver.received()
+		if len(received) != 1 {
+			t.Fatalf("expected 1 delivery, got %d", len(received))
+		}
+		if err := webhook.Verify(*hook.Secret, received[0].header, received[0].body, time.Minute, time.Now()); err != nil {
+			t.Fatalf("expected a valid signature: %v", err)
+		}
+		var message outbox.Message
+		if err := json.Unmarshal(received[0].body, &message); err != nil {
+			t.Fatalf("failed to decode delivery: %v", err)
+		}
+		if message.Type != "TransferCompleted" || message.AccountId != aliceAccount.Id {
+			t.Fatalf("unexpected delivery %+v", message)
+		}
+		var transfer map[string]any
+		if err := json.Unmarshal(message.Data, &transfer); err != nil {
+			t.Fatalf("failed to decode transfer: %v", err)
+		}
+		if transfer["target_account_id"] != float64(bobAccount.Id) || transfer["amount"] != float64(40) {
+			t.Fatalf("unexpected transfer %v", transfer)
+		}
+		if _, ok := transfer["risk_evaluations"]; ok {
+			t.Fatalf("expected the risk evaluations to be left out, got %v", transfer)
+		}
+
+		deliveries := mustGETWebhookDeliveries(t, bobKey, hook.Id, "")
+		if len(deliveries) != 1 || deliveries[0].Status != api.Delivered || deliveries[0].Attempts != 1 ||
+			deliveries[0].LastResponseStatus == nil || *deliveries[0].LastResponseStatus != http.StatusOK {
+			t.Fatalf("unexpected deliveries %+v", deliveries)
+		}
+		if received[0].header.Get(webhook.IdHeader) != fmt.Sprint(deliveries[0].Id) {
+			t.Fatalf("expected the delivery id in the %s header", webhook.IdHeader)
+		}
+
+		// the secret is only returned on creation
+		for _, listed := range mustGETWebhooks(t, bobKey) {
+			if listed.Secret != nil {
+				t.Fatalf("expected the secret to be left out of the list, got %+v", listed)
+			}
+		}
+	})
+
+	t.Run(`should retry failed deliveries until they are dead, and redeliver them on request`, func(t *testing.T) {
+		receiver := newTestReceiver(t)
+		receiver.setStatus(http.StatusInternalServerError)
+		hook := mustPOSTWebhook(t, testAPIKey, map[string]any{
+			"url":         receiver.URL,
+			"event_types": []string{"BalanceAdded"},
+			"account_ids": []int64{bobAccount.Id},
+			"secret":      "0123456789abcdef",
+		})
+		t.Cleanup(func() {
+			requireStatus(t, http.StatusNoContent, reqWithAPIKey(t, testHandler, http.MethodDelete, fmt.Sprintf("/api/webhooks/%d", hook.Id), nil, testAPIKey))
+		})
+
+		mustPOSTAddBalance(t, testHandler, bobAccount.Id, 10)
+		now := time.Now()
+		mustDispatchWebhooks(t, sender, policy, now)
+		delivery := mustGETWebhookDeliveries(t, testAPIKey, hook.Id, "")[0]
+		if delivery.Status != api.Pending || delivery.Attempts != 1 || *delivery.LastResponseStatus != http.StatusInternalServerError ||
+			delivery.LastError == nil || delivery.NextAttemptAt.Sub(now) < time.Minute-time.Second {
+			t.Fatalf("expected the delivery to be retried in a minute, got %+v", delivery)
+		}
+
+		// nothing is attempted before the backoff is over
+		mustDispatchWebhooks(t, sender, policy, now.Add(30*time.Second))
+		if attempts := len(receiver.received()); attempts != 1 {
+			t.Fatalf("expected 1 attempt, got %d", attempts)
+		}
+
+		mustDispatchWebhooks(t, sender, policy, now.Add(2*time.Minute))
+		mustDispatchWebhooks(t, sender, policy, now.Add(10*time.Minute))
+		delivery = mustGETWebhookDeliveries(t, testAPIKey, hook.Id, "dead")[0]
+		if delivery.Attempts != 3 || delivery.NextAttemptAt != nil {
+			t.Fatalf("expected the delivery to be dead after 3 attempts, got %+v", delivery)
+		}
+		received := receiver.received()
+		if len(received) != 3 || received[0].header.Get(webhook.IdHeader) != received[2].header.Get(webhook.IdHeader) {
+			t.Fatalf("expected 3 attempts with the same id, got %d", len(received))
+		}
+
+		receiver.setStatus(http.StatusNoContent)
+		target := fmt.Sprintf("/api/webhooks/%d/deliveries/%d/redeliver", hook.Id, delivery.Id)
+		requireStatus(t, http.StatusAccepted, reqWithAPIKey(t, testHandler, http.MethodPost, target, nil, testAPIKey))
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, target, nil, testAPIKey)
+		requireStatus(t, http.StatusConflict, rec)
+		requireErrorMessage(t, "webhook delivery is already pending", rec)
+
+		mustDispatchWebhooks(t, sender, policy, time.Now())
+		delivery = mustGETWebhookDeliveries(t, testAPIKey, hook.Id, "")[0]
+		if delivery.Status != api.Delivered || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+			t.Fatalf("expected the redelivery to succeed, got %+v", delivery)
+		}
+	})
+
+	t.Run(`should refuse to deliver to loopback and private addresses`, func(t *testing.T) {
+		strictSender := webhook.NewSender(5*time.Second, false)
+		receiver := newTestReceiver(t)
+		hook := mustPOSTWebhook(t, bobKey, map[string]any{"url": receiver.URL, "event_types": []string{"BalanceAdded"}})
+		t.Cleanup(func() {
+			requireStatus(t, http.StatusNoContent, reqWithAPIKey(t, testHandler, http.MethodDelete, fmt.Sprintf("/api/webhooks/%d", hook.Id), nil, bobKey))
+		})
+
+		mustPOSTAddBalance(t, testHandler, bobAccount.Id, 10)
+		mustDispatchWebhooks(t, strictSender, policy, time.Now())
+		if attempts := len(receiver.received()); attempts != 0 {
+			t.Fatalf("expected the loopback receiver not to be called, got %d attempts", attempts)
+		}
+		delivery := mustGETWebhookDeliveries(t, bobKey, hook.Id, "")[0]
+		if delivery.Attempts != 1 || delivery.LastResponseStatus != nil || delivery.LastError == nil ||
+			*delivery.LastError != webhook.ErrNonPublicAddress.Error() {
+			t.Fatalf("expected the delivery to fail on the address only, got %+v", delivery)
+		}
+
+		// host names are checked once resolved
+		localhost := strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)
+		for _, target := range []string{localhost, "http://10.0.0.5:22/", "http://169.254.169.254/latest/meta-data", "http://[::1]:9090/metrics"} {
+			status, err := strictSender.Send(context.Background(), target, "secret", "1", []byte("{}"), time.Now())
+			if status != 0 || !errors.Is(err, webhook.ErrNonPublicAddress) {
+				t.Fatalf("expected %s to be refused, got %d and %v", target, status, err)
+			}
+		}
+	})
+
+	t.Run(`should keep the webhooks of other customers hidden`, func(t *testing.T) {
+		hook := mustPOSTWebhook(t, bobKey, map[string]any{"url": "https://example.com/hooks", "event_types": []string{"AccountFrozen"}})
+
+		for _, listed := range mustGETWebhooks(t, aliceKey) {
+			if listed.Id == hook.Id {
+				t.Fatalf("expected bob's webhook to be hidden from alice")
+			}
+		}
+		target := fmt.Sprintf("/api/webhooks/%d", hook.Id)
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodGet, target+"/deliveries", nil, aliceKey))
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodDelete, target, nil, aliceKey))
+
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/webhooks",
+			map[string]any{"url": "https://example.com/hooks", "event_types": []string{"BalanceAdded"}, "account_ids": []int64{bobAccount.Id}}, aliceKey)
+		requireStatus(t, http.StatusNotFound, rec)
+		if p := requireProblem(t, rec, problem.CodeAccountNotFound); p.Detail != fmt.Sprintf("account %d not found", bobAccount.Id) {
+			t.Fatalf("expected bob's account not to be found, got %q", p.Detail)
+		}
+
+		requireStatus(t, http.StatusNoContent, reqWithAPIKey(t, testHandler, http.MethodDelete, target, nil, bobKey))
+		requireStatus(t, http.StatusNotFound, reqWithAPIKey(t, testHandler, http.MethodGet, target+"/deliveries", nil, bobKey))
+	})
+
+	t.Run(`should validate the webhooks`, func(t *testing.T) {
+		rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/webhooks", map[string]any{"url": "ftp://example.com", "event_types": []string{"BalanceAdded"}}, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+		requireErrorMessage(t, "url must be an http or https URL", rec)
+
+		rec = reqWithAPIKey(t, testHandler, http.MethodGet, "/api/webhooks/1/deliveries?status=lost", nil, testAPIKey)
+		requireStatus(t, http.StatusBadRequest, rec)
+	})
+}
+
+func mustPOSTWebhook(t *testing.T, apiKey string, body map[string]any) api.Webhook {
+	t.Helper()
+	rec := reqWithAPIKey(t, testHandler, http.MethodPost, "/api/webhooks", body, apiKey)
+	requireStatus(t, http.StatusCreated, rec)
+	var hook api.Webhook
+	if err := json.NewDecoder(rec.Body).Decode(&hook); err != nil {
+		t.Fatalf("failed to decode webhook response: %v", err)
+	}
+	return hook
+}
+
+func mustGETWebhooks(t *testing.T, apiKey string) []api.Webhook {
+	t.Helper()
+	rec := reqWithAPIKey(t, testHandler, http.MethodGet, "/api/webhooks", nil, apiKey)
+	requireStatus(t, http.StatusOK, rec)
+	var hooks []api.Webhook
+	if err := json.NewDecoder(rec.Body).Decode(&hooks); err != nil {
+		t.Fatalf("failed to decode webhooks response: %v", err)
+	}
+	return hooks
+}
+
+func mustGETWebhookDeliveries(t *testing.T, apiKey string, webhookId int64, status string) []api.WebhookDelivery {
+	t.Helper()
+	target := fmt.Sprintf("/api/webhooks/%d/deliveries", webhookId)
+	if status != "" {
+		target += "?status=" + status
+	}
+	rec := reqWithAPIKey(t, testHandler, http.MethodGet, target, nil, apiKey)
+	requireStatus(t, http.StatusOK, rec)
+	var deliveries []api.WebhookDelivery
+	if err := json.NewDecoder(rec.Body).Decode(&deliveries); err != nil {
+		t.Fatalf("failed to decode webhook deliveries response: %v", err)
+	}
+	if len(deliveries) == 0 {
+		t.Fatalf("expected deliveries for webhook %d", webhookId)
+	}
+	return deliveries
+}
+
+// mustDispatchWebhooks attempts all the deliveries due at now, including the ones left by the other tests.
+func mustDispatchWebhooks(t *testing.T, sender *webhook.Sender, policy webhook.RetryPolicy, now time.Time) {
+	t.Helper()
+	for {
+		attempted, err := api.DispatchWebhooks(context.Background(), testStore, sender, policy, now)
+		if err != nil {
+			t.Fatalf("failed to dispatch webhooks: %v", err)
+		}
+		if attempted == 0 {
+			return
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log/slog"
+	"os"
 	"tiny-bank-api/pkg/logging"
 
 	"github.com/alecthomas/kong"
@@ -9,6 +10,14 @@ import (
 
 type Cli struct {
 	Serve CmdServe `cmd:"1" help:"Run the API to serve requests."`
+	Keys  CmdKeys  `cmd:"" help:"Manage API keys."`
+	Roles CmdRoles `cmd:"" help:"Manage the roles of back-office users."`
+
+	// the commands calling a running server, e.g. to script operations
+	Accounts CmdAccounts `cmd:"" help:"Manage the accounts of a running server."`
+	Transfer CmdTransfer `cmd:"" help:"Transfer money between two accounts of a running server."`
+
+	VerifyAudit CmdVerifyAudit `cmd:"" help:"Check that the audit log wasn't tampered with."`
 }
 
 func main() {
@@ -22,5 +31,6 @@ func main() {
 	err := ctx.Run()
 	if err != nil {
 		logger.Error("error in main: " + err.Error())
+		os.Exit(1)
 	}
 }
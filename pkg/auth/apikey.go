@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"tiny-bank-api/pkg/problem"
+	"tiny-bank-api/store/entities"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	// apiKeyPrefix makes our keys easy to spot, e.g. by secret scanners.
+	apiKeyPrefix = "tbk_"
+)
+
+// APIKeyLookup finds the API keys by the hash of their secret, found is false when no key has it.
+type APIKeyLookup interface {
+	LookupAPIKey(ctx context.Context, hash string) (key entities.APIKey, found bool, err error)
+}
+
+// NewAPIKey generates a random API key. The returned secret is what the client sends, it is shown once
+// and only its hash is kept in the returned entity.
+func NewAPIKey(name string, scopes []string, expiresAt *time.Time) (string, entities.APIKey, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", entities.APIKey{}, err
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	return secret, entities.APIKey{
+		Name:      name,
+		Prefix:    secret[:len(apiKeyPrefix)+6],
+		Hash:      HashAPIKey(secret),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// HashAPIKey hashes an API key for storage and lookup. Keys are random 256 bits values, so a fast
+// unsalted hash is enough, unlike for passwords.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CredentialsError is returned when the credentials of a request are rejected, its reason can be shown to
+// the client. The other authentication errors are internal.
+type CredentialsError struct {
+	Reason string
+}
+
+func (e CredentialsError) Error() string {
+	return e.Reason
+}
+
+// ErrConflictingCredentials rejects the requests carrying both an API key and a bearer token, which may act
+// for different principals.
+var ErrConflictingCredentials = CredentialsError{"send either an api key or a bearer token, not both"}
+
+// AuthenticateAPIKey returns the principal of the API key secret.
+func AuthenticateAPIKey(ctx context.Context, keys APIKeyLookup, secret string) (Principal, error) {
+	key, found, err := keys.LookupAPIKey(ctx, HashAPIKey(secret))
+	if err != nil {
+		return Principal{}, fmt.Errorf("failed to look up api key: %w", err)
+	}
+	if !found {
+		return Principal{}, CredentialsError{"invalid api key"}
+	}
+	if !key.Usable(time.Now()) {
+		return Principal{}, CredentialsError{"api key is expired or revoked"}
+	}
+
+	return Principal{
+		Subject:    "apikey:" + strconv.FormatInt(key.Id, 10),
+		Scopes:     key.Scopes,
+		CustomerId: key.CustomerId,
+	}, nil
+}
+
+// APIKeyMiddleware authenticates the requests carrying an API key header. Requests without one go
+// through unauthenticated, it's up to the operations to require credentials.
+func APIKeyMiddleware(keys APIKeyLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := r.Header.Get(APIKeyHeader)
+			if secret == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := BearerToken(r.Header.Get("Authorization")); ok {
+				writeAuthenticationError(w, r, ErrConflictingCredentials)
+				return
+			}
+
+			principal, err := AuthenticateAPIKey(r.Context(), keys, secret)
+			if err != nil {
+				writeAuthenticationError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// writeAuthenticationError writes a 401 for the rejected credentials, a 500 for the other errors.
+func writeAuthenticationError(w http.ResponseWriter, r *http.Request, err error) {
+	var credentialsErr CredentialsError
+	if errors.As(err, &credentialsErr) {
+		problem.Write(r.Context(), w, http.StatusUnauthorized, problem.CodeUnauthorized, credentialsErr.Reason)
+		return
+	}
+	slog.Error("Failed to authenticate request", "error", err)
+	problem.Write(r.Context(), w, http.StatusInternalServerError, problem.CodeInternalError, "the server failed to authenticate the request")
+}
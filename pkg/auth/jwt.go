@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"tiny-bank-api/store/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtSubjectPrefix distinguishes token subjects from API keys in the principal subject.
+const jwtSubjectPrefix = "jwt:"
+
+// CustomerLookup finds the customers by the subject of their tokens, found is false when none matches.
+type CustomerLookup interface {
+	LookupCustomerByExternalId(ctx context.Context, externalId string) (customer entities.Customer, found bool, err error)
+}
+
+// JWTVerifier validates bearer tokens signed with RS256 or ES256 by one of the keys of a JWKS.
+type JWTVerifier struct {
+	keys     *JWKS
+	issuer   string
+	audience string
+}
+
+func NewJWTVerifier(keys *JWKS, issuer, audience string) *JWTVerifier {
+	return &JWTVerifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	// Scope is the space separated list of scopes of OAuth 2 access tokens (RFC 9068).
+	Scope string `json:"scope"`
+	// Scp is the list of scopes used by some identity providers instead of scope.
+	Scp []string `json:"scp"`
+}
+
+// Verify checks the signature, issuer, audience and expiry of the token, and maps its claims to a principal.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (Principal, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+
+	var claims jwtClaims
+	_, err := parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return Principal{}, err
+	}
+	if claims.Subject == "" {
+		return Principal{}, errors.New("token has no subject")
+	}
+
+	scopes := claims.Scp
+	if claims.Scope != "" {
+		scopes = strings.Fields(claims.Scope)
+	}
+	return Principal{
+		Subject: jwtSubjectPrefix + claims.Subject,
+		Scopes:  scopes,
+	}, nil
+}
+
+// AuthenticateBearerToken returns the principal of the bearer token. The caller acts for the customer whose
+// external id is the subject of the token, if there is one.
+func AuthenticateBearerToken(ctx context.Context, verifier *JWTVerifier, customers CustomerLookup, token string) (Principal, error) {
+	principal, err := verifier.Verify(ctx, token)
+	if err != nil {
+		slog.Debug("Rejected bearer token", "error", err)
+		return Principal{}, CredentialsError{fmt.Sprintf("invalid bearer token: %s", jwtErrorReason(err))}
+	}
+
+	customer, found, err := customers.LookupCustomerByExternalId(ctx, strings.TrimPrefix(principal.Subject, jwtSubjectPrefix))
+	if err != nil {
+		return Principal{}, fmt.Errorf("failed to look up customer: %w", err)
+	}
+	if found {
+		principal.CustomerId = &customer.Id
+	}
+	return principal, nil
+}
+
+// JWTMiddleware authenticates the requests carrying a bearer token. Like APIKeyMiddleware, requests
+// without one go through unauthenticated.
+func JWTMiddleware(verifier *JWTVerifier, customers CustomerLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := BearerToken(r.Header.Get("Authorization"))
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := AuthenticateBearerToken(r.Context(), verifier, customers, token)
+			if err != nil {
+				writeAuthenticationError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// BearerToken returns the token of an Authorization header value using the Bearer scheme.
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// jwtErrorReason is a short description of why a token was rejected, that doesn't leak details about our keys.
+func jwtErrorReason(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "expired"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "wrong issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "wrong audience"
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return "not valid yet"
+	default:
+		return "malformed or bad signature"
+	}
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// ScopeAdmin grants every other scope.
+const ScopeAdmin = "admin"
+
+// Back-office roles, assigned to principals in the database.
+const (
+	// RoleSupport can look at every account without changing anything.
+	RoleSupport = "support"
+	// RoleOperator can freeze, unfreeze and adjust every account.
+	RoleOperator = "operator"
+	// RoleCompliance can look at every account and decides on the sanctions screening hits.
+	RoleCompliance = "compliance"
+	// RoleAdmin can do everything. Credentials with the admin scope have it without an assignment.
+	RoleAdmin = "admin"
+	// RoleCustomer is held by the principals acting for a customer, it is never assigned.
+	RoleCustomer = "customer"
+)
+
+// Roles lists the roles that can be assigned.
+var Roles = []string{RoleSupport, RoleOperator, RoleCompliance, RoleAdmin}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller in logs and audit trails, e.g. "apikey:3".
+	Subject string
+	Scopes  []string
+	// CustomerId is the customer the caller acts for, nil for operators and unbound credentials.
+	CustomerId *int64
+	// Roles are the roles assigned to the principal, loaded by the policy layer.
+	Roles []string
+}
+
+// IsAdmin reports whether the principal can access every account regardless of its owner.
+func (p Principal) IsAdmin() bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Roles, RoleAdmin)
+}
+
+// HasAnyRole reports whether the principal holds one of the roles, including the implicit admin and
+// customer roles.
+func (p Principal) HasAnyRole(roles []string) bool {
+	for _, role := range roles {
+		switch {
+		case role == RoleAdmin && p.IsAdmin():
+			return true
+		case role == RoleCustomer && p.CustomerId != nil:
+			return true
+		case slices.Contains(p.Roles, role):
+			return true
+		}
+	}
+	return false
+}
+
+// IsBackOffice reports whether the principal holds one of the back-office roles.
+func (p Principal) IsBackOffice() bool {
+	return p.HasAnyRole([]string{RoleSupport, RoleOperator, RoleCompliance, RoleAdmin})
+}
+
+// CanAccessAccount reports whether the principal may see and modify an account owned by ownerId. Back-office
+// roles access every account, the policy decides what they can do with it. Everybody else only accesses the
+// accounts of the customer they act for.
+func (p Principal) CanAccessAccount(ownerId *int64) bool {
+	if p.IsBackOffice() {
+		return true
+	}
+	return p.CustomerId != nil && ownerId != nil && *p.CustomerId == *ownerId
+}
+
+// HasScopes reports whether the principal was granted all the required scopes.
+func (p Principal) HasScopes(required []string) bool {
+	if slices.Contains(p.Scopes, ScopeAdmin) {
+		return true
+	}
+	for _, scope := range required {
+		if !slices.Contains(p.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal authenticated by one of the middlewares, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
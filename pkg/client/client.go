@@ -0,0 +1,176 @@
+// Package client is a Go client of the API. The Client and ClientWithResponses types are generated from
+// api/openapi.yaml, BankClient wraps them with the credentials and the retries, and turns the error responses
+// of the most common operations into errors. The operations of BankClient moving money or creating accounts
+// send an Idempotency-Key, so they are retried like the idempotent ones without being applied twice.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"tiny-bank-api/pkg/problem"
+)
+
+const (
+	apiKeyHeader         = "X-API-Key"
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	defaultRetryBackoff = 200 * time.Millisecond
+)
+
+// Config configures a BankClient.
+type Config struct {
+	// BaseURL is the URL of the API including its /api prefix, e.g. http://localhost:8080/api.
+	BaseURL string
+	// APIKey authenticates the requests in the X-API-Key header.
+	APIKey string
+	// BearerToken authenticates the requests in the Authorization header when APIKey is not set.
+	BearerToken string
+	// HTTPClient sends the requests, http.DefaultClient when nil.
+	HTTPClient HttpRequestDoer
+	// MaxRetries is the number of times a failed request is retried, 0 disables the retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled after each one, 200ms when not set. The
+	// Retry-After of the server is waited for when longer.
+	RetryBackoff time.Duration
+}
+
+// BankClient calls the API with the credentials of its config, retrying the failed requests.
+type BankClient struct {
+	*ClientWithResponses
+}
+
+func New(cfg Config) (*BankClient, error) {
+	doer := cfg.HTTPClient
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+
+	generated, err := NewClientWithResponses(cfg.BaseURL,
+		WithHTTPClient(retryingDoer{doer: doer, maxRetries: cfg.MaxRetries, backoff: cfg.RetryBackoff}),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			switch {
+			case cfg.APIKey != "":
+				req.Header.Set(apiKeyHeader, cfg.APIKey)
+			case cfg.BearerToken != "":
+				req.Header.Set("Authorization", "Bearer "+cfg.BearerToken)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &BankClient{ClientWithResponses: generated}, nil
+}
+
+// APIError is an error response of the API, with the details of its problem.
+type APIError struct {
+	StatusCode int
+	// Code is the kind of problem, empty when the response isn't a problem.
+	Code      problem.Code
+	Message   string
+	RequestId string
+	Errors    []FieldError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api responded %d: %s", e.StatusCode, e.Message)
+}
+
+// ListAccounts lists the accounts the credentials can access.
+func (c *BankClient) ListAccounts(ctx context.Context) ([]Account, error) {
+	resp, err := c.GetAccountsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetAccount gets an account.
+func (c *BankClient) GetAccount(ctx context.Context, accountId int64) (Account, error) {
+	resp, err := c.GetAccountWithResponse(ctx, accountId)
+	if err != nil {
+		return Account{}, err
+	}
+	if resp.JSON200 == nil {
+		return Account{}, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// CreateAccount creates an account, owned by the customer of the credentials unless an admin sets ownerId.
+func (c *BankClient) CreateAccount(ctx context.Context, name string, ownerId *int64) error {
+	resp, err := c.CreateAccountWithResponse(ctx, &CreateAccountParams{IdempotencyKey: newIdempotencyKey()},
+		CreateAccountJSONRequestBody{Name: name, OwnerId: ownerId})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
+
+// AddBalance adds an amount to the balance of an account.
+func (c *BankClient) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	resp, err := c.AddBalanceToAccountWithResponse(ctx, accountId, &AddBalanceToAccountParams{IdempotencyKey: newIdempotencyKey()},
+		AddBalanceToAccountJSONRequestBody{Amount: amount})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
+
+// Transfer transfers an amount between two accounts. It returns the transfer when it waits for an approval
+// or a sanctions review, nil when the money moved right away.
+func (c *BankClient) Transfer(ctx context.Context, sourceAccountId, targetAccountId int64, amount float64) (*Transfer, error) {
+	resp, err := c.TransferMoneyWithResponse(ctx, sourceAccountId, &TransferMoneyParams{IdempotencyKey: newIdempotencyKey()}, TransferMoneyJSONRequestBody{
+		TargetAccountId: targetAccountId,
+		Amount:          amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil, nil
+	case http.StatusAccepted:
+		return resp.JSON202, nil
+	default:
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+}
+
+// newIdempotencyKey generates the Idempotency-Key of a call, which its retries send again.
+func newIdempotencyKey() *string {
+	key := rand.Text()
+	return &key
+}
+
+// newAPIError reads the problem of an error response, the message being the status text when it has none.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	var p Problem
+	if err := json.Unmarshal(body, &p); err != nil || p.Detail == "" {
+		p.Detail = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       p.Code,
+		Message:    p.Detail,
+		RequestId:  p.RequestId,
+		Errors:     p.Errors,
+	}
+}
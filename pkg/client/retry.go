@@ -0,0 +1,91 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// retryingDoer retries the requests failing with a transient error. The API only deduplicates the requests
+// sent with an Idempotency-Key, so the other ones that aren't idempotent are only retried when they can't have
+// been processed: the connection was refused, or the server refused them with a 429 or a 503 telling when to
+// retry. The retries send the same headers, Idempotency-Key included.
+type retryingDoer struct {
+	doer       HttpRequestDoer
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (d retryingDoer) Do(req *http.Request) (*http.Response, error) {
+	// a body that can't be read again can't be retried
+	maxRetries := d.maxRetries
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	delay := d.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := d.doer.Do(req)
+		retry, retryAfter := shouldRetry(req, resp, err)
+		if !retry || attempt >= maxRetries {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(max(delay, retryAfter)):
+		}
+		delay *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// shouldRetry reports whether the request should be retried after its outcome, and how long the server
+// asked to wait before.
+func shouldRetry(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false, 0
+		}
+		return errors.Is(err, syscall.ECONNREFUSED) || idempotent(req), 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// the Retry-After is the guarantee the server refused the request rather than failed processing it,
+		// e.g. the rate limiter sends it before the request reaches the handlers
+		retryAfter := resp.Header.Get("Retry-After")
+		seconds, _ := strconv.Atoi(retryAfter)
+		return retryAfter != "" || idempotent(req), time.Duration(seconds) * time.Second
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req), 0
+	default:
+		return false, 0
+	}
+}
+
+// idempotent reports whether sending the request more than once has the same effect as sending it once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return req.Header.Get(idempotencyKeyHeader) != ""
+	}
+}
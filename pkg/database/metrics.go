@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TxWrapper is implemented by the SQLDB wrappers that also wrap the queries of the transactions they begin,
+// which WithTx hands to the units of work instead of the bare transaction.
+type TxWrapper interface {
+	WrapTx(tx *sqlx.Tx) Querier
+}
+
+// MetricsDB observes the duration of the queries in Queries, labeled by statement.
+type MetricsDB struct {
+	SQLDB
+	Queries prometheus.ObserverVec
+}
+
+var (
+	_ SQLDB     = MetricsDB{}
+	_ TxWrapper = MetricsDB{}
+)
+
+func (db MetricsDB) WrapTx(tx *sqlx.Tx) Querier {
+	return metricsQuerier{q: tx, queries: db.Queries}
+}
+
+func (db MetricsDB) querier() metricsQuerier {
+	return metricsQuerier{q: db.SQLDB, queries: db.Queries}
+}
+
+func (db MetricsDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.querier().ExecContext(ctx, query, args...)
+}
+
+func (db MetricsDB) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return db.querier().NamedExecContext(ctx, query, arg)
+}
+
+func (db MetricsDB) PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error) {
+	return db.querier().PrepareNamedContext(ctx, query)
+}
+
+func (db MetricsDB) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	return db.querier().PreparexContext(ctx, query)
+}
+
+func (db MetricsDB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return db.querier().QueryRowxContext(ctx, query, args...)
+}
+
+func (db MetricsDB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return db.querier().QueryxContext(ctx, query, args...)
+}
+
+// metricsQuerier times the queries of either the pool or a transaction. The rows of the queries are read
+// after the observation, so it measures the time to the first row.
+type metricsQuerier struct {
+	q       Querier
+	queries prometheus.ObserverVec
+}
+
+func (m metricsQuerier) observe(query string, start time.Time) {
+	m.queries.WithLabelValues(statement(query)).Observe(time.Since(start).Seconds())
+}
+
+func (m metricsQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer m.observe(query, time.Now())
+	return m.q.ExecContext(ctx, query, args...)
+}
+
+func (m metricsQuerier) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	defer m.observe(query, time.Now())
+	return m.q.NamedExecContext(ctx, query, arg)
+}
+
+func (m metricsQuerier) PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error) {
+	defer m.observe(query, time.Now())
+	return m.q.PrepareNamedContext(ctx, query)
+}
+
+func (m metricsQuerier) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	defer m.observe(query, time.Now())
+	return m.q.PreparexContext(ctx, query)
+}
+
+func (m metricsQuerier) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	defer m.observe(query, time.Now())
+	return m.q.QueryRowxContext(ctx, query, args...)
+}
+
+func (m metricsQuerier) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	defer m.observe(query, time.Now())
+	return m.q.QueryxContext(ctx, query, args...)
+}
+
+// statement is the first keyword of query, lowercased, keeping the label values bounded.
+func statement(query string) string {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	keyword = strings.ToLower(strings.TrimRight(keyword, "\n\t;("))
+	switch keyword {
+	case "select", "insert", "update", "delete", "with":
+		return keyword
+	default:
+		return "other"
+	}
+}
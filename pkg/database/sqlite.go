@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/XSAM/otelsql"
+	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	_ "modernc.org/sqlite"
+)
+
+// NewSQLiteConnection constructor for a connection to a sqlite database file
+func NewSQLiteConnection(ctx context.Context, path string) (*sqlx.DB, error) {
+	params := url.Values{}
+	// Transactions take the write lock upfront, so concurrent units of work wait for each other
+	// instead of failing with SQLITE_BUSY when upgrading from a read to a write lock.
+	params.Add("_txlock", "immediate")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_time_format", "sqlite")
+
+	stdDB, err := otelsql.Open("sqlite", "file:"+path+"?"+params.Encode(), otelsql.WithAttributes(semconv.DBSystemNameSQLite))
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite db: %w", err)
+	}
+	// sqlite only allows a single writer, a single connection avoids lock contention between our own
+	// connections and keeps ":memory:" databases from being created once per connection.
+	stdDB.SetMaxOpenConns(1)
+
+	if err := stdDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("error connecting to sqlite: %w", err)
+	}
+
+	return sqlx.NewDb(stdDB, "sqlite"), nil
+}
@@ -0,0 +1,132 @@
+// Package metrics holds the Prometheus metrics of the service: the HTTP requests, the database queries and
+// the business events like the transfers and the created accounts.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tinybank"
+
+// Metrics are the collectors of the service, registered in their own registry. A nil *Metrics records
+// nothing, so the code recording business events doesn't have to check whether metrics are enabled.
+type Metrics struct {
+	registry *prometheus.Registry
+	currency string
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+	DBQueryDuration     *prometheus.HistogramVec
+	TransfersCreated    *prometheus.CounterVec
+	TransfersFailed     *prometheus.CounterVec
+	TransferredAmount   *prometheus.CounterVec
+	AccountsCreated     prometheus.Counter
+}
+
+// New creates the metrics, currency being the ISO 4217 code the amounts of the accounts are in.
+func New(currency string) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		currency: currency,
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests of the API by operationId and status code.",
+		}, []string{"operation", "status"}),
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to answer the HTTP requests of the API by operationId and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "status"}),
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Time taken by the database queries by statement (select, insert, update, delete...).",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"statement"}),
+		TransfersCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfers_created_total",
+			Help:      "Transfers accepted by status: completed right away, or pending approval or review.",
+		}, []string{"status"}),
+		TransfersFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfers_failed_total",
+			Help:      "Transfers refused by reason, the code of the problem they were answered with.",
+		}, []string{"reason"}),
+		TransferredAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transferred_amount_total",
+			Help:      "Total amount moved between accounts by completed transfers, by currency.",
+		}, []string{"currency"}),
+		AccountsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "accounts_created_total",
+			Help:      "Accounts created.",
+		}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.DBQueryDuration,
+		m.TransfersCreated,
+		m.TransfersFailed,
+		m.TransferredAmount,
+		m.AccountsCreated,
+	)
+	return m
+}
+
+// RegisterDB exposes the statistics of the connection pool of db, as go_sql_* metrics labeled with name.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// TransferCreated counts a transfer accepted with status, and its amount when it completed right away.
+func (m *Metrics) TransferCreated(status string, amount float64) {
+	if m == nil {
+		return
+	}
+	m.TransfersCreated.WithLabelValues(status).Inc()
+	if status == "completed" {
+		m.TransferCompleted(amount)
+	}
+}
+
+// TransferCompleted counts the amount of a transfer that moved money, including the held transfers
+// completed once approved or cleared.
+func (m *Metrics) TransferCompleted(amount float64) {
+	if m == nil {
+		return
+	}
+	m.TransferredAmount.WithLabelValues(m.currency).Add(amount)
+}
+
+// TransferFailed counts a refused transfer.
+func (m *Metrics) TransferFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.TransfersFailed.WithLabelValues(reason).Inc()
+}
+
+// AccountCreated counts a created account.
+func (m *Metrics) AccountCreated() {
+	if m == nil {
+		return
+	}
+	m.AccountsCreated.Inc()
+}
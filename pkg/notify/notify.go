@@ -0,0 +1,73 @@
+// Package notify fans out the notifications of new domain events to the subscribers of the process, like
+// the event streams, whichever replica committed the events.
+package notify
+
+import (
+	"slices"
+	"sync"
+)
+
+// subscriberBuffer is the number of notifications kept for a subscriber, the following ones are dropped
+// until it catches up.
+const subscriberBuffer = 64
+
+// Notification tells that an event was committed.
+type Notification struct {
+	// EventId is the id of the event, 0 when notifications may have been missed, e.g. while the listener
+	// was reconnecting, in which case subscribers should check the events table for anything new.
+	EventId          int64  `json:"id"`
+	AccountId        int64  `json:"account_id"`
+	RelatedAccountId *int64 `json:"related_account_id"`
+}
+
+// Concerns reports whether the notification may be about the account.
+func (n Notification) Concerns(accountId int64) bool {
+	return n.EventId == 0 || n.AccountId == accountId || (n.RelatedAccountId != nil && *n.RelatedAccountId == accountId)
+}
+
+// Hub broadcasts the notifications to its subscribers.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers []*Subscription
+}
+
+func NewHub() *Hub {
+	return &Hub{}
+}
+
+// Subscription receives the notifications of a hub on C until it is closed. Notifications are only hints:
+// the ones sent while the subscriber is busy are dropped, so subscribers read the events table when woken
+// up rather than relying on each notification.
+type Subscription struct {
+	C   <-chan Notification
+	c   chan Notification
+	hub *Hub
+}
+
+func (h *Hub) Subscribe() *Subscription {
+	c := make(chan Notification, subscriberBuffer)
+	sub := &Subscription{C: c, c: c, hub: h}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = append(h.subscribers, sub)
+	return sub
+}
+
+// Close stops the notifications of the subscription.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.subscribers = slices.DeleteFunc(s.hub.subscribers, func(sub *Subscription) bool { return sub == s })
+}
+
+// Publish sends the notification to every subscriber, without waiting for the busy ones.
+func (h *Hub) Publish(notification Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sub := range h.subscribers {
+		select {
+		case sub.c <- notification:
+		default:
+		}
+	}
+}
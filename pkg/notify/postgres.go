@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// Channel is the postgres channel the outbox_events trigger notifies.
+	Channel = "outbox_events"
+
+	minReconnectDelay = 100 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+)
+
+// ListenPostgres publishes the notifications of the postgres channel to hub until ctx is done. The listening
+// connection is reopened with an exponential backoff when lost, after which the subscribers are told that
+// notifications may have been missed.
+func ListenPostgres(ctx context.Context, logger *slog.Logger, connString, channel string, hub *Hub) {
+	delay := minReconnectDelay
+	reconnecting := false
+	for ctx.Err() == nil {
+		err := listen(ctx, logger, connString, channel, hub, func() {
+			delay = minReconnectDelay
+			if reconnecting {
+				hub.Publish(Notification{})
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("Error listening to postgres notifications, reconnecting: "+err.Error(), "delay", delay)
+		reconnecting = true
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen publishes the notifications of a single connection until it fails, calling listening once it
+// listens to the channel.
+func listen(ctx context.Context, logger *slog.Logger, connString, channel string, hub *Hub, listening func()) error {
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	listening()
+
+	for {
+		pgNotification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var notification Notification
+		if err := json.Unmarshal([]byte(pgNotification.Payload), &notification); err != nil {
+			logger.Warn("Ignoring invalid postgres notification", "payload", pgNotification.Payload, "error", err)
+			continue
+		}
+		hub.Publish(notification)
+	}
+}
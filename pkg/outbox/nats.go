@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// flushTimeout bounds the wait for the server to receive a message.
+const flushTimeout = 5 * time.Second
+
+// NATSSink publishes the messages to <subjectPrefix>.<account id>.<type>, so consumers can subscribe to
+// the events of an account or to a type of event.
+type NATSSink struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+func NewNATSSink(url, subjectPrefix string) (*NATSSink, error) {
+	conn, err := nats.Connect(url, nats.Name("tiny-bank-api"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to NATS: %w", err)
+	}
+	return &NATSSink{conn: conn, subjectPrefix: subjectPrefix}, nil
+}
+
+// Subject returns the subject of the messages of an account with a type.
+func (s *NATSSink) Subject(accountId int64, eventType string) string {
+	return s.subjectPrefix + "." + strconv.FormatInt(accountId, 10) + "." + eventType
+}
+
+// Publish waits for the server to receive the message. The message id is sent in the Nats-Msg-Id header,
+// which JetStream streams use to drop the duplicates.
+func (s *NATSSink) Publish(ctx context.Context, message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(s.Subject(message.AccountId, message.Type))
+	msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(message.Id, 10))
+	msg.Data = data
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flushTimeout)
+	defer cancel()
+	return s.conn.FlushWithContext(ctx)
+}
+
+func (s *NATSSink) Close() error {
+	return s.conn.Drain()
+}
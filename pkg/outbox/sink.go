@@ -0,0 +1,84 @@
+// Package outbox publishes the domain events relayed from the outbox table to the downstream systems.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a domain event as published to the sinks. Delivery is at least once, consumers can drop the
+// messages whose Id they already handled.
+type Message struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	AccountId  int64           `json:"account_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sink publishes messages. Publish must only return once the message is handed over durably, the message
+// is published again otherwise.
+type Sink interface {
+	Publish(ctx context.Context, message Message) error
+	Close() error
+}
+
+// Open opens the sink of a URL: nats://host:port publishes to NATS, "-" or stdout writes to the standard
+// output and anything else is the path of a file the messages are appended to.
+func Open(url, subjectPrefix string) (Sink, error) {
+	switch {
+	case strings.HasPrefix(url, "nats://") || strings.HasPrefix(url, "tls://"):
+		return NewNATSSink(url, subjectPrefix)
+	case url == "-" || url == "stdout":
+		return NewWriterSink(os.Stdout), nil
+	default:
+		return OpenFileSink(url)
+	}
+}
+
+// WriterSink writes the messages as JSON lines.
+type WriterSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	// sync flushes the writes to disk, nil when the writer isn't a file.
+	sync   func() error
+	closer io.Closer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(w)}
+}
+
+// OpenFileSink appends the messages to the file at path, creating it if needed.
+func OpenFileSink(path string) (*WriterSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening events file: %w", err)
+	}
+	return &WriterSink{encoder: json.NewEncoder(f), sync: f.Sync, closer: f}, nil
+}
+
+func (s *WriterSink) Publish(_ context.Context, message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.encoder.Encode(message); err != nil {
+		return err
+	}
+	if s.sync != nil {
+		return s.sync()
+	}
+	return nil
+}
+
+func (s *WriterSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
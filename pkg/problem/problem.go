@@ -0,0 +1,103 @@
+// Package problem writes the errors of the API as RFC 7807 problem details, so every error has the same
+// shape whichever layer answers: the auth middlewares, the spec validation or the handlers.
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// Code identifies the kind of problem. Clients should branch on it rather than on the status or the
+// detail, which is meant for humans.
+type Code string
+
+const (
+	CodeInvalidRequest       Code = "invalid_request"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeForbidden            Code = "forbidden"
+	CodeSanctionsBlocked     Code = "sanctions_blocked"
+	CodeNotFound             Code = "not_found"
+	CodeAccountNotFound      Code = "account_not_found"
+	CodeMethodNotAllowed     Code = "method_not_allowed"
+	CodeConflict             Code = "conflict"
+	CodePreconditionFailed   Code = "precondition_failed"
+	CodeTransferRefused      Code = "transfer_refused"
+	CodeTransferDeclined     Code = "transfer_declined"
+	CodeIdempotencyKeyReused Code = "idempotency_key_reused"
+	CodeRateLimited          Code = "rate_limited"
+	CodeInternalError        Code = "internal_error"
+)
+
+// titles are the summaries of the codes, which unlike the details don't change between occurrences.
+var titles = map[Code]string{
+	CodeInvalidRequest:       "Invalid request",
+	CodeUnauthorized:         "Unauthorized",
+	CodeForbidden:            "Forbidden",
+	CodeSanctionsBlocked:     "Blocked by sanctions screening",
+	CodeNotFound:             "Resource not found",
+	CodeAccountNotFound:      "Account not found",
+	CodeMethodNotAllowed:     "Method not allowed",
+	CodeConflict:             "Conflict with the current state",
+	CodePreconditionFailed:   "Precondition failed",
+	CodeTransferRefused:      "Transfer refused",
+	CodeTransferDeclined:     "Transfer declined by the risk rules",
+	CodeIdempotencyKeyReused: "Idempotency key reused",
+	CodeRateLimited:          "Too many requests",
+	CodeInternalError:        "Internal error",
+}
+
+// Problem is the body of the error responses.
+type Problem struct {
+	// Type is a URI identifying the kind of problem, derived from Code.
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Code   Code   `json:"code"`
+	// RequestId is the id the request was logged and audited with.
+	RequestId string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a violation of the spec by a parameter or a body field of the request.
+type FieldError struct {
+	// Field is the parameter, or the body field in dotted notation, violating the spec.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// TypeURI is the Type of the problems of code. It's a URN as the problems aren't documented at a URL, the
+// spec lists them instead.
+func TypeURI(code Code) string {
+	return "urn:tiny-bank:problem:" + string(code)
+}
+
+// New returns a problem of code, with the id chi's RequestID middleware gave to the request of ctx.
+func New(ctx context.Context, status int, code Code, detail string) Problem {
+	return Problem{
+		Type:      TypeURI(code),
+		Title:     titles[code],
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestId: middleware.GetReqID(ctx),
+	}
+}
+
+// Write answers the request of ctx with a problem, for the middlewares answering before the handlers.
+func Write(ctx context.Context, w http.ResponseWriter, status int, code Code, detail string) {
+	WriteProblem(w, New(ctx, status, code, detail))
+}
+
+// WriteProblem answers with p.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit configures a token bucket: Burst requests can be made at once, after which requests are allowed
+// at Rate per second. The zero value doesn't limit anything.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit lets every request through.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// UnmarshalText parses limits like "100/1m" or "100/1m:20", 100 requests per minute in bursts of up to
+// 20 requests. The burst defaults to the number of requests, and "off" disables the limit.
+func (l *Limit) UnmarshalText(text []byte) error {
+	value := string(text)
+	if value == "off" {
+		*l = Limit{}
+		return nil
+	}
+
+	rate, burst, hasBurst := strings.Cut(value, ":")
+	requests, period, found := strings.Cut(rate, "/")
+	if !found {
+		return fmt.Errorf("invalid rate limit %q, expected <requests>/<period>[:<burst>]", value)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid number of requests in rate limit %q", value)
+	}
+	// allow "10/s" as well as "10/1s"
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid period in rate limit %q", value)
+	}
+
+	l.Rate = float64(n) / d.Seconds()
+	l.Burst = n
+	if hasBurst {
+		if l.Burst, err = strconv.Atoi(burst); err != nil || l.Burst <= 0 {
+			return fmt.Errorf("invalid burst in rate limit %q", value)
+		}
+	}
+	return nil
+}
+
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "off"
+	}
+	return fmt.Sprintf("%g/s:%d", l.Rate, l.Burst)
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, zero when one is allowed now.
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from the bucket of each client.
+type Limiter interface {
+	// Allow takes a token from the bucket identified by key, if there is one.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// Refund gives back a token taken by Allow, for the requests another bucket rejects afterwards.
+	Refund(ctx context.Context, key string, limit Limit) error
+}
+
+// Bucket is the state of a token bucket, kept by the limiters between requests.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket returns a full bucket.
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+}
+
+// Take refills the bucket for the time elapsed since its last update, then takes a token if there is one.
+func (b *Bucket) Take(limit Limit, now time.Time) Result {
+	b.refill(limit, now)
+
+	result := Result{Limit: limit.Burst}
+	if b.Tokens >= 1 {
+		b.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.Tokens) / limit.Rate)
+	}
+	result.Remaining = int(math.Floor(b.Tokens))
+	result.Reset = secondsToDuration((float64(limit.Burst) - b.Tokens) / limit.Rate)
+	return result
+}
+
+// Refund refills the bucket for the time elapsed since its last update, then gives back a token.
+func (b *Bucket) Refund(limit Limit, now time.Time) {
+	b.refill(limit, now)
+	b.Tokens = math.Min(float64(limit.Burst), b.Tokens+1)
+}
+
+// Full reports whether the bucket would be full at the given time, full buckets can be forgotten.
+func (b Bucket) Full(limit Limit, now time.Time) bool {
+	b.refill(limit, now)
+	return b.Tokens >= float64(limit.Burst)
+}
+
+func (b *Bucket) refill(limit Limit, now time.Time) {
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens = math.Min(float64(limit.Burst), b.Tokens+elapsed.Seconds()*limit.Rate)
+		b.UpdatedAt = now
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,196 @@
+package risk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+	"tiny-bank-api/store/entities"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Engine evaluates the rules of a YAML file, which is reloaded when it changes so rules can be tuned without
+// restarting. A nil Engine allows every transfer.
+type Engine struct {
+	path string
+
+	mu      sync.RWMutex
+	rules   Rules
+	modTime time.Time
+}
+
+// NewEngine loads the rules of the file at path.
+func NewEngine(path string) (*Engine, error) {
+	e := &Engine{path: path}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload reads the rules again. The current rules are kept if the file is invalid.
+func (e *Engine) Reload() error {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return fmt.Errorf("error loading risk rules: %w", err)
+	}
+	raw, err := os.ReadFile(e.path)
+	if err != nil {
+		return fmt.Errorf("error loading risk rules: %w", err)
+	}
+	rules, err := ParseRules(raw)
+	if err != nil {
+		return fmt.Errorf("error parsing risk rules from %s: %w", e.path, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = rules
+	e.modTime = info.ModTime()
+	return nil
+}
+
+// Run reloads the rules every interval when the file was modified, until ctx is done.
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(e.path)
+			if err != nil {
+				slog.Warn("Failed to check risk rules", "error", err)
+				continue
+			}
+			e.mu.RLock()
+			modified := !info.ModTime().Equal(e.modTime)
+			e.mu.RUnlock()
+			if !modified {
+				continue
+			}
+			if err := e.Reload(); err != nil {
+				slog.Warn("Failed to reload risk rules", "error", err)
+				continue
+			}
+			slog.Info("Reloaded risk rules", "path", e.path)
+		}
+	}
+}
+
+// Evaluate runs the current rules against the transfer, see Rules.Evaluate.
+func (e *Engine) Evaluate(ctx context.Context, history History, transfer Transfer) (entities.RiskDecision, entities.RiskEvaluations, error) {
+	if e == nil {
+		return entities.RiskDecisionAllow, entities.RiskEvaluations{}, nil
+	}
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+	return rules.Evaluate(ctx, history, transfer)
+}
+
+type rulesFile struct {
+	Rules []ruleConfig `yaml:"rules"`
+}
+
+type ruleConfig struct {
+	Name     string                `yaml:"name"`
+	Type     string                `yaml:"type"`
+	Decision entities.RiskDecision `yaml:"decision"`
+
+	// new_account
+	MinAge time.Duration `yaml:"min_age"`
+	// amount and round_amount
+	Min *float64 `yaml:"min"`
+	Max *float64 `yaml:"max"`
+	// fan_out
+	Window        time.Duration `yaml:"window"`
+	MaxNewTargets int64         `yaml:"max_new_targets"`
+	// round_amount
+	Multiple float64 `yaml:"multiple"`
+}
+
+// ParseRules parses a YAML rules file, e.g.
+//
+//	rules:
+//	  - name: cooling-off
+//	    type: new_account
+//	    min_age: 72h
+//	    decision: review
+//	  - name: large-amount
+//	    type: amount
+//	    min: 10000
+//	    decision: deny
+func ParseRules(raw []byte) (Rules, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	var file rulesFile
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	rules := make(Rules, 0, len(file.Rules))
+	names := map[string]bool{}
+	for i, config := range file.Rules {
+		if config.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i+1)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("rule %s is defined twice", config.Name)
+		}
+		names[config.Name] = true
+
+		rule, err := config.rule()
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", config.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (c ruleConfig) rule() (Rule, error) {
+	rule := Rule{Name: c.Name, Decision: c.Decision}
+	switch c.Decision {
+	case entities.RiskDecisionAllow, entities.RiskDecisionReview, entities.RiskDecisionDeny:
+	default:
+		return Rule{}, fmt.Errorf("decision must be one of allow, review, deny")
+	}
+
+	switch c.Type {
+	case "new_account":
+		if c.MinAge <= 0 {
+			return Rule{}, errors.New("min_age must be greater than 0")
+		}
+		rule.match = newAccountRule(c.MinAge)
+	case "amount":
+		if c.Min == nil && c.Max == nil {
+			return Rule{}, errors.New("min or max is required")
+		}
+		rule.match = amountRule(c.Min, c.Max)
+	case "fan_out":
+		if c.Window <= 0 || c.MaxNewTargets <= 0 {
+			return Rule{}, errors.New("window and max_new_targets must be greater than 0")
+		}
+		rule.match = fanOutRule(c.Window, c.MaxNewTargets)
+	case "round_amount":
+		if c.Multiple <= 0 {
+			return Rule{}, errors.New("multiple must be greater than 0")
+		}
+		var minAmount float64
+		if c.Min != nil {
+			minAmount = *c.Min
+		}
+		rule.match = roundAmountRule(c.Multiple, minAmount)
+	default:
+		return Rule{}, fmt.Errorf("unknown type %q, expected one of new_account, amount, fan_out, round_amount", c.Type)
+	}
+	return rule, nil
+}
@@ -0,0 +1,124 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"tiny-bank-api/store/entities"
+)
+
+// Transfer is what the rules know about a transfer being made.
+type Transfer struct {
+	Source entities.Account
+	Target entities.Account
+	Amount float64
+	Time   time.Time
+}
+
+// History gives the rules access to the past transfers. It is implemented by the store, and must be the
+// unit of work making the transfer so concurrent transfers are taken into account.
+type History interface {
+	CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error)
+	HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error)
+}
+
+// Rule is a check made on every transfer. When it matches, its decision applies to the transfer.
+type Rule struct {
+	Name     string
+	Decision entities.RiskDecision
+	// match returns why the rule matches the transfer, or an empty reason when it doesn't.
+	match func(ctx context.Context, history History, transfer Transfer) (string, error)
+}
+
+// Rules are evaluated in order. A matching rule deciding to deny or allow the transfer ends the
+// evaluation, while rules flagging it for review let the evaluation go on so a later rule can still deny it.
+type Rules []Rule
+
+// Evaluate runs the rules against the transfer. The transfer is allowed when no rule matches, and flagged
+// for review when a rule did so before the evaluation ended with an allow.
+func (r Rules) Evaluate(ctx context.Context, history History, transfer Transfer) (entities.RiskDecision, entities.RiskEvaluations, error) {
+	decision := entities.RiskDecisionAllow
+	evaluations := entities.RiskEvaluations{}
+	for _, rule := range r {
+		reason, err := rule.match(ctx, history, transfer)
+		if err != nil {
+			return "", nil, fmt.Errorf("error evaluating risk rule %s: %w", rule.Name, err)
+		}
+		if reason == "" {
+			continue
+		}
+		evaluations = append(evaluations, entities.RiskEvaluation{Rule: rule.Name, Decision: rule.Decision, Reason: reason})
+
+		switch rule.Decision {
+		case entities.RiskDecisionDeny:
+			return entities.RiskDecisionDeny, evaluations, nil
+		case entities.RiskDecisionReview:
+			decision = entities.RiskDecisionReview
+		case entities.RiskDecisionAllow:
+			return decision, evaluations, nil
+		}
+	}
+	return decision, evaluations, nil
+}
+
+// newAccountRule matches transfers from accounts younger than minAge, a cooling-off period for new accounts.
+func newAccountRule(minAge time.Duration) func(context.Context, History, Transfer) (string, error) {
+	return func(_ context.Context, _ History, transfer Transfer) (string, error) {
+		age := transfer.Time.Sub(transfer.Source.CreatedAt)
+		if age >= minAge {
+			return "", nil
+		}
+		return fmt.Sprintf("source account is %s old, less than %s", age.Round(time.Second), minAge), nil
+	}
+}
+
+// amountRule matches transfers of an amount between minAmount and maxAmount, when set.
+func amountRule(minAmount, maxAmount *float64) func(context.Context, History, Transfer) (string, error) {
+	return func(_ context.Context, _ History, transfer Transfer) (string, error) {
+		if minAmount != nil && transfer.Amount < *minAmount {
+			return "", nil
+		}
+		if maxAmount != nil && transfer.Amount > *maxAmount {
+			return "", nil
+		}
+		switch {
+		case minAmount != nil && maxAmount != nil:
+			return fmt.Sprintf("amount %.2f is between %.2f and %.2f", transfer.Amount, *minAmount, *maxAmount), nil
+		case minAmount != nil:
+			return fmt.Sprintf("amount %.2f is at least %.2f", transfer.Amount, *minAmount), nil
+		default:
+			return fmt.Sprintf("amount %.2f is at most %.2f", transfer.Amount, *maxAmount), nil
+		}
+	}
+}
+
+// fanOutRule matches transfers to a new target when the source account already started transferring to
+// maxNewTargets other accounts within the window.
+func fanOutRule(window time.Duration, maxNewTargets int64) func(context.Context, History, Transfer) (string, error) {
+	return func(ctx context.Context, history History, transfer Transfer) (string, error) {
+		known, err := history.HasTransferredTo(ctx, int64(transfer.Source.Id), int64(transfer.Target.Id))
+		if err != nil || known {
+			return "", err
+		}
+		count, err := history.CountNewTransferTargets(ctx, int64(transfer.Source.Id), transfer.Time.Add(-window))
+		if err != nil || count < maxNewTargets {
+			return "", err
+		}
+		return fmt.Sprintf("%d new targets within %s", count+1, window), nil
+	}
+}
+
+// roundAmountRule matches transfers of at least minAmount that are a multiple of multiple.
+func roundAmountRule(multiple, minAmount float64) func(context.Context, History, Transfer) (string, error) {
+	return func(_ context.Context, _ History, transfer Transfer) (string, error) {
+		if transfer.Amount < minAmount {
+			return "", nil
+		}
+		cents, multipleCents := math.Round(transfer.Amount*100), math.Round(multiple*100)
+		if math.Mod(cents, multipleCents) != 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("amount %.2f is a multiple of %.2f", transfer.Amount, multiple), nil
+	}
+}
@@ -0,0 +1,183 @@
+package sanctions
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Entry is a sanctioned person or organisation.
+type Entry struct {
+	Uid      string
+	Name     string
+	Type     string
+	Programs []string
+	// Aliases are the other names the entry is known by, they are screened like its name.
+	Aliases []string
+}
+
+// Names returns the name and the aliases of the entry.
+func (e Entry) Names() []string {
+	return append([]string{e.Name}, e.Aliases...)
+}
+
+// LoadFile reads a list of entries from a .csv or .xml file, see ParseCSV and ParseXML.
+func LoadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(f)
+	case ".xml":
+		return ParseXML(f)
+	default:
+		return nil, fmt.Errorf("unknown sanctions list format %q, expected .csv or .xml", filepath.Ext(path))
+	}
+}
+
+// csvColumns maps the accepted header names to the fields of Entry, the OFAC SDN names included.
+var csvColumns = map[string]string{
+	"uid": "uid", "id": "uid", "ent_num": "uid",
+	"name": "name", "sdn_name": "name",
+	"type": "type", "sdn_type": "type",
+	"program": "programs", "programs": "programs",
+	"aliases": "aliases", "alt_names": "aliases",
+}
+
+// ParseCSV parses a CSV list with a header row. Only the name column is required, the uid, type, programs
+// and aliases columns are optional, and programs and aliases hold ';' separated values, e.g.
+//
+//	uid,name,type,programs,aliases
+//	36,"AEROCARIBBEAN AIRLINES",entity,CUBA,"AERO-CARIBBEAN"
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		if field, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("the csv header has no name column")
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := Entry{
+			Uid:      field("uid"),
+			Name:     field("name"),
+			Type:     field("type"),
+			Programs: splitValues(field("programs")),
+			Aliases:  splitValues(field("aliases")),
+		}
+		if entry.Name == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d has no name", line)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func splitValues(values string) []string {
+	var split []string
+	for _, value := range strings.Split(values, ";") {
+		if value = strings.TrimSpace(value); value != "" {
+			split = append(split, value)
+		}
+	}
+	return split
+}
+
+type sdnList struct {
+	Entries []sdnEntry `xml:"sdnEntry"`
+}
+
+type sdnName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func (n sdnName) String() string {
+	return strings.TrimSpace(n.FirstName + " " + n.LastName)
+}
+
+type sdnEntry struct {
+	Uid string `xml:"uid"`
+	sdnName
+	Type     string    `xml:"sdnType"`
+	Programs []string  `xml:"programList>program"`
+	Aliases  []sdnName `xml:"akaList>aka"`
+}
+
+// ParseXML parses a list in the format of the OFAC SDN XML file, e.g.
+//
+//	<sdnList>
+//	  <sdnEntry>
+//	    <uid>36</uid>
+//	    <lastName>AEROCARIBBEAN AIRLINES</lastName>
+//	    <sdnType>Entity</sdnType>
+//	    <programList><program>CUBA</program></programList>
+//	    <akaList><aka><lastName>AERO-CARIBBEAN</lastName></aka></akaList>
+//	  </sdnEntry>
+//	</sdnList>
+func ParseXML(r io.Reader) ([]Entry, error) {
+	var list sdnList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(list.Entries))
+	for _, sdn := range list.Entries {
+		entry := Entry{
+			Uid:      sdn.Uid,
+			Name:     sdn.String(),
+			Type:     sdn.Type,
+			Programs: sdn.Programs,
+		}
+		if entry.Name == "" {
+			return nil, fmt.Errorf("entry %s has no name", sdn.Uid)
+		}
+		for _, aka := range sdn.Aliases {
+			if alias := aka.String(); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
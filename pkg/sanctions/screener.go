@@ -0,0 +1,157 @@
+package sanctions
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Match is an entry of the list whose name or one of its aliases is similar to a screened name.
+type Match struct {
+	Entry Entry
+	// MatchedName is the name or alias of the entry most similar to the screened name.
+	MatchedName string
+	Score       float64
+	// Block tells that the score reached the block threshold, the operation must be refused rather than
+	// queued for review.
+	Block bool
+}
+
+// Thresholds are the similarity scores from which names match the list.
+type Thresholds struct {
+	// Review is the score from which a match queues the operation for review.
+	Review float64
+	// Block is the score from which a match refuses the operation, it is disabled when above 1.
+	Block float64
+}
+
+type indexedName struct {
+	entry      int
+	name       string
+	normalized string
+}
+
+// Screener screens names against the sanctions list of a file, which is reloaded when it changes so a new
+// list can be imported without restarting. A nil Screener matches nothing.
+type Screener struct {
+	path       string
+	thresholds Thresholds
+
+	mu      sync.RWMutex
+	entries []Entry
+	names   []indexedName
+	modTime time.Time
+}
+
+// NewScreener loads the list of the file at path, see LoadFile.
+func NewScreener(path string, thresholds Thresholds) (*Screener, error) {
+	if thresholds.Review <= 0 || thresholds.Review > 1 {
+		return nil, fmt.Errorf("the review threshold must be between 0 and 1")
+	}
+	s := &Screener{path: path, thresholds: thresholds}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload reads the list again. The current list is kept if the file is invalid.
+func (s *Screener) Reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("error loading sanctions list: %w", err)
+	}
+	entries, err := LoadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("error parsing sanctions list from %s: %w", s.path, err)
+	}
+
+	var names []indexedName
+	for i, entry := range entries {
+		for _, name := range entry.Names() {
+			if normalized := Normalize(name); normalized != "" {
+				names = append(names, indexedName{entry: i, name: name, normalized: normalized})
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = entries
+	s.names = names
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// Run reloads the list every interval when the file was modified, until ctx is done.
+func (s *Screener) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(s.path)
+			if err != nil {
+				slog.Warn("Failed to check sanctions list", "error", err)
+				continue
+			}
+			s.mu.RLock()
+			modified := !info.ModTime().Equal(s.modTime)
+			s.mu.RUnlock()
+			if !modified {
+				continue
+			}
+			if err := s.Reload(); err != nil {
+				slog.Warn("Failed to reload sanctions list", "error", err)
+				continue
+			}
+			slog.Info("Reloaded sanctions list", "path", s.path)
+		}
+	}
+}
+
+// Screen returns the entries matching name, the best match first.
+func (s *Screener) Screen(name string) []Match {
+	if s == nil {
+		return nil
+	}
+	normalized := Normalize(name)
+	if normalized == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	best := map[int]Match{}
+	for _, candidate := range s.names {
+		score := Similarity(normalized, candidate.normalized)
+		if score < s.thresholds.Review {
+			continue
+		}
+		if match, ok := best[candidate.entry]; !ok || score > match.Score {
+			best[candidate.entry] = Match{
+				Entry:       s.entries[candidate.entry],
+				MatchedName: candidate.name,
+				Score:       score,
+				Block:       score >= s.thresholds.Block,
+			}
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Entry.Uid, b.Entry.Uid))
+	})
+	return matches
+}
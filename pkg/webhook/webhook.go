@@ -0,0 +1,211 @@
+// Package webhook delivers signed HTTP callbacks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// IdHeader carries the id of the delivery, which stays the same across its attempts.
+	IdHeader        = "X-Webhook-Id"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+
+	// signatureVersion prefixes the signatures, so the scheme can change without breaking the receivers.
+	signatureVersion = "v1="
+	// secretPrefix makes our secrets easy to spot, e.g. by secret scanners.
+	secretPrefix = "whsec_"
+)
+
+// The errors returned by Send when no response is received. They are stored on the deliveries shown to the
+// customers, so they don't tell more about the network of the service than whether the receiver answered.
+var (
+	ErrNonPublicAddress = errors.New("the receiver's address is not public")
+	ErrTimeout          = errors.New("the receiver didn't answer in time")
+	ErrUnreachable      = errors.New("the receiver could not be reached")
+)
+
+// nonPublicPrefixes are the shared or reserved ranges not covered by the netip.Addr predicates.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// Sign returns the signature of a body sent at timestamp: the hex HMAC-SHA256 of the Unix timestamp in
+// seconds, a dot and the body. Signing the timestamp lets receivers refuse replayed deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a delivery, refusing the ones sent more than
+// tolerance away from now.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", TimestampHeader)
+	}
+	timestamp := time.Unix(seconds, 0)
+	if now.Sub(timestamp).Abs() > tolerance {
+		return fmt.Errorf("timestamp %s is too far from now", timestamp.UTC().Format(time.RFC3339))
+	}
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(header.Get(SignatureHeader)), []byte(expected)) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// RetryPolicy spaces out the attempts of the failed deliveries exponentially.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts after which a delivery is given up.
+	MaxAttempts int
+	// Backoff is the delay after the first failed attempt, doubled after each following one.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Delay returns how long to wait after the given number of failed attempts.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
+
+// Sender POSTs the deliveries.
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a sender giving up on the receivers not answering within timeout. Unless
+// allowPrivateNetworks is set, it refuses to connect to loopback, private, link-local and other non-public
+// addresses, so a webhook can't reach the internal services.
+func NewSender(timeout time.Duration, allowPrivateNetworks bool) *Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		// the address is checked once resolved, right before connecting, so a host name resolving to a
+		// public address when the webhook is created and to a private one later is refused as well
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublic(addrPort.Addr()) {
+				return ErrNonPublicAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be dialed instead of the receiver, bypassing the check of its address
+	transport.Proxy = nil
+	return &Sender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// a redirect would resend the signed body to another URL than the subscribed one
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}}
+}
+
+// isPublic reports whether addr is a globally routable unicast address.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Send POSTs a signed JSON body to targetURL. It returns the status of the response, 0 when none was
+// received, and an error unless the status is 2xx.
+func (s *Sender) Send(ctx context.Context, targetURL, secret, id string, body []byte, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tiny-bank-api-webhooks")
+	req.Header.Set(IdHeader, id)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, transportError(err)
+	}
+	defer resp.Body.Close()
+	// draining the body lets the connection be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// transportError replaces the error of a request left without response, which tells the addresses and ports
+// dialed, by one of ErrNonPublicAddress, ErrTimeout and ErrUnreachable.
+func transportError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrNonPublicAddress):
+		return ErrNonPublicAddress
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	default:
+		return ErrUnreachable
+	}
+}
+
+// ValidateURL checks that rawURL is an absolute http or https URL.
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("url is invalid")
+	}
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("url must have a host")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tiny-bank-api/store/entities"
+)
+
+// ErrAuditChainBroken is returned by AuditChainVerifier when an audit event was modified or removed.
+var ErrAuditChainBroken = errors.New("audit chain broken")
+
+// chainAuditEvent links the event to the last one of the chain, nil when the chain is empty, and hashes it.
+func chainAuditEvent(last *entities.AuditEvent, event entities.AuditEvent) entities.AuditEvent {
+	event.Id = 1
+	event.PrevHash = ""
+	if last != nil {
+		event.Id = last.Id + 1
+		event.PrevHash = last.Hash
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	// postgres timestamps only keep microseconds, the hash must survive the round trip
+	event.CreatedAt = event.CreatedAt.UTC().Truncate(time.Microsecond)
+	event.Hash = event.ComputeHash()
+	return event
+}
+
+// AuditChainVerifier checks audit events one at a time, in the order of the chain.
+type AuditChainVerifier struct {
+	last *entities.AuditEvent
+}
+
+// Verify checks that the event is intact and directly follows the previously verified one.
+func (v *AuditChainVerifier) Verify(event entities.AuditEvent) error {
+	expectedId, expectedPrevHash := int64(1), ""
+	if v.last != nil {
+		expectedId, expectedPrevHash = v.last.Id+1, v.last.Hash
+	}
+
+	switch {
+	case event.Id != expectedId:
+		return fmt.Errorf("%w: expected event %d, got event %d", ErrAuditChainBroken, expectedId, event.Id)
+	case event.PrevHash != expectedPrevHash:
+		return fmt.Errorf("%w: event %d doesn't link to the previous event", ErrAuditChainBroken, event.Id)
+	case event.ComputeHash() != event.Hash:
+		return fmt.Errorf("%w: event %d was modified", ErrAuditChainBroken, event.Id)
+	}
+
+	v.last = &event
+	return nil
+}
+
+// Last returns the last verified event, nil when none was verified.
+func (v *AuditChainVerifier) Last() *entities.AuditEvent {
+	return v.last
+}
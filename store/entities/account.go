@@ -4,12 +4,35 @@ import (
 	"time"
 )
 
+type AccountStatus string
+
+const (
+	AccountStatusActive AccountStatus = "active"
+	AccountStatusFrozen AccountStatus = "frozen"
+)
+
+// DefaultAccountTier is the tier of new accounts, it sets their transfer limits.
+const DefaultAccountTier = "standard"
+
 type Account struct {
-	Id        int       `db:"id"`
-	Name      string    `db:"name"`
-	Balance   float64   `db:"balance"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	Id          int           `db:"id"`
+	Name        string        `db:"name"`
+	Balance     float64       `db:"balance"`
+	HeldBalance float64       `db:"held_balance"`
+	Status      AccountStatus `db:"status"`
+	Version     int64         `db:"version"`
+	Metadata    StringMap     `db:"metadata"`
+	Labels      StringMap     `db:"labels"`
+	OwnerId     *int64        `db:"owner_id"`
+	Tier        string        `db:"tier"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+}
+
+// AvailableBalance is the part of the balance that can be spent, HeldBalance being held for the transfers
+// pending approval.
+func (a Account) AvailableBalance() float64 {
+	return a.Balance - a.HeldBalance
 }
 
 func NewAccount(name string, balance float64) Account {
@@ -17,6 +40,11 @@ func NewAccount(name string, balance float64) Account {
 	return Account{
 		Name:      name,
 		Balance:   balance,
+		Status:    AccountStatusActive,
+		Version:   1,
+		Metadata:  StringMap{},
+		Labels:    StringMap{},
+		Tier:      DefaultAccountTier,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+)
+
+// AccountChange records a single field of an account changed by an actor.
+type AccountChange struct {
+	Id        int64     `db:"id"`
+	AccountId int64     `db:"account_id"`
+	Actor     string    `db:"actor"`
+	Field     string    `db:"field"`
+	OldValue  *string   `db:"old_value"`
+	NewValue  *string   `db:"new_value"`
+	ChangedAt time.Time `db:"changed_at"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+)
+
+// APIKey is an API key as stored, only the SHA-256 hash of the secret is kept.
+type APIKey struct {
+	Id     int64      `db:"id"`
+	Name   string     `db:"name"`
+	Prefix string     `db:"prefix"`
+	Hash   string     `db:"hash"`
+	Scopes StringList `db:"scopes"`
+	// CustomerId binds the key to a customer, who can then only access their own accounts.
+	CustomerId *int64     `db:"customer_id"`
+	CreatedAt  time.Time  `db:"created_at"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+// Usable reports whether the key can still authenticate requests at the given time.
+func (k APIKey) Usable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
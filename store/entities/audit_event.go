@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditEvent records a change of state. Events are chained: each one includes the hash of the previous
+// one in its own hash, so modifying or removing an event breaks the chain.
+type AuditEvent struct {
+	// Id is the position of the event in the chain, starting at 1 without gaps.
+	Id        int64  `db:"id"`
+	Actor     string `db:"actor"`
+	Operation string `db:"operation"`
+	RequestId string `db:"request_id"`
+	ClientIP  string `db:"client_ip"`
+	// Before and After are JSON snapshots of what changed, Before is nil for creations.
+	Before    *string   `db:"before"`
+	After     *string   `db:"after"`
+	CreatedAt time.Time `db:"created_at"`
+	// PrevHash is the hash of the previous event, empty for the first one.
+	PrevHash string `db:"prev_hash"`
+	Hash     string `db:"hash"`
+}
+
+// NewAuditEvent creates an event with JSON snapshots of before and after, which are nil when the resource
+// was created or deleted.
+func NewAuditEvent(actor, operation string, before, after any) (AuditEvent, error) {
+	event := AuditEvent{
+		Actor:     actor,
+		Operation: operation,
+		CreatedAt: time.Now(),
+	}
+	var err error
+	if event.Before, err = auditSnapshot(before); err != nil {
+		return AuditEvent{}, err
+	}
+	if event.After, err = auditSnapshot(after); err != nil {
+		return AuditEvent{}, err
+	}
+	return event, nil
+}
+
+func auditSnapshot(value any) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := string(raw)
+	return &snapshot, nil
+}
+
+// ComputeHash hashes every field of the event but Hash itself.
+func (e AuditEvent) ComputeHash() string {
+	// encoding the fields as a JSON array keeps them unambiguously delimited
+	fields, _ := json.Marshal([]any{
+		e.Id,
+		e.PrevHash,
+		e.Actor,
+		e.Operation,
+		e.RequestId,
+		e.ClientIP,
+		e.Before,
+		e.After,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(fields)
+	return hex.EncodeToString(sum[:])
+}
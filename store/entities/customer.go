@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+// Customer is the owner of accounts.
+type Customer struct {
+	Id   int64  `db:"id"`
+	Name string `db:"name"`
+	// ExternalId is the subject of the customer at the identity provider, used to match bearer tokens.
+	ExternalId *string   `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// IdempotencyKey is the Idempotency-Key of a request, along with the response saved for its retries.
+type IdempotencyKey struct {
+	// Subject is the principal who sent the request, the keys of different principals don't collide.
+	Subject   string `db:"subject"`
+	Key       string `db:"key"`
+	Operation string `db:"operation"`
+	// RequestHash identifies the request, so the key can't be reused for a different one.
+	RequestHash         string    `db:"request_hash"`
+	ResponseStatus      int       `db:"response_status"`
+	ResponseContentType string    `db:"response_content_type"`
+	ResponseBody        string    `db:"response_body"`
+	CreatedAt           time.Time `db:"created_at"`
+}
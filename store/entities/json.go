@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringMap is a map stored as a JSON object column.
+type StringMap map[string]string
+
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *StringMap) Scan(src any) error {
+	b, err := jsonBytes(src)
+	if err != nil || b == nil {
+		*m = StringMap{}
+		return err
+	}
+	return json.Unmarshal(b, m)
+}
+
+// StringList is a list stored as a JSON array column.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(src any) error {
+	b, err := jsonBytes(src)
+	if err != nil || b == nil {
+		*l = StringList{}
+		return err
+	}
+	return json.Unmarshal(b, l)
+}
+
+// jsonBytes returns the raw JSON of a column, postgres drivers return []byte while sqlite returns string.
+func jsonBytes(src any) ([]byte, error) {
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, fmt.Errorf("cannot scan %T as JSON", src)
+	}
+}
+
+// Int64List is a list of ids stored as a JSON array column.
+type Int64List []int64
+
+func (l Int64List) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *Int64List) Scan(src any) error {
+	b, err := jsonBytes(src)
+	if err != nil || b == nil {
+		*l = Int64List{}
+		return err
+	}
+	return json.Unmarshal(b, l)
+}
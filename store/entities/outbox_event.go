@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEventType is the kind of change a domain event notifies.
+type OutboxEventType string
+
+const (
+	OutboxEventAccountCreated    OutboxEventType = "AccountCreated"
+	OutboxEventBalanceAdded      OutboxEventType = "BalanceAdded"
+	OutboxEventTransferCompleted OutboxEventType = "TransferCompleted"
+	OutboxEventAccountFrozen     OutboxEventType = "AccountFrozen"
+)
+
+// OutboxEvent is a domain event stored with the change it notifies, until it is published to the sinks.
+type OutboxEvent struct {
+	Id   int64           `db:"id"`
+	Type OutboxEventType `db:"type"`
+	// AccountId is the account the event is about, events are published in order per account. Transfers
+	// are about their source account.
+	AccountId int64 `db:"account_id"`
+	// RelatedAccountId is the other account the event is about, the target of transfers.
+	RelatedAccountId *int64 `db:"related_account_id"`
+	// Payload is the JSON representation of the resource after the change.
+	Payload     string     `db:"payload"`
+	CreatedAt   time.Time  `db:"created_at"`
+	PublishedAt *time.Time `db:"published_at"`
+	// Attempts counts the failed attempts to publish the event.
+	Attempts int `db:"attempts"`
+	// NextAttemptAt is when the relay may claim the event again, after a failed attempt or the lease of a
+	// relay publishing it. The following events of its account wait for it.
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+}
+
+// NewOutboxEvent creates an event with a JSON payload.
+func NewOutboxEvent(eventType OutboxEventType, accountId int64, payload any) (OutboxEvent, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return OutboxEvent{}, err
+	}
+	return OutboxEvent{
+		Type:      eventType,
+		AccountId: accountId,
+		Payload:   string(raw),
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// RiskDecision is the outcome of the risk rules for a transfer.
+type RiskDecision string
+
+const (
+	RiskDecisionAllow RiskDecision = "allow"
+	// RiskDecisionReview holds the transfer until a back-office user approves or rejects it.
+	RiskDecisionReview RiskDecision = "review"
+	RiskDecisionDeny   RiskDecision = "deny"
+)
+
+// RiskEvaluation records a risk rule matching a transfer.
+type RiskEvaluation struct {
+	Rule     string       `json:"rule"`
+	Decision RiskDecision `json:"decision"`
+	Reason   string       `json:"reason"`
+}
+
+// RiskEvaluations are the rules that matched a transfer, in evaluation order, stored as a JSON array column.
+type RiskEvaluations []RiskEvaluation
+
+func (e RiskEvaluations) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (e *RiskEvaluations) Scan(src any) error {
+	b, err := jsonBytes(src)
+	if err != nil || b == nil {
+		*e = RiskEvaluations{}
+		return err
+	}
+	return json.Unmarshal(b, e)
+}
@@ -0,0 +1,12 @@
+package entities
+
+import (
+	"time"
+)
+
+// RoleAssignment grants a back-office role to a principal, identified by its subject, e.g. "apikey:3".
+type RoleAssignment struct {
+	Subject   string    `db:"subject"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
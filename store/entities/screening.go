@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// ScreeningSubject is what a screening hit was found on.
+type ScreeningSubject string
+
+const (
+	ScreeningSubjectAccount  ScreeningSubject = "account"
+	ScreeningSubjectTransfer ScreeningSubject = "transfer"
+)
+
+type ScreeningHitStatus string
+
+const (
+	// ScreeningHitStatusPending hits wait for compliance staff, their account is frozen or their transfer
+	// pending review meanwhile.
+	ScreeningHitStatusPending ScreeningHitStatus = "pending"
+	// ScreeningHitStatusCleared hits were false positives.
+	ScreeningHitStatusCleared ScreeningHitStatus = "cleared"
+	// ScreeningHitStatusConfirmed hits are true matches, their account stays frozen or their transfer is
+	// blocked.
+	ScreeningHitStatusConfirmed ScreeningHitStatus = "confirmed"
+	// ScreeningHitStatusBlocked hits were close enough to refuse the operation right away, they are kept for
+	// the record.
+	ScreeningHitStatusBlocked ScreeningHitStatus = "blocked"
+)
+
+// ScreeningHit is a name screened on an operation that matched an entry of the sanctions list.
+type ScreeningHit struct {
+	Id          int64            `db:"id"`
+	SubjectType ScreeningSubject `db:"subject_type"`
+	// SubjectId is the id of the account or transfer, nil when the operation was refused before creating it.
+	SubjectId    *int64             `db:"subject_id"`
+	Operation    string             `db:"operation"`
+	ScreenedName string             `db:"screened_name"`
+	EntryUid     string             `db:"entry_uid"`
+	EntryName    string             `db:"entry_name"`
+	MatchedName  string             `db:"matched_name"`
+	Score        float64            `db:"score"`
+	Status       ScreeningHitStatus `db:"status"`
+	DecidedBy    *string            `db:"decided_by"`
+	DecidedAt    *time.Time         `db:"decided_at"`
+	CreatedAt    time.Time          `db:"created_at"`
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"cmp"
+	"time"
+)
+
+type TransferStatus string
+
+const (
+	TransferStatusCompleted TransferStatus = "completed"
+	// TransferStatusDeclined transfers were denied by the risk rules, no money moved.
+	TransferStatusDeclined TransferStatus = "declined"
+	// TransferStatusPendingApproval transfers wait for another user to approve them, their amount is held
+	// on the source account meanwhile.
+	TransferStatusPendingApproval TransferStatus = "pending_approval"
+	TransferStatusRejected        TransferStatus = "rejected"
+	TransferStatusExpired         TransferStatus = "expired"
+	// TransferStatusPendingReview transfers matched the sanctions list and wait for compliance staff, their
+	// amount is held on the source account meanwhile.
+	TransferStatusPendingReview TransferStatus = "pending_review"
+	// TransferStatusBlocked transfers were confirmed to match the sanctions list, no money moved.
+	TransferStatusBlocked TransferStatus = "blocked"
+)
+
+// Transfer is a movement of money between two accounts, kept to enforce the transfer limits and to record
+// the decision of the risk rules. Only completed transfers count towards the limits.
+type Transfer struct {
+	Id              int64           `db:"id"`
+	SourceAccountId int64           `db:"source_account_id"`
+	TargetAccountId int64           `db:"target_account_id"`
+	Amount          float64         `db:"amount"`
+	Status          TransferStatus  `db:"status"`
+	RiskDecision    RiskDecision    `db:"risk_decision"`
+	RiskEvaluations RiskEvaluations `db:"risk_evaluations"`
+	// RequestedBy is the subject of the principal who made the transfer.
+	RequestedBy string `db:"requested_by"`
+	// DecidedBy is the subject of the principal who approved or rejected the transfer.
+	DecidedBy *string `db:"decided_by"`
+	// ExpiresAt is when a transfer pending approval expires.
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// TransferTotals sums up the transfers made from an account over a period.
+type TransferTotals struct {
+	Amount float64 `db:"amount"`
+	Count  int64   `db:"count"`
+}
+
+// TransferLimits are the velocity limits of the transfers made from an account, nil limits don't apply.
+// The amounts are totals over rolling windows of 24 hours, 7 days and 30 days.
+type TransferLimits struct {
+	MaxAmount     *float64 `db:"max_amount"`
+	DailyAmount   *float64 `db:"daily_amount"`
+	WeeklyAmount  *float64 `db:"weekly_amount"`
+	MonthlyAmount *float64 `db:"monthly_amount"`
+	DailyCount    *int64   `db:"daily_count"`
+}
+
+// Override returns the limits with the ones set in overrides replacing them.
+func (l TransferLimits) Override(overrides TransferLimits) TransferLimits {
+	return TransferLimits{
+		MaxAmount:     cmp.Or(overrides.MaxAmount, l.MaxAmount),
+		DailyAmount:   cmp.Or(overrides.DailyAmount, l.DailyAmount),
+		WeeklyAmount:  cmp.Or(overrides.WeeklyAmount, l.WeeklyAmount),
+		MonthlyAmount: cmp.Or(overrides.MonthlyAmount, l.MonthlyAmount),
+		DailyCount:    cmp.Or(overrides.DailyCount, l.DailyCount),
+	}
+}
+
+// TierTransferLimits are the transfer limits of the accounts of a tier.
+type TierTransferLimits struct {
+	Tier string `db:"tier"`
+	TransferLimits
+}
@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// Webhook subscribes a URL to the domain events of some accounts.
+type Webhook struct {
+	Id         int64      `db:"id"`
+	URL        string     `db:"url"`
+	EventTypes StringList `db:"event_types"`
+	// AccountIds restricts the events delivered to the ones of these accounts, empty for all of them.
+	AccountIds Int64List `db:"account_ids"`
+	// Secret signs the deliveries, it is kept in clear as it is needed to compute the signatures.
+	Secret string `db:"secret"`
+	// OwnerId is the customer who created the webhook, who only receives the events of their accounts. It is
+	// nil for the webhooks of back-office users.
+	OwnerId   *int64    `db:"owner_id"`
+	CreatedBy string    `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending deliveries wait for their next attempt.
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryStatusDead deliveries failed every attempt, they are only delivered again on request.
+	WebhookDeliveryStatusDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is the delivery of a domain event to a webhook.
+type WebhookDelivery struct {
+	Id        int64           `db:"id"`
+	WebhookId int64           `db:"webhook_id"`
+	EventId   int64           `db:"event_id"`
+	EventType OutboxEventType `db:"event_type"`
+	// Payload is the JSON body POSTed to the webhook.
+	Payload string                `db:"payload"`
+	Status  WebhookDeliveryStatus `db:"status"`
+	// Attempts counts the attempts made since the delivery was last queued.
+	Attempts      int        `db:"attempts"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+	LastAttemptAt *time.Time `db:"last_attempt_at"`
+	// LastResponseStatus is the HTTP status of the last attempt, nil when no response was received.
+	LastResponseStatus *int       `db:"last_response_status"`
+	LastError          *string    `db:"last_error"`
+	DeliveredAt        *time.Time `db:"delivered_at"`
+	CreatedAt          time.Time  `db:"created_at"`
+}
@@ -0,0 +1,967 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"tiny-bank-api/store/entities"
+)
+
+// MemoryStore is a Store that keeps everything in process memory. It is meant for tests and local
+// development, all the data is lost when the process exits.
+type MemoryStore struct {
+	mu       *sync.Mutex
+	accounts *memoryAccounts
+	apiKeys  *[]entities.APIKey
+	roles    *[]entities.RoleAssignment
+}
+
+var _ Store = MemoryStore{}
+
+func NewMemoryStore() MemoryStore {
+	return MemoryStore{
+		mu: &sync.Mutex{},
+		accounts: &memoryAccounts{
+			byId:              map[int64]entities.Account{},
+			webhooks:          map[int64]entities.Webhook{},
+			webhookDeliveries: map[int64]entities.WebhookDelivery{},
+			tierLimits:        map[string]entities.TransferLimits{},
+			accountLimits:     map[int64]entities.TransferLimits{},
+			idempotencyKeys:   map[idempotencyKeyId]entities.IdempotencyKey{},
+		},
+		apiKeys: &[]entities.APIKey{},
+		roles:   &[]entities.RoleAssignment{},
+	}
+}
+
+func (s MemoryStore) CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateAccount(ctx, account)
+}
+
+func (s MemoryStore) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetAccountById(ctx, accountId)
+}
+
+func (s MemoryStore) GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetAccounts(ctx, filter)
+}
+
+func (s MemoryStore) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.AddBalance(ctx, accountId, amount)
+}
+
+func (s MemoryStore) SubtractBalance(ctx context.Context, accountId int64, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.SubtractBalance(ctx, accountId, amount)
+}
+
+func (s MemoryStore) HoldBalance(ctx context.Context, accountId int64, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.HoldBalance(ctx, accountId, amount)
+}
+
+func (s MemoryStore) ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.ReleaseHeldBalance(ctx, accountId, amount)
+}
+
+func (s MemoryStore) UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.UpdateAccount(ctx, accountId, ifVersion, update)
+}
+
+func (s MemoryStore) AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.AddAccountChanges(ctx, changes)
+}
+
+func (s MemoryStore) GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetAccountChanges(ctx, accountId)
+}
+
+func (s MemoryStore) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateTransfer(ctx, transfer)
+}
+
+func (s MemoryStore) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetOutgoingTransferTotals(ctx, accountId, since)
+}
+
+func (s MemoryStore) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetTransferById(ctx, transferId)
+}
+
+func (s MemoryStore) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetTransfers(ctx, filter)
+}
+
+func (s MemoryStore) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.UpdateTransfer(ctx, transfer)
+}
+
+func (s MemoryStore) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateScreeningHit(ctx, hit)
+}
+
+func (s MemoryStore) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetScreeningHitById(ctx, hitId)
+}
+
+func (s MemoryStore) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetScreeningHits(ctx, filter)
+}
+
+func (s MemoryStore) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.UpdateScreeningHit(ctx, hit)
+}
+
+func (s MemoryStore) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.AddOutboxEvent(ctx, event)
+}
+
+func (s MemoryStore) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.ClaimOutboxEvents(ctx, limit, now, leasedUntil)
+}
+
+func (s MemoryStore) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.MarkOutboxEventsPublished(ctx, eventIds, publishedAt)
+}
+
+func (s MemoryStore) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.DeferOutboxEvent(ctx, eventId, attempts, nextAttemptAt)
+}
+
+func (s MemoryStore) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetOutboxEvents(ctx, filter)
+}
+
+func (s MemoryStore) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetLastOutboxEventId(ctx)
+}
+
+func (s MemoryStore) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateWebhook(ctx, webhook)
+}
+
+func (s MemoryStore) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetWebhookById(ctx, webhookId)
+}
+
+func (s MemoryStore) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetWebhooks(ctx, filter)
+}
+
+func (s MemoryStore) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.DeleteWebhook(ctx, webhookId)
+}
+
+func (s MemoryStore) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (s MemoryStore) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetWebhookDeliveryById(ctx, deliveryId)
+}
+
+func (s MemoryStore) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetWebhookDeliveries(ctx, filter)
+}
+
+func (s MemoryStore) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.UpdateWebhookDelivery(ctx, delivery)
+}
+
+func (s MemoryStore) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CountNewTransferTargets(ctx, sourceAccountId, since)
+}
+
+func (s MemoryStore) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.HasTransferredTo(ctx, sourceAccountId, targetAccountId)
+}
+
+func (s MemoryStore) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetTierTransferLimits(ctx, tier)
+}
+
+func (s MemoryStore) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.SetTierTransferLimits(ctx, tier, limits)
+}
+
+func (s MemoryStore) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetAccountTransferLimits(ctx, accountId)
+}
+
+func (s MemoryStore) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.SetAccountTransferLimits(ctx, accountId, limits)
+}
+
+func (s MemoryStore) GetTiers(_ context.Context) ([]entities.TierTransferLimits, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tiers := make([]entities.TierTransferLimits, 0, len(s.accounts.tierLimits))
+	for _, tier := range slices.Sorted(maps.Keys(s.accounts.tierLimits)) {
+		tiers = append(tiers, entities.TierTransferLimits{Tier: tier, TransferLimits: s.accounts.tierLimits[tier]})
+	}
+	return tiers, nil
+}
+
+func (s MemoryStore) CreateAPIKey(_ context.Context, key entities.APIKey) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key.Id = int64(len(*s.apiKeys) + 1)
+	*s.apiKeys = append(*s.apiKeys, key)
+	return key.Id, nil
+}
+
+func (s MemoryStore) GetAPIKeyByHash(_ context.Context, hash string) (entities.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range *s.apiKeys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return entities.APIKey{}, ErrAPIKeyNotFound
+}
+
+func (s MemoryStore) LookupAPIKey(ctx context.Context, hash string) (entities.APIKey, bool, error) {
+	key, err := s.GetAPIKeyByHash(ctx, hash)
+	return lookup(key, err, ErrAPIKeyNotFound)
+}
+
+func (s MemoryStore) GetAPIKeys(_ context.Context) ([]entities.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(*s.apiKeys), nil
+}
+
+func (s MemoryStore) RevokeAPIKey(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > int64(len(*s.apiKeys)) {
+		return ErrAPIKeyNotFound
+	}
+	key := &(*s.apiKeys)[id-1]
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+	}
+	return nil
+}
+
+func (s MemoryStore) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.CreateCustomer(ctx, customer)
+}
+
+func (s MemoryStore) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetCustomers(ctx)
+}
+
+func (s MemoryStore) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetCustomerById(ctx, customerId)
+}
+
+func (s MemoryStore) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.GetCustomerByExternalId(ctx, externalId)
+}
+
+func (s MemoryStore) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := s.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+func (s MemoryStore) AssignRole(_ context.Context, subject, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, assignment := range *s.roles {
+		if assignment.Subject == subject && assignment.Role == role {
+			return nil
+		}
+	}
+	*s.roles = append(*s.roles, entities.RoleAssignment{Subject: subject, Role: role, CreatedAt: time.Now()})
+	return nil
+}
+
+func (s MemoryStore) UnassignRole(_ context.Context, subject, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, assignment := range *s.roles {
+		if assignment.Subject == subject && assignment.Role == role {
+			*s.roles = slices.Delete(*s.roles, i, i+1)
+			return nil
+		}
+	}
+	return ErrRoleAssignmentNotFound
+}
+
+func (s MemoryStore) GetRoles(_ context.Context, subject string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var roles []string
+	for _, assignment := range *s.roles {
+		if assignment.Subject == subject {
+			roles = append(roles, assignment.Role)
+		}
+	}
+	slices.Sort(roles)
+	return roles, nil
+}
+
+func (s MemoryStore) GetRoleAssignments(_ context.Context) ([]entities.RoleAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assignments := slices.Clone(*s.roles)
+	slices.SortFunc(assignments, func(a, b entities.RoleAssignment) int {
+		if c := strings.Compare(a.Subject, b.Subject); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Role, b.Role)
+	})
+	return assignments, nil
+}
+
+func (s MemoryStore) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.AppendAuditEvent(ctx, event)
+}
+
+func (s MemoryStore) GetAuditEvents(_ context.Context, afterId int64, limit int) ([]entities.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []entities.AuditEvent
+	for _, event := range s.accounts.auditEvents {
+		if event.Id > afterId && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// RunInTx holds the store lock for the whole unit of work, and applies fn to a copy of the data that
+// only replaces the live one when fn succeeds.
+func (s MemoryStore) RunInTx(ctx context.Context, fn func(tx Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.accounts.clone()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	*s.accounts = *tx
+	return nil
+}
+
+// memoryAccounts implements Tx without any locking, callers must hold the MemoryStore lock.
+// Accounts are never mutated in place, updates store a modified copy, so cloning the maps is enough to
+// isolate a unit of work.
+type memoryAccounts struct {
+	byId          map[int64]entities.Account
+	lastId        int64
+	customers     []entities.Customer
+	changes       []entities.AccountChange
+	auditEvents   []entities.AuditEvent
+	transfers     []entities.Transfer
+	screeningHits []entities.ScreeningHit
+	outboxEvents  []entities.OutboxEvent
+	// webhooks and their deliveries can be deleted, so they are kept by id
+	webhooks          map[int64]entities.Webhook
+	lastWebhookId     int64
+	webhookDeliveries map[int64]entities.WebhookDelivery
+	lastDeliveryId    int64
+	// the limits are stored by value, so cloning their maps is enough too
+	tierLimits      map[string]entities.TransferLimits
+	accountLimits   map[int64]entities.TransferLimits
+	idempotencyKeys map[idempotencyKeyId]entities.IdempotencyKey
+}
+
+type idempotencyKeyId struct {
+	subject, key string
+}
+
+func (a *memoryAccounts) clone() *memoryAccounts {
+	return &memoryAccounts{
+		byId:              maps.Clone(a.byId),
+		lastId:            a.lastId,
+		customers:         slices.Clone(a.customers),
+		changes:           slices.Clone(a.changes),
+		auditEvents:       slices.Clone(a.auditEvents),
+		transfers:         slices.Clone(a.transfers),
+		screeningHits:     slices.Clone(a.screeningHits),
+		outboxEvents:      slices.Clone(a.outboxEvents),
+		webhooks:          maps.Clone(a.webhooks),
+		lastWebhookId:     a.lastWebhookId,
+		webhookDeliveries: maps.Clone(a.webhookDeliveries),
+		lastDeliveryId:    a.lastDeliveryId,
+		tierLimits:        maps.Clone(a.tierLimits),
+		accountLimits:     maps.Clone(a.accountLimits),
+		idempotencyKeys:   maps.Clone(a.idempotencyKeys),
+	}
+}
+
+func (a *memoryAccounts) CreateAccount(_ context.Context, account entities.Account) (entities.Account, error) {
+	a.lastId++
+	account.Id = int(a.lastId)
+	a.byId[a.lastId] = account
+	return account, nil
+}
+
+func (a *memoryAccounts) GetAccountById(_ context.Context, accountId int64) (entities.Account, error) {
+	account, ok := a.byId[accountId]
+	if !ok {
+		return entities.Account{}, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (a *memoryAccounts) GetAccounts(_ context.Context, filter AccountFilter) ([]entities.Account, error) {
+	accounts := make([]entities.Account, 0, len(a.byId))
+	for _, id := range slices.Sorted(maps.Keys(a.byId)) {
+		account := a.byId[id]
+		if filter.OwnerId != nil && (account.OwnerId == nil || *account.OwnerId != *filter.OwnerId) {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (a *memoryAccounts) AddBalance(_ context.Context, accountId int64, amount float64) error {
+	return a.updateBalance(accountId, amount)
+}
+
+func (a *memoryAccounts) SubtractBalance(_ context.Context, accountId int64, amount float64) error {
+	return a.updateBalance(accountId, -amount)
+}
+
+func (a *memoryAccounts) HoldBalance(_ context.Context, accountId int64, amount float64) error {
+	return a.updateHeldBalance(accountId, amount)
+}
+
+func (a *memoryAccounts) ReleaseHeldBalance(_ context.Context, accountId int64, amount float64) error {
+	return a.updateHeldBalance(accountId, -amount)
+}
+
+func (a *memoryAccounts) UpdateAccount(_ context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	account, ok := a.byId[accountId]
+	if !ok {
+		return entities.Account{}, ErrAccountNotFound
+	}
+	if ifVersion != nil && *ifVersion != account.Version {
+		return entities.Account{}, ErrVersionMismatch
+	}
+
+	if update.Name != nil {
+		account.Name = *update.Name
+	}
+	if update.Status != nil {
+		account.Status = *update.Status
+	}
+	if update.Metadata != nil {
+		account.Metadata = maps.Clone(update.Metadata)
+	}
+	if update.Labels != nil {
+		account.Labels = maps.Clone(update.Labels)
+	}
+	if update.Tier != nil {
+		account.Tier = *update.Tier
+	}
+	account.Version++
+	account.UpdatedAt = time.Now()
+	a.byId[accountId] = account
+	return account, nil
+}
+
+func (a *memoryAccounts) AddAccountChanges(_ context.Context, changes []entities.AccountChange) error {
+	for _, change := range changes {
+		change.Id = int64(len(a.changes) + 1)
+		a.changes = append(a.changes, change)
+	}
+	return nil
+}
+
+func (a *memoryAccounts) GetAccountChanges(_ context.Context, accountId int64) ([]entities.AccountChange, error) {
+	var changes []entities.AccountChange
+	for _, change := range a.changes {
+		if change.AccountId == accountId {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (a *memoryAccounts) CreateCustomer(_ context.Context, customer entities.Customer) (entities.Customer, error) {
+	customer.Id = int64(len(a.customers) + 1)
+	a.customers = append(a.customers, customer)
+	return customer, nil
+}
+
+func (a *memoryAccounts) GetCustomers(_ context.Context) ([]entities.Customer, error) {
+	return slices.Clone(a.customers), nil
+}
+
+func (a *memoryAccounts) GetCustomerById(_ context.Context, customerId int64) (entities.Customer, error) {
+	if customerId < 1 || customerId > int64(len(a.customers)) {
+		return entities.Customer{}, ErrCustomerNotFound
+	}
+	return a.customers[customerId-1], nil
+}
+
+func (a *memoryAccounts) GetCustomerByExternalId(_ context.Context, externalId string) (entities.Customer, error) {
+	for _, customer := range a.customers {
+		if customer.ExternalId != nil && *customer.ExternalId == externalId {
+			return customer, nil
+		}
+	}
+	return entities.Customer{}, ErrCustomerNotFound
+}
+
+func (a *memoryAccounts) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := a.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+func (a *memoryAccounts) AppendAuditEvent(_ context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	var last *entities.AuditEvent
+	if len(a.auditEvents) > 0 {
+		last = &a.auditEvents[len(a.auditEvents)-1]
+	}
+	event = chainAuditEvent(last, event)
+	a.auditEvents = append(a.auditEvents, event)
+	return event, nil
+}
+
+func (a *memoryAccounts) CreateTransfer(_ context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	transfer.Id = int64(len(a.transfers) + 1)
+	transfer.Amount = roundCents(transfer.Amount)
+	a.transfers = append(a.transfers, transfer)
+	return transfer, nil
+}
+
+func (a *memoryAccounts) GetOutgoingTransferTotals(_ context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	var totals entities.TransferTotals
+	for _, transfer := range a.transfers {
+		if transfer.SourceAccountId == accountId && transfer.Status == entities.TransferStatusCompleted && transfer.CreatedAt.After(since) {
+			totals.Amount = roundCents(totals.Amount + transfer.Amount)
+			totals.Count++
+		}
+	}
+	return totals, nil
+}
+
+func (a *memoryAccounts) GetTransferById(_ context.Context, transferId int64) (entities.Transfer, error) {
+	if transferId < 1 || transferId > int64(len(a.transfers)) {
+		return entities.Transfer{}, ErrTransferNotFound
+	}
+	return a.transfers[transferId-1], nil
+}
+
+func (a *memoryAccounts) GetTransfers(_ context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	var transfers []entities.Transfer
+	for _, transfer := range a.transfers {
+		if filter.SourceAccountId != nil && transfer.SourceAccountId != *filter.SourceAccountId {
+			continue
+		}
+		if filter.Status != nil && transfer.Status != *filter.Status {
+			continue
+		}
+		if filter.ExpiresBefore != nil && (transfer.ExpiresAt == nil || !transfer.ExpiresAt.Before(*filter.ExpiresBefore)) {
+			continue
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+func (a *memoryAccounts) UpdateTransfer(_ context.Context, transfer entities.Transfer) error {
+	if transfer.Id < 1 || transfer.Id > int64(len(a.transfers)) {
+		return ErrTransferNotFound
+	}
+	current := &a.transfers[transfer.Id-1]
+	current.Status = transfer.Status
+	current.RiskDecision = transfer.RiskDecision
+	current.RiskEvaluations = transfer.RiskEvaluations
+	current.DecidedBy = transfer.DecidedBy
+	current.ExpiresAt = transfer.ExpiresAt
+	return nil
+}
+
+func (a *memoryAccounts) CreateScreeningHit(_ context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	hit.Id = int64(len(a.screeningHits) + 1)
+	a.screeningHits = append(a.screeningHits, hit)
+	return hit, nil
+}
+
+func (a *memoryAccounts) GetScreeningHitById(_ context.Context, hitId int64) (entities.ScreeningHit, error) {
+	if hitId < 1 || hitId > int64(len(a.screeningHits)) {
+		return entities.ScreeningHit{}, ErrScreeningHitNotFound
+	}
+	return a.screeningHits[hitId-1], nil
+}
+
+func (a *memoryAccounts) GetScreeningHits(_ context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	var hits []entities.ScreeningHit
+	for _, hit := range a.screeningHits {
+		if filter.Status != nil && hit.Status != *filter.Status {
+			continue
+		}
+		if filter.SubjectType != nil && hit.SubjectType != *filter.SubjectType {
+			continue
+		}
+		if filter.SubjectId != nil && (hit.SubjectId == nil || *hit.SubjectId != *filter.SubjectId) {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+func (a *memoryAccounts) UpdateScreeningHit(_ context.Context, hit entities.ScreeningHit) error {
+	if hit.Id < 1 || hit.Id > int64(len(a.screeningHits)) {
+		return ErrScreeningHitNotFound
+	}
+	current := &a.screeningHits[hit.Id-1]
+	current.Status = hit.Status
+	current.DecidedBy = hit.DecidedBy
+	current.DecidedAt = hit.DecidedAt
+	return nil
+}
+
+func (a *memoryAccounts) AddOutboxEvent(_ context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	event.Id = int64(len(a.outboxEvents) + 1)
+	a.outboxEvents = append(a.outboxEvents, event)
+	return event, nil
+}
+
+func (a *memoryAccounts) ClaimOutboxEvents(_ context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	var events []entities.OutboxEvent
+	// whether the accounts met so far are claimed, decided on their oldest unpublished event
+	claimed := map[int64]bool{}
+	for i := range a.outboxEvents {
+		if len(events) == limit {
+			break
+		}
+		event := &a.outboxEvents[i]
+		if event.PublishedAt != nil {
+			continue
+		}
+		accountClaimed, met := claimed[event.AccountId]
+		if !met {
+			accountClaimed = event.NextAttemptAt == nil || !event.NextAttemptAt.After(now)
+			claimed[event.AccountId] = accountClaimed
+			if accountClaimed {
+				event.NextAttemptAt = &leasedUntil
+			}
+		}
+		if accountClaimed {
+			events = append(events, *event)
+		}
+	}
+	return events, nil
+}
+
+func (a *memoryAccounts) MarkOutboxEventsPublished(_ context.Context, eventIds []int64, publishedAt time.Time) error {
+	for _, id := range eventIds {
+		if id >= 1 && id <= int64(len(a.outboxEvents)) {
+			a.outboxEvents[id-1].PublishedAt = &publishedAt
+		}
+	}
+	return nil
+}
+
+func (a *memoryAccounts) DeferOutboxEvent(_ context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	if eventId >= 1 && eventId <= int64(len(a.outboxEvents)) {
+		a.outboxEvents[eventId-1].Attempts = attempts
+		a.outboxEvents[eventId-1].NextAttemptAt = &nextAttemptAt
+	}
+	return nil
+}
+
+func (a *memoryAccounts) GetOutboxEvents(_ context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	var events []entities.OutboxEvent
+	for _, event := range a.outboxEvents {
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+		if event.Id <= filter.AfterId || (len(filter.Ids) > 0 && !slices.Contains(filter.Ids, event.Id)) {
+			continue
+		}
+		if filter.AccountId != nil && event.AccountId != *filter.AccountId &&
+			(event.RelatedAccountId == nil || *event.RelatedAccountId != *filter.AccountId) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (a *memoryAccounts) GetLastOutboxEventId(_ context.Context) (int64, error) {
+	return int64(len(a.outboxEvents)), nil
+}
+
+func (a *memoryAccounts) CreateWebhook(_ context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	a.lastWebhookId++
+	webhook.Id = a.lastWebhookId
+	a.webhooks[webhook.Id] = webhook
+	return webhook, nil
+}
+
+func (a *memoryAccounts) GetWebhookById(_ context.Context, webhookId int64) (entities.Webhook, error) {
+	webhook, ok := a.webhooks[webhookId]
+	if !ok {
+		return entities.Webhook{}, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (a *memoryAccounts) GetWebhooks(_ context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	for _, webhook := range a.webhooks {
+		if filter.OwnerId != nil && (webhook.OwnerId == nil || *webhook.OwnerId != *filter.OwnerId) {
+			continue
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	slices.SortFunc(webhooks, func(a, b entities.Webhook) int { return cmp.Compare(a.Id, b.Id) })
+	return webhooks, nil
+}
+
+func (a *memoryAccounts) DeleteWebhook(_ context.Context, webhookId int64) error {
+	if _, ok := a.webhooks[webhookId]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(a.webhooks, webhookId)
+	maps.DeleteFunc(a.webhookDeliveries, func(_ int64, delivery entities.WebhookDelivery) bool {
+		return delivery.WebhookId == webhookId
+	})
+	return nil
+}
+
+func (a *memoryAccounts) CreateWebhookDelivery(_ context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	a.lastDeliveryId++
+	delivery.Id = a.lastDeliveryId
+	a.webhookDeliveries[delivery.Id] = delivery
+	return delivery, nil
+}
+
+func (a *memoryAccounts) GetWebhookDeliveryById(_ context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	delivery, ok := a.webhookDeliveries[deliveryId]
+	if !ok {
+		return entities.WebhookDelivery{}, ErrWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (a *memoryAccounts) GetWebhookDeliveries(_ context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	for _, delivery := range a.webhookDeliveries {
+		if filter.WebhookId != nil && delivery.WebhookId != *filter.WebhookId {
+			continue
+		}
+		if filter.Status != nil && delivery.Status != *filter.Status {
+			continue
+		}
+		if filter.DueBefore != nil && (delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(*filter.DueBefore)) {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	slices.SortFunc(deliveries, func(a, b entities.WebhookDelivery) int { return cmp.Compare(a.Id, b.Id) })
+	if filter.Limit > 0 && len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+	return deliveries, nil
+}
+
+func (a *memoryAccounts) UpdateWebhookDelivery(_ context.Context, delivery entities.WebhookDelivery) error {
+	current, ok := a.webhookDeliveries[delivery.Id]
+	if !ok {
+		return ErrWebhookDeliveryNotFound
+	}
+	current.Status = delivery.Status
+	current.Attempts = delivery.Attempts
+	current.NextAttemptAt = delivery.NextAttemptAt
+	current.LastAttemptAt = delivery.LastAttemptAt
+	current.LastResponseStatus = delivery.LastResponseStatus
+	current.LastError = delivery.LastError
+	current.DeliveredAt = delivery.DeliveredAt
+	a.webhookDeliveries[delivery.Id] = current
+	return nil
+}
+
+func (a *memoryAccounts) ClaimIdempotencyKey(_ context.Context, key entities.IdempotencyKey) (entities.IdempotencyKey, bool, error) {
+	id := idempotencyKeyId{key.Subject, key.Key}
+	if saved, ok := a.idempotencyKeys[id]; ok {
+		return saved, false, nil
+	}
+	a.idempotencyKeys[id] = key
+	return key, true, nil
+}
+
+func (a *memoryAccounts) SaveIdempotentResponse(_ context.Context, key entities.IdempotencyKey) error {
+	a.idempotencyKeys[idempotencyKeyId{key.Subject, key.Key}] = key
+	return nil
+}
+
+func (a *memoryAccounts) CountNewTransferTargets(_ context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	firstTransfers := map[int64]time.Time{}
+	for _, transfer := range a.transfers {
+		if transfer.SourceAccountId != sourceAccountId || transfer.Status != entities.TransferStatusCompleted {
+			continue
+		}
+		if first, ok := firstTransfers[transfer.TargetAccountId]; !ok || transfer.CreatedAt.Before(first) {
+			firstTransfers[transfer.TargetAccountId] = transfer.CreatedAt
+		}
+	}
+	var count int64
+	for _, first := range firstTransfers {
+		if first.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (a *memoryAccounts) HasTransferredTo(_ context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	for _, transfer := range a.transfers {
+		if transfer.SourceAccountId == sourceAccountId && transfer.TargetAccountId == targetAccountId &&
+			transfer.Status == entities.TransferStatusCompleted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *memoryAccounts) GetTierTransferLimits(_ context.Context, tier string) (entities.TransferLimits, error) {
+	return a.tierLimits[tier], nil
+}
+
+func (a *memoryAccounts) SetTierTransferLimits(_ context.Context, tier string, limits entities.TransferLimits) error {
+	a.tierLimits[tier] = limits
+	return nil
+}
+
+func (a *memoryAccounts) GetAccountTransferLimits(_ context.Context, accountId int64) (entities.TransferLimits, error) {
+	return a.accountLimits[accountId], nil
+}
+
+func (a *memoryAccounts) SetAccountTransferLimits(_ context.Context, accountId int64, limits entities.TransferLimits) error {
+	a.accountLimits[accountId] = limits
+	return nil
+}
+
+// updateBalance mirrors the postgres UPDATE, which silently affects no rows for an unknown account.
+func (a *memoryAccounts) updateBalance(accountId int64, delta float64) error {
+	account, ok := a.byId[accountId]
+	if !ok {
+		return nil
+	}
+	account.Balance = roundCents(account.Balance + delta)
+	account.Version++
+	account.UpdatedAt = time.Now()
+	a.byId[accountId] = account
+	return nil
+}
+
+func (a *memoryAccounts) updateHeldBalance(accountId int64, delta float64) error {
+	account, ok := a.byId[accountId]
+	if !ok {
+		return nil
+	}
+	account.HeldBalance = roundCents(account.HeldBalance + delta)
+	account.Version++
+	account.UpdatedAt = time.Now()
+	a.byId[accountId] = account
+	return nil
+}
+
+// roundCents mimics the DECIMAL(15, 2) balance column.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts"
+    DROP COLUMN IF EXISTS "version",
+    DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts"
+    ADD COLUMN IF NOT EXISTS "version" BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS "status" VARCHAR(16) NOT NULL DEFAULT 'active';
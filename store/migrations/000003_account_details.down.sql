@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "account_changes";
+ALTER TABLE "accounts"
+    DROP COLUMN IF EXISTS "metadata",
+    DROP COLUMN IF EXISTS "labels";
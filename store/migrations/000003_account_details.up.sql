@@ -0,0 +1,14 @@
+ALTER TABLE "accounts"
+    ADD COLUMN IF NOT EXISTS "metadata" JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS "labels" JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS "account_changes" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "account_id" BIGINT NOT NULL REFERENCES "accounts" ("id"),
+    "actor" VARCHAR(255) NOT NULL,
+    "field" VARCHAR(255) NOT NULL,
+    "old_value" TEXT,
+    "new_value" TEXT,
+    "changed_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "account_changes_account_id_idx" ON "account_changes" ("account_id", "id");
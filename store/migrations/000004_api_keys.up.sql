@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(16) NOT NULL,
+    "hash" CHAR(64) NOT NULL UNIQUE,
+    "scopes" JSONB NOT NULL DEFAULT '[]',
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP WITH TIME ZONE,
+    "revoked_at" TIMESTAMP WITH TIME ZONE
+);
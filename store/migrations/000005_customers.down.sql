@@ -0,0 +1,3 @@
+ALTER TABLE "api_keys" DROP COLUMN IF EXISTS "customer_id";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "owner_id";
+DROP TABLE IF EXISTS "customers";
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "customers" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "external_id" VARCHAR(255) UNIQUE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "owner_id" BIGINT REFERENCES "customers" ("id");
+CREATE INDEX IF NOT EXISTS "accounts_owner_id_idx" ON "accounts" ("owner_id");
+
+ALTER TABLE "api_keys" ADD COLUMN IF NOT EXISTS "customer_id" BIGINT REFERENCES "customers" ("id");
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS "role_assignments" (
+    "subject" VARCHAR(255) NOT NULL,
+    "role" VARCHAR(64) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("subject", "role")
+);
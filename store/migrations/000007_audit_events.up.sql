@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" BIGINT PRIMARY KEY,
+    "actor" VARCHAR(255) NOT NULL,
+    "operation" VARCHAR(255) NOT NULL,
+    "request_id" VARCHAR(255) NOT NULL,
+    "client_ip" VARCHAR(64) NOT NULL,
+    -- snapshots are hashed as stored, JSONB would normalize them and break the chain
+    "before" TEXT,
+    "after" TEXT,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "prev_hash" VARCHAR(64) NOT NULL,
+    "hash" VARCHAR(64) NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_append_only"
+    BEFORE UPDATE OR DELETE ON "audit_events"
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,7 @@
+-- only used with --rate-limit-backend=postgres, unlogged as losing the buckets on a crash is harmless
+CREATE UNLOGGED TABLE IF NOT EXISTS "rate_limit_buckets" (
+    "key" VARCHAR(512) PRIMARY KEY,
+    "tokens" DOUBLE PRECISION NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS "rate_limit_buckets_updated_at_idx" ON "rate_limit_buckets" ("updated_at");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "account_transfer_limits";
+DROP TABLE IF EXISTS "tier_transfer_limits";
+DROP TABLE IF EXISTS "transfers";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "tier";
@@ -0,0 +1,28 @@
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "tier" VARCHAR(64) NOT NULL DEFAULT 'standard';
+
+CREATE TABLE IF NOT EXISTS "transfers" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "source_account_id" BIGINT NOT NULL REFERENCES "accounts" ("id"),
+    "target_account_id" BIGINT NOT NULL REFERENCES "accounts" ("id"),
+    "amount" DECIMAL(15, 2) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "transfers_source_account_id_idx" ON "transfers" ("source_account_id", "created_at");
+
+CREATE TABLE IF NOT EXISTS "tier_transfer_limits" (
+    "tier" VARCHAR(64) PRIMARY KEY,
+    "max_amount" DECIMAL(15, 2),
+    "daily_amount" DECIMAL(15, 2),
+    "weekly_amount" DECIMAL(15, 2),
+    "monthly_amount" DECIMAL(15, 2),
+    "daily_count" INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS "account_transfer_limits" (
+    "account_id" BIGINT PRIMARY KEY REFERENCES "accounts" ("id"),
+    "max_amount" DECIMAL(15, 2),
+    "daily_amount" DECIMAL(15, 2),
+    "weekly_amount" DECIMAL(15, 2),
+    "monthly_amount" DECIMAL(15, 2),
+    "daily_count" INTEGER
+);
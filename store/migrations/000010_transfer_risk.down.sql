@@ -0,0 +1,4 @@
+ALTER TABLE "transfers"
+    DROP COLUMN IF EXISTS "risk_evaluations",
+    DROP COLUMN IF EXISTS "risk_decision",
+    DROP COLUMN IF EXISTS "status";
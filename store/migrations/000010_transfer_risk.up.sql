@@ -0,0 +1,4 @@
+ALTER TABLE "transfers"
+    ADD COLUMN IF NOT EXISTS "status" VARCHAR(16) NOT NULL DEFAULT 'completed',
+    ADD COLUMN IF NOT EXISTS "risk_decision" VARCHAR(16) NOT NULL DEFAULT 'allow',
+    ADD COLUMN IF NOT EXISTS "risk_evaluations" JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "transfers_pending_approval_idx";
+ALTER TABLE "transfers"
+    DROP COLUMN IF EXISTS "expires_at",
+    DROP COLUMN IF EXISTS "decided_by",
+    DROP COLUMN IF EXISTS "requested_by";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "held_balance";
@@ -0,0 +1,7 @@
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "held_balance" DECIMAL(15, 2) NOT NULL DEFAULT 0.00;
+
+ALTER TABLE "transfers"
+    ADD COLUMN IF NOT EXISTS "requested_by" VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "decided_by" VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS "expires_at" TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS "transfers_pending_approval_idx" ON "transfers" ("expires_at") WHERE "status" = 'pending_approval';
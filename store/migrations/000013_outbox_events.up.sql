@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "type" VARCHAR(64) NOT NULL,
+    "account_id" BIGINT NOT NULL,
+    "payload" TEXT NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "published_at" TIMESTAMP WITH TIME ZONE
+);
+-- the relay only reads the events not published yet
+CREATE INDEX IF NOT EXISTS "outbox_events_unpublished_idx" ON "outbox_events" ("id") WHERE "published_at" IS NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "url" VARCHAR(2048) NOT NULL,
+    "event_types" JSONB NOT NULL DEFAULT '[]',
+    "account_ids" JSONB NOT NULL DEFAULT '[]',
+    "secret" VARCHAR(255) NOT NULL,
+    "owner_id" BIGINT REFERENCES "customers" ("id"),
+    "created_by" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "webhooks_owner_id_idx" ON "webhooks" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "webhook_id" BIGINT NOT NULL REFERENCES "webhooks" ("id") ON DELETE CASCADE,
+    "event_id" BIGINT NOT NULL REFERENCES "outbox_events" ("id"),
+    "event_type" VARCHAR(64) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" TIMESTAMP WITH TIME ZONE,
+    "last_attempt_at" TIMESTAMP WITH TIME ZONE,
+    "last_response_status" INTEGER,
+    "last_error" TEXT,
+    "delivered_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_webhook_id_idx" ON "webhook_deliveries" ("webhook_id");
+-- the dispatcher only reads the pending deliveries that are due
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_due_idx" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
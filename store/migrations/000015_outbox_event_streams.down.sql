@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "outbox_events_related_account_id_idx";
+DROP INDEX IF EXISTS "outbox_events_account_id_idx";
+ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "related_account_id";
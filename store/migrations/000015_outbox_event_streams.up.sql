@@ -0,0 +1,7 @@
+-- the other account of an event, the target of transfers, so the streams of both accounts can read it
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "related_account_id" BIGINT;
+UPDATE "outbox_events" SET "related_account_id" = ("payload"::jsonb ->> 'target_account_id')::BIGINT
+WHERE "type" = 'TransferCompleted' AND "related_account_id" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "outbox_events_account_id_idx" ON "outbox_events" ("account_id", "id");
+CREATE INDEX IF NOT EXISTS "outbox_events_related_account_id_idx" ON "outbox_events" ("related_account_id", "id");
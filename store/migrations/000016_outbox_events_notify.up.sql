@@ -0,0 +1,15 @@
+-- notifies the replicas of the committed events, for their live streams
+CREATE OR REPLACE FUNCTION "notify_outbox_event"() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', json_build_object(
+        'id', NEW."id",
+        'account_id', NEW."account_id",
+        'related_account_id', NEW."related_account_id"
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "outbox_events_notify" ON "outbox_events";
+CREATE TRIGGER "outbox_events_notify" AFTER INSERT ON "outbox_events"
+    FOR EACH ROW EXECUTE FUNCTION "notify_outbox_event"();
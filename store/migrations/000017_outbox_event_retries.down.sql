@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "outbox_events_unpublished_account_id_idx";
+ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "next_attempt_at";
+ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "attempts";
@@ -0,0 +1,6 @@
+-- the relay leases the events it publishes, and backs off the accounts whose events fail to be published
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "attempts" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "next_attempt_at" TIMESTAMP WITH TIME ZONE;
+
+-- the relay looks for the oldest unpublished event of each account
+CREATE INDEX IF NOT EXISTS "outbox_events_unpublished_account_id_idx" ON "outbox_events" ("account_id", "id") WHERE "published_at" IS NULL;
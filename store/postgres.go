@@ -0,0 +1,589 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+type PostgresStore struct {
+	db database.SQLDB
+}
+
+var _ Store = PostgresStore{}
+
+func NewPostgresStore(db database.SQLDB) PostgresStore {
+	return PostgresStore{
+		db: db,
+	}
+}
+
+func (s PostgresStore) CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error) {
+	return postgresAccounts{q: s.db}.CreateAccount(ctx, account)
+}
+
+func (s PostgresStore) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
+	return postgresAccounts{q: s.db}.GetAccountById(ctx, accountId)
+}
+
+func (s PostgresStore) GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error) {
+	return postgresAccounts{q: s.db}.GetAccounts(ctx, filter)
+}
+
+func (s PostgresStore) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	return postgresAccounts{q: s.db}.AddBalance(ctx, accountId, amount)
+}
+
+func (s PostgresStore) SubtractBalance(ctx context.Context, accountId int64, amount float64) error {
+	return postgresAccounts{q: s.db}.SubtractBalance(ctx, accountId, amount)
+}
+
+func (s PostgresStore) HoldBalance(ctx context.Context, accountId int64, amount float64) error {
+	return postgresAccounts{q: s.db}.HoldBalance(ctx, accountId, amount)
+}
+
+func (s PostgresStore) ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error {
+	return postgresAccounts{q: s.db}.ReleaseHeldBalance(ctx, accountId, amount)
+}
+
+func (s PostgresStore) UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	return postgresAccounts{q: s.db}.UpdateAccount(ctx, accountId, ifVersion, update)
+}
+
+func (s PostgresStore) AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error {
+	return postgresAccounts{q: s.db}.AddAccountChanges(ctx, changes)
+}
+
+func (s PostgresStore) GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error) {
+	return postgresAccounts{q: s.db}.GetAccountChanges(ctx, accountId)
+}
+
+func (s PostgresStore) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	return postgresAccounts{q: s.db}.CreateTransfer(ctx, transfer)
+}
+
+func (s PostgresStore) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	return postgresAccounts{q: s.db}.GetOutgoingTransferTotals(ctx, accountId, since)
+}
+
+func (s PostgresStore) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	return postgresAccounts{q: s.db}.GetTransferById(ctx, transferId)
+}
+
+func (s PostgresStore) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	return postgresAccounts{q: s.db}.GetTransfers(ctx, filter)
+}
+
+func (s PostgresStore) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	return postgresAccounts{q: s.db}.UpdateTransfer(ctx, transfer)
+}
+
+func (s PostgresStore) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	return postgresAccounts{q: s.db}.CreateScreeningHit(ctx, hit)
+}
+
+func (s PostgresStore) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	return postgresAccounts{q: s.db}.GetScreeningHitById(ctx, hitId)
+}
+
+func (s PostgresStore) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	return postgresAccounts{q: s.db}.GetScreeningHits(ctx, filter)
+}
+
+func (s PostgresStore) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	return postgresAccounts{q: s.db}.UpdateScreeningHit(ctx, hit)
+}
+
+func (s PostgresStore) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	return postgresAccounts{q: s.db}.AddOutboxEvent(ctx, event)
+}
+
+func (s PostgresStore) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	return postgresAccounts{q: s.db}.ClaimOutboxEvents(ctx, limit, now, leasedUntil)
+}
+
+func (s PostgresStore) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	return postgresAccounts{q: s.db}.MarkOutboxEventsPublished(ctx, eventIds, publishedAt)
+}
+
+func (s PostgresStore) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	return postgresAccounts{q: s.db}.DeferOutboxEvent(ctx, eventId, attempts, nextAttemptAt)
+}
+
+func (s PostgresStore) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	return postgresAccounts{q: s.db}.GetOutboxEvents(ctx, filter)
+}
+
+func (s PostgresStore) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	return postgresAccounts{q: s.db}.GetLastOutboxEventId(ctx)
+}
+
+func (s PostgresStore) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	return postgresAccounts{q: s.db}.CreateWebhook(ctx, webhook)
+}
+
+func (s PostgresStore) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	return postgresAccounts{q: s.db}.GetWebhookById(ctx, webhookId)
+}
+
+func (s PostgresStore) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	return postgresAccounts{q: s.db}.GetWebhooks(ctx, filter)
+}
+
+func (s PostgresStore) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	return postgresAccounts{q: s.db}.DeleteWebhook(ctx, webhookId)
+}
+
+func (s PostgresStore) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	return postgresAccounts{q: s.db}.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (s PostgresStore) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	return postgresAccounts{q: s.db}.GetWebhookDeliveryById(ctx, deliveryId)
+}
+
+func (s PostgresStore) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	return postgresAccounts{q: s.db}.GetWebhookDeliveries(ctx, filter)
+}
+
+func (s PostgresStore) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	return postgresAccounts{q: s.db}.UpdateWebhookDelivery(ctx, delivery)
+}
+
+func (s PostgresStore) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	return postgresAccounts{q: s.db}.CountNewTransferTargets(ctx, sourceAccountId, since)
+}
+
+func (s PostgresStore) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	return postgresAccounts{q: s.db}.HasTransferredTo(ctx, sourceAccountId, targetAccountId)
+}
+
+func (s PostgresStore) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	return postgresAccounts{q: s.db}.GetTierTransferLimits(ctx, tier)
+}
+
+func (s PostgresStore) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	return postgresAccounts{q: s.db}.SetTierTransferLimits(ctx, tier, limits)
+}
+
+func (s PostgresStore) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	return postgresAccounts{q: s.db}.GetAccountTransferLimits(ctx, accountId)
+}
+
+func (s PostgresStore) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	return postgresAccounts{q: s.db}.SetAccountTransferLimits(ctx, accountId, limits)
+}
+
+func (s PostgresStore) GetTiers(ctx context.Context) ([]entities.TierTransferLimits, error) {
+	return sqlTransfers{q: s.db}.GetTiers(ctx)
+}
+
+func (s PostgresStore) CreateAPIKey(ctx context.Context, key entities.APIKey) (int64, error) {
+	return sqlAPIKeys{q: s.db}.CreateAPIKey(ctx, key)
+}
+
+func (s PostgresStore) GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error) {
+	return sqlAPIKeys{q: s.db}.GetAPIKeyByHash(ctx, hash)
+}
+
+func (s PostgresStore) LookupAPIKey(ctx context.Context, hash string) (entities.APIKey, bool, error) {
+	key, err := s.GetAPIKeyByHash(ctx, hash)
+	return lookup(key, err, ErrAPIKeyNotFound)
+}
+
+func (s PostgresStore) GetAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	return sqlAPIKeys{q: s.db}.GetAPIKeys(ctx)
+}
+
+func (s PostgresStore) RevokeAPIKey(ctx context.Context, id int64) error {
+	return sqlAPIKeys{q: s.db}.RevokeAPIKey(ctx, id)
+}
+
+func (s PostgresStore) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.CreateCustomer(ctx, customer)
+}
+
+func (s PostgresStore) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomers(ctx)
+}
+
+func (s PostgresStore) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomerById(ctx, customerId)
+}
+
+func (s PostgresStore) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomerByExternalId(ctx, externalId)
+}
+
+func (s PostgresStore) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := s.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+func (s PostgresStore) AssignRole(ctx context.Context, subject, role string) error {
+	return sqlRoles{q: s.db}.AssignRole(ctx, subject, role)
+}
+
+func (s PostgresStore) UnassignRole(ctx context.Context, subject, role string) error {
+	return sqlRoles{q: s.db}.UnassignRole(ctx, subject, role)
+}
+
+func (s PostgresStore) GetRoles(ctx context.Context, subject string) ([]string, error) {
+	return sqlRoles{q: s.db}.GetRoles(ctx, subject)
+}
+
+func (s PostgresStore) GetRoleAssignments(ctx context.Context) ([]entities.RoleAssignment, error) {
+	return sqlRoles{q: s.db}.GetRoleAssignments(ctx)
+}
+
+// AppendAuditEvent runs in its own transaction, as the lock serializing appends is only held until the end
+// of a transaction.
+func (s PostgresStore) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	var appended entities.AuditEvent
+	err := WithTx(ctx, s.db, nil, func(q database.Querier) error {
+		var err error
+		appended, err = postgresAccounts{q: q, forUpdate: true}.AppendAuditEvent(ctx, event)
+		return err
+	})
+	return appended, err
+}
+
+func (s PostgresStore) GetAuditEvents(ctx context.Context, afterId int64, limit int) ([]entities.AuditEvent, error) {
+	return sqlAuditEvents{q: s.db}.GetAuditEvents(ctx, afterId, limit)
+}
+
+func (s PostgresStore) RunInTx(ctx context.Context, fn func(tx Tx) error) error {
+	// Rows read inside a unit of work are locked until the end of it, so read committed is enough for
+	// concurrent transfers touching the same accounts to be serialized instead of reading stale balances.
+	opts := &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+	return WithTx(ctx, s.db, opts, func(q database.Querier) error {
+		return fn(postgresAccounts{q: q, forUpdate: true})
+	})
+}
+
+// postgresAccounts implements Tx on top of either the connection pool or a transaction.
+type postgresAccounts struct {
+	q         database.Querier
+	forUpdate bool
+}
+
+func (a postgresAccounts) CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error) {
+	q := `
+		INSERT INTO accounts (name, balance, status, version, metadata, labels, owner_id, tier, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
+	`
+	err := a.q.QueryRowxContext(ctx, q, account.Name, account.Balance, account.Status, account.Version,
+		account.Metadata, account.Labels, account.OwnerId, account.Tier, account.CreatedAt, account.UpdatedAt).Scan(&account.Id)
+	return account, err
+}
+
+func (a postgresAccounts) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
+	var account entities.Account
+	q := `SELECT ` + accountColumns + ` FROM accounts WHERE id = $1`
+	if a.forUpdate {
+		q += ` FOR UPDATE`
+	}
+	if err := a.q.QueryRowxContext(ctx, q, accountId).StructScan(&account); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Account{}, ErrAccountNotFound
+		}
+		return entities.Account{}, err
+	}
+	return account, nil
+}
+
+func (a postgresAccounts) GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error) {
+	var accounts []entities.Account
+	q := `SELECT ` + accountColumns + ` FROM accounts WHERE ($1::BIGINT IS NULL OR owner_id = $1) ORDER BY id;`
+	rows, err := a.q.QueryxContext(ctx, q, filter.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var account entities.Account
+		if err := rows.StructScan(&account); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (a postgresAccounts) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts 
+		SET balance = balance + $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, accountId)
+	return err
+}
+
+func (a postgresAccounts) SubtractBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts 
+		SET balance = balance - $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, accountId)
+	return err
+}
+
+func (a postgresAccounts) HoldBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts
+		SET held_balance = held_balance + $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, accountId)
+	return err
+}
+
+func (a postgresAccounts) ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts
+		SET held_balance = held_balance - $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, accountId)
+	return err
+}
+
+func (a postgresAccounts) UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	var account entities.Account
+	q := `
+		UPDATE accounts
+		SET name = COALESCE($1, name), status = COALESCE($2, status), metadata = COALESCE($3, metadata),
+			labels = COALESCE($4, labels), tier = COALESCE($5, tier), version = version + 1, updated_at = NOW()
+		WHERE id = $6 AND ($7::BIGINT IS NULL OR version = $7)
+		RETURNING ` + accountColumns + `;
+	`
+	err := a.q.QueryRowxContext(ctx, q, update.Name, update.Status, update.Metadata, update.Labels, update.Tier, accountId, ifVersion).StructScan(&account)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no row was updated, either because the account doesn't exist or because its version moved on
+		if _, err := a.GetAccountById(ctx, accountId); err != nil {
+			return entities.Account{}, err
+		}
+		return entities.Account{}, ErrVersionMismatch
+	}
+	if err != nil {
+		return entities.Account{}, err
+	}
+	return account, nil
+}
+
+func (a postgresAccounts) AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	q := `
+		INSERT INTO account_changes (account_id, actor, field, old_value, new_value, changed_at)
+		VALUES (:account_id, :actor, :field, :old_value, :new_value, :changed_at);
+	`
+	_, err := a.q.NamedExecContext(ctx, q, changes)
+	return err
+}
+
+func (a postgresAccounts) GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error) {
+	var changes []entities.AccountChange
+	q := `
+		SELECT id, account_id, actor, field, old_value, new_value, changed_at
+		FROM account_changes WHERE account_id = $1 ORDER BY id;
+	`
+	rows, err := a.q.QueryxContext(ctx, q, accountId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var change entities.AccountChange
+		if err := rows.StructScan(&change); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (a postgresAccounts) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.CreateCustomer(ctx, customer)
+}
+
+func (a postgresAccounts) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomers(ctx)
+}
+
+func (a postgresAccounts) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomerById(ctx, customerId)
+}
+
+func (a postgresAccounts) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomerByExternalId(ctx, externalId)
+}
+
+func (a postgresAccounts) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := a.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+// auditLockKey identifies the advisory lock serializing the appends to the audit log.
+const auditLockKey = 7_461_001
+
+// AppendAuditEvent must run in a transaction: it holds a lock on the audit log until the end of it, so
+// events are chained in commit order.
+func (a postgresAccounts) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	if _, err := a.q.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1);`, auditLockKey); err != nil {
+		return entities.AuditEvent{}, err
+	}
+	return sqlAuditEvents{q: a.q}.AppendAuditEvent(ctx, event)
+}
+
+func (a postgresAccounts) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	return sqlTransfers{q: a.q}.CreateTransfer(ctx, transfer)
+}
+
+func (a postgresAccounts) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	return sqlTransfers{q: a.q}.GetOutgoingTransferTotals(ctx, accountId, since)
+}
+
+func (a postgresAccounts) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	return sqlTransfers{q: a.q, forUpdate: a.forUpdate}.GetTransferById(ctx, transferId)
+}
+
+func (a postgresAccounts) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	return sqlTransfers{q: a.q}.GetTransfers(ctx, filter)
+}
+
+func (a postgresAccounts) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	return sqlTransfers{q: a.q}.UpdateTransfer(ctx, transfer)
+}
+
+func (a postgresAccounts) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q}.CreateScreeningHit(ctx, hit)
+}
+
+func (a postgresAccounts) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q, forUpdate: a.forUpdate}.GetScreeningHitById(ctx, hitId)
+}
+
+func (a postgresAccounts) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q}.GetScreeningHits(ctx, filter)
+}
+
+func (a postgresAccounts) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	return sqlScreening{q: a.q}.UpdateScreeningHit(ctx, hit)
+}
+
+func (a postgresAccounts) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q}.AddOutboxEvent(ctx, event)
+}
+
+func (a postgresAccounts) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q, skipLocked: a.forUpdate}.ClaimOutboxEvents(ctx, limit, now, leasedUntil)
+}
+
+func (a postgresAccounts) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	return sqlOutbox{q: a.q}.MarkOutboxEventsPublished(ctx, eventIds, publishedAt)
+}
+
+func (a postgresAccounts) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	return sqlOutbox{q: a.q}.DeferOutboxEvent(ctx, eventId, attempts, nextAttemptAt)
+}
+
+func (a postgresAccounts) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q}.GetOutboxEvents(ctx, filter)
+}
+
+func (a postgresAccounts) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	return sqlOutbox{q: a.q}.GetLastOutboxEventId(ctx)
+}
+
+func (a postgresAccounts) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.CreateWebhook(ctx, webhook)
+}
+
+func (a postgresAccounts) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.GetWebhookById(ctx, webhookId)
+}
+
+func (a postgresAccounts) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.GetWebhooks(ctx, filter)
+}
+
+func (a postgresAccounts) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	return sqlWebhooks{q: a.q}.DeleteWebhook(ctx, webhookId)
+}
+
+func (a postgresAccounts) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q}.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (a postgresAccounts) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q, forUpdate: a.forUpdate}.GetWebhookDeliveryById(ctx, deliveryId)
+}
+
+func (a postgresAccounts) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q}.GetWebhookDeliveries(ctx, filter)
+}
+
+func (a postgresAccounts) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	return sqlWebhooks{q: a.q}.UpdateWebhookDelivery(ctx, delivery)
+}
+
+func (a postgresAccounts) ClaimIdempotencyKey(ctx context.Context, key entities.IdempotencyKey) (entities.IdempotencyKey, bool, error) {
+	return sqlIdempotencyKeys{q: a.q}.ClaimIdempotencyKey(ctx, key)
+}
+
+func (a postgresAccounts) SaveIdempotentResponse(ctx context.Context, key entities.IdempotencyKey) error {
+	return sqlIdempotencyKeys{q: a.q}.SaveIdempotentResponse(ctx, key)
+}
+
+func (a postgresAccounts) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	return sqlTransfers{q: a.q}.CountNewTransferTargets(ctx, sourceAccountId, since)
+}
+
+func (a postgresAccounts) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	return sqlTransfers{q: a.q}.HasTransferredTo(ctx, sourceAccountId, targetAccountId)
+}
+
+func (a postgresAccounts) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	return sqlTransfers{q: a.q}.GetTierTransferLimits(ctx, tier)
+}
+
+func (a postgresAccounts) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	return sqlTransfers{q: a.q}.SetTierTransferLimits(ctx, tier, limits)
+}
+
+func (a postgresAccounts) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	return sqlTransfers{q: a.q}.GetAccountTransferLimits(ctx, accountId)
+}
+
+func (a postgresAccounts) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	return sqlTransfers{q: a.q}.SetAccountTransferLimits(ctx, accountId, limits)
+}
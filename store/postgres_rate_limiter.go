@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/pkg/ratelimit"
+)
+
+// PostgresRateLimiter keeps the rate limit buckets in postgres, so the limits hold across all the instances
+// sharing the database. The clock of the database is used, so the instances don't need synchronized clocks.
+type PostgresRateLimiter struct {
+	db database.SQLDB
+}
+
+var _ ratelimit.Limiter = PostgresRateLimiter{}
+
+func NewPostgresRateLimiter(s PostgresStore) PostgresRateLimiter {
+	return PostgresRateLimiter{db: s.db}
+}
+
+func (l PostgresRateLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	var result ratelimit.Result
+	err := WithTx(ctx, l.db, nil, func(q database.Querier) error {
+		insert := `
+			INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+			VALUES ($1, $2, clock_timestamp())
+			ON CONFLICT (key) DO NOTHING;
+		`
+		if _, err := q.ExecContext(ctx, insert, key, limit.Burst); err != nil {
+			return err
+		}
+
+		var bucket ratelimit.Bucket
+		var now time.Time
+		selectQ := `SELECT tokens, updated_at, clock_timestamp() FROM rate_limit_buckets WHERE key = $1 FOR UPDATE;`
+		if err := q.QueryRowxContext(ctx, selectQ, key).Scan(&bucket.Tokens, &bucket.UpdatedAt, &now); err != nil {
+			return err
+		}
+
+		result = bucket.Take(limit, now)
+		update := `UPDATE rate_limit_buckets SET tokens = $1, updated_at = $2 WHERE key = $3;`
+		_, err := q.ExecContext(ctx, update, bucket.Tokens, bucket.UpdatedAt, key)
+		return err
+	})
+	return result, err
+}
+
+func (l PostgresRateLimiter) Refund(ctx context.Context, key string, limit ratelimit.Limit) error {
+	return WithTx(ctx, l.db, nil, func(q database.Querier) error {
+		var bucket ratelimit.Bucket
+		var now time.Time
+		selectQ := `SELECT tokens, updated_at, clock_timestamp() FROM rate_limit_buckets WHERE key = $1 FOR UPDATE;`
+		err := q.QueryRowxContext(ctx, selectQ, key).Scan(&bucket.Tokens, &bucket.UpdatedAt, &now)
+		if errors.Is(err, sql.ErrNoRows) {
+			// a bucket deleted meanwhile was full already
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		bucket.Refund(limit, now)
+		update := `UPDATE rate_limit_buckets SET tokens = $1, updated_at = $2 WHERE key = $3;`
+		_, err = q.ExecContext(ctx, update, bucket.Tokens, bucket.UpdatedAt, key)
+		return err
+	})
+}
+
+// Run deletes the buckets left untouched for longer than idle every interval until ctx is done. idle must be
+// longer than the time the slowest limit takes to refill a bucket, or clients could get a full bucket early.
+func (l PostgresRateLimiter) Run(ctx context.Context, interval, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q := `DELETE FROM rate_limit_buckets WHERE updated_at < clock_timestamp() - $1 * INTERVAL '1 second';`
+			if _, err := l.db.ExecContext(ctx, q, idle.Seconds()); err != nil {
+				slog.Warn("Failed to delete idle rate limit buckets", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const apiKeyColumns = `id, name, prefix, hash, scopes, customer_id, created_at, expires_at, revoked_at`
+
+// sqlAPIKeys implements APIKeys with queries that run on both postgres and sqlite.
+type sqlAPIKeys struct {
+	q database.Querier
+}
+
+func (k sqlAPIKeys) CreateAPIKey(ctx context.Context, key entities.APIKey) (int64, error) {
+	var id int64
+	q := `
+		INSERT INTO api_keys (name, prefix, hash, scopes, customer_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id;
+	`
+	err := k.q.QueryRowxContext(ctx, q, key.Name, key.Prefix, key.Hash, key.Scopes, key.CustomerId, key.CreatedAt, key.ExpiresAt).Scan(&id)
+	return id, err
+}
+
+func (k sqlAPIKeys) GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error) {
+	var key entities.APIKey
+	q := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE hash = $1;`
+	if err := k.q.QueryRowxContext(ctx, q, hash).StructScan(&key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.APIKey{}, ErrAPIKeyNotFound
+		}
+		return entities.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (k sqlAPIKeys) GetAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	var keys []entities.APIKey
+	q := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id;`
+	rows, err := k.q.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var key entities.APIKey
+		if err := rows.StructScan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (k sqlAPIKeys) RevokeAPIKey(ctx context.Context, id int64) error {
+	q := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2;`
+	res, err := k.q.ExecContext(ctx, q, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
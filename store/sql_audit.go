@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const auditEventColumns = `id, actor, operation, request_id, client_ip, before, after, created_at, prev_hash, hash`
+
+// sqlAuditEvents implements the audit log with queries that run on both postgres and sqlite. Appending
+// must be serialized by the caller, or two events could link to the same previous one.
+type sqlAuditEvents struct {
+	q database.Querier
+}
+
+func (a sqlAuditEvents) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	var last entities.AuditEvent
+	q := `SELECT ` + auditEventColumns + ` FROM audit_events ORDER BY id DESC LIMIT 1;`
+	err := a.q.QueryRowxContext(ctx, q).StructScan(&last)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		event = chainAuditEvent(nil, event)
+	case err != nil:
+		return entities.AuditEvent{}, err
+	default:
+		event = chainAuditEvent(&last, event)
+	}
+
+	q = `
+		INSERT INTO audit_events (` + auditEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	`
+	_, err = a.q.ExecContext(ctx, q, event.Id, event.Actor, event.Operation, event.RequestId, event.ClientIP,
+		event.Before, event.After, event.CreatedAt, event.PrevHash, event.Hash)
+	return event, err
+}
+
+func (a sqlAuditEvents) GetAuditEvents(ctx context.Context, afterId int64, limit int) ([]entities.AuditEvent, error) {
+	var events []entities.AuditEvent
+	q := `SELECT ` + auditEventColumns + ` FROM audit_events WHERE id > $1 ORDER BY id LIMIT $2;`
+	rows, err := a.q.QueryxContext(ctx, q, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var event entities.AuditEvent
+		if err := rows.StructScan(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
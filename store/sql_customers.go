@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const customerColumns = `id, name, external_id, created_at`
+
+// sqlCustomers implements Customers with queries that run on both postgres and sqlite.
+type sqlCustomers struct {
+	q database.Querier
+}
+
+func (c sqlCustomers) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	q := `
+		INSERT INTO customers (name, external_id, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id;
+	`
+	err := c.q.QueryRowxContext(ctx, q, customer.Name, customer.ExternalId, customer.CreatedAt).Scan(&customer.Id)
+	return customer, err
+}
+
+func (c sqlCustomers) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	var customers []entities.Customer
+	q := `SELECT ` + customerColumns + ` FROM customers ORDER BY id;`
+	rows, err := c.q.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var customer entities.Customer
+		if err := rows.StructScan(&customer); err != nil {
+			return nil, err
+		}
+		customers = append(customers, customer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func (c sqlCustomers) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	return c.getCustomer(ctx, `SELECT `+customerColumns+` FROM customers WHERE id = $1;`, customerId)
+}
+
+func (c sqlCustomers) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	return c.getCustomer(ctx, `SELECT `+customerColumns+` FROM customers WHERE external_id = $1;`, externalId)
+}
+
+func (c sqlCustomers) getCustomer(ctx context.Context, q string, arg any) (entities.Customer, error) {
+	var customer entities.Customer
+	if err := c.q.QueryRowxContext(ctx, q, arg).StructScan(&customer); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Customer{}, ErrCustomerNotFound
+		}
+		return entities.Customer{}, err
+	}
+	return customer, nil
+}
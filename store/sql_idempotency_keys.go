@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const idempotencyKeyColumns = `subject, key, operation, request_hash, response_status, response_content_type, response_body,
+	created_at`
+
+// sqlIdempotencyKeys implements IdempotencyKeys with queries that run on both postgres and sqlite.
+type sqlIdempotencyKeys struct {
+	q database.Querier
+}
+
+func (k sqlIdempotencyKeys) ClaimIdempotencyKey(ctx context.Context, key entities.IdempotencyKey) (entities.IdempotencyKey, bool, error) {
+	key.CreatedAt = key.CreatedAt.UTC()
+	// the insert waits for the unit of work of a concurrent request with the same key to end, and then only
+	// goes through when it was rolled back
+	q := `
+		INSERT INTO idempotency_keys (subject, key, operation, request_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (subject, key) DO NOTHING;
+	`
+	res, err := k.q.ExecContext(ctx, q, key.Subject, key.Key, key.Operation, key.RequestHash, key.CreatedAt)
+	if err != nil {
+		return entities.IdempotencyKey{}, false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return entities.IdempotencyKey{}, false, err
+	}
+	if affected == 1 {
+		return key, true, nil
+	}
+
+	var saved entities.IdempotencyKey
+	q = `SELECT ` + idempotencyKeyColumns + ` FROM idempotency_keys WHERE subject = $1 AND key = $2;`
+	if err := k.q.QueryRowxContext(ctx, q, key.Subject, key.Key).StructScan(&saved); err != nil {
+		return entities.IdempotencyKey{}, false, err
+	}
+	return saved, false, nil
+}
+
+func (k sqlIdempotencyKeys) SaveIdempotentResponse(ctx context.Context, key entities.IdempotencyKey) error {
+	q := `
+		UPDATE idempotency_keys SET response_status = $1, response_content_type = $2, response_body = $3
+		WHERE subject = $4 AND key = $5;
+	`
+	_, err := k.q.ExecContext(ctx, q, key.ResponseStatus, key.ResponseContentType, key.ResponseBody, key.Subject, key.Key)
+	return err
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const outboxEventColumns = `id, type, account_id, related_account_id, payload, created_at, published_at, attempts, next_attempt_at`
+
+// sqlOutbox implements Outbox with queries that run on both postgres and sqlite.
+type sqlOutbox struct {
+	q database.Querier
+	// skipLocked skips the events claimed by the concurrent relays, only postgres needs it.
+	skipLocked bool
+}
+
+func (o sqlOutbox) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	event.CreatedAt = event.CreatedAt.UTC()
+	q := `
+		INSERT INTO outbox_events (type, account_id, related_account_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+	err := o.q.QueryRowxContext(ctx, q, event.Type, event.AccountId, event.RelatedAccountId, event.Payload, event.CreatedAt).
+		Scan(&event.Id)
+	return event, err
+}
+
+func (o sqlOutbox) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	// the oldest unpublished events of the accounts, the other events of an account wait for them
+	q := `
+		SELECT id, account_id FROM outbox_events e
+		WHERE published_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox_events b WHERE b.account_id = e.account_id AND b.published_at IS NULL AND b.id < e.id
+		)
+		ORDER BY id LIMIT $2`
+	if o.skipLocked {
+		q += ` FOR UPDATE SKIP LOCKED`
+	}
+	rows, err := o.q.QueryxContext(ctx, q, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	heads := map[int64]int64{}
+	for rows.Next() {
+		var id, accountId int64
+		if err := rows.Scan(&id, &accountId); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		heads[accountId] = id
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(heads) == 0 {
+		return nil, nil
+	}
+
+	var args []any
+	placeholders := make([]string, 0, len(heads))
+	for accountId := range heads {
+		args = append(args, accountId)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	args = append(args, limit)
+	q = `SELECT ` + outboxEventColumns + ` FROM outbox_events
+		WHERE published_at IS NULL AND account_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY id LIMIT ` + fmt.Sprintf("$%d", len(args))
+	events, err := o.selectOutboxEvents(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the accounts making it into the batch are leased, the others stay due
+	for i, event := range events {
+		if heads[event.AccountId] != event.Id {
+			continue
+		}
+		if _, err := o.q.ExecContext(ctx, `UPDATE outbox_events SET next_attempt_at = $1 WHERE id = $2;`, leasedUntil.UTC(), event.Id); err != nil {
+			return nil, err
+		}
+		events[i].NextAttemptAt = &leasedUntil
+	}
+	return events, nil
+}
+
+func (o sqlOutbox) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+	if filter.AccountId != nil {
+		args = append(args, *filter.AccountId)
+		conditions = append(conditions, fmt.Sprintf("(account_id = $%d OR related_account_id = $%d)", len(args), len(args)))
+	}
+	if filter.AfterId > 0 {
+		args = append(args, filter.AfterId)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(args)))
+	}
+	if len(filter.Ids) > 0 {
+		placeholders := make([]string, 0, len(filter.Ids))
+		for _, id := range filter.Ids {
+			args = append(args, id)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	q := `SELECT ` + outboxEventColumns + ` FROM outbox_events WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		q += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return o.selectOutboxEvents(ctx, q, args...)
+}
+
+func (o sqlOutbox) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	var id int64
+	err := o.q.QueryRowxContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM outbox_events`).Scan(&id)
+	return id, err
+}
+
+func (o sqlOutbox) selectOutboxEvents(ctx context.Context, q string, args ...any) ([]entities.OutboxEvent, error) {
+	rows, err := o.q.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	var events []entities.OutboxEvent
+	for rows.Next() {
+		var event entities.OutboxEvent
+		if err := rows.StructScan(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (o sqlOutbox) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	if len(eventIds) == 0 {
+		return nil
+	}
+	args := []any{publishedAt.UTC()}
+	placeholders := make([]string, 0, len(eventIds))
+	for _, id := range eventIds {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	q := `UPDATE outbox_events SET published_at = $1 WHERE id IN (` + strings.Join(placeholders, ", ") + `);`
+	_, err := o.q.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (o sqlOutbox) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	q := `UPDATE outbox_events SET attempts = $1, next_attempt_at = $2 WHERE id = $3;`
+	_, err := o.q.ExecContext(ctx, q, attempts, nextAttemptAt.UTC(), eventId)
+	return err
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+// sqlRoles implements Roles with queries that run on both postgres and sqlite.
+type sqlRoles struct {
+	q database.Querier
+}
+
+func (r sqlRoles) AssignRole(ctx context.Context, subject, role string) error {
+	q := `
+		INSERT INTO role_assignments (subject, role, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (subject, role) DO NOTHING;
+	`
+	_, err := r.q.ExecContext(ctx, q, subject, role, time.Now())
+	return err
+}
+
+func (r sqlRoles) UnassignRole(ctx context.Context, subject, role string) error {
+	q := `DELETE FROM role_assignments WHERE subject = $1 AND role = $2;`
+	res, err := r.q.ExecContext(ctx, q, subject, role)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrRoleAssignmentNotFound
+	}
+	return nil
+}
+
+func (r sqlRoles) GetRoles(ctx context.Context, subject string) ([]string, error) {
+	var roles []string
+	q := `SELECT role FROM role_assignments WHERE subject = $1 ORDER BY role;`
+	rows, err := r.q.QueryxContext(ctx, q, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r sqlRoles) GetRoleAssignments(ctx context.Context) ([]entities.RoleAssignment, error) {
+	var assignments []entities.RoleAssignment
+	q := `SELECT subject, role, created_at FROM role_assignments ORDER BY subject, role;`
+	rows, err := r.q.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var assignment entities.RoleAssignment
+		if err := rows.StructScan(&assignment); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const screeningHitColumns = `id, subject_type, subject_id, operation, screened_name, entry_uid, entry_name, matched_name,
+	score, status, decided_by, decided_at, created_at`
+
+// sqlScreening implements Screening with queries that run on both postgres and sqlite.
+type sqlScreening struct {
+	q database.Querier
+	// forUpdate locks the hits read by id, only postgres needs it.
+	forUpdate bool
+}
+
+func (s sqlScreening) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	hit.CreatedAt = hit.CreatedAt.UTC()
+	q := `
+		INSERT INTO screening_hits (subject_type, subject_id, operation, screened_name, entry_uid, entry_name,
+			matched_name, score, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
+	`
+	err := s.q.QueryRowxContext(ctx, q, hit.SubjectType, hit.SubjectId, hit.Operation, hit.ScreenedName,
+		hit.EntryUid, hit.EntryName, hit.MatchedName, hit.Score, hit.Status, hit.CreatedAt).Scan(&hit.Id)
+	return hit, err
+}
+
+func (s sqlScreening) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	var hit entities.ScreeningHit
+	q := `SELECT ` + screeningHitColumns + ` FROM screening_hits WHERE id = $1`
+	if s.forUpdate {
+		q += ` FOR UPDATE`
+	}
+	if err := s.q.QueryRowxContext(ctx, q, hitId).StructScan(&hit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.ScreeningHit{}, ErrScreeningHitNotFound
+		}
+		return entities.ScreeningHit{}, err
+	}
+	return hit, nil
+}
+
+func (s sqlScreening) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	// the conditions are only added for the set filters, like in sqlTransfers.GetTransfers
+	conditions := []string{"TRUE"}
+	var args []any
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.SubjectType != nil {
+		args = append(args, *filter.SubjectType)
+		conditions = append(conditions, fmt.Sprintf("subject_type = $%d", len(args)))
+	}
+	if filter.SubjectId != nil {
+		args = append(args, *filter.SubjectId)
+		conditions = append(conditions, fmt.Sprintf("subject_id = $%d", len(args)))
+	}
+
+	var hits []entities.ScreeningHit
+	q := `SELECT ` + screeningHitColumns + ` FROM screening_hits WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY id;`
+	rows, err := s.q.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var hit entities.ScreeningHit
+		if err := rows.StructScan(&hit); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+func (s sqlScreening) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	if hit.DecidedAt != nil {
+		decidedAt := hit.DecidedAt.UTC()
+		hit.DecidedAt = &decidedAt
+	}
+	q := `UPDATE screening_hits SET status = $1, decided_by = $2, decided_at = $3 WHERE id = $4;`
+	res, err := s.q.ExecContext(ctx, q, hit.Status, hit.DecidedBy, hit.DecidedAt, hit.Id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrScreeningHitNotFound
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const transferLimitsColumns = `max_amount, daily_amount, weekly_amount, monthly_amount, daily_count`
+
+const transferColumns = `id, source_account_id, target_account_id, amount, status, risk_decision, risk_evaluations,
+	requested_by, decided_by, expires_at, created_at`
+
+// sqlTransfers implements Transfers and Limits with queries that run on both postgres and sqlite. Times are
+// kept in UTC so that sqlite, which stores them as text, compares them in order.
+type sqlTransfers struct {
+	q database.Querier
+	// forUpdate locks the transfers read by id, only postgres needs it.
+	forUpdate bool
+}
+
+func (t sqlTransfers) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	transfer.CreatedAt = transfer.CreatedAt.UTC()
+	if transfer.ExpiresAt != nil {
+		expiresAt := transfer.ExpiresAt.UTC()
+		transfer.ExpiresAt = &expiresAt
+	}
+	q := `
+		INSERT INTO transfers (source_account_id, target_account_id, amount, status, risk_decision, risk_evaluations,
+			requested_by, decided_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
+	`
+	err := t.q.QueryRowxContext(ctx, q, transfer.SourceAccountId, transfer.TargetAccountId, transfer.Amount,
+		transfer.Status, transfer.RiskDecision, transfer.RiskEvaluations, transfer.RequestedBy, transfer.DecidedBy,
+		transfer.ExpiresAt, transfer.CreatedAt).Scan(&transfer.Id)
+	return transfer, err
+}
+
+func (t sqlTransfers) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	var transfer entities.Transfer
+	q := `SELECT ` + transferColumns + ` FROM transfers WHERE id = $1`
+	if t.forUpdate {
+		q += ` FOR UPDATE`
+	}
+	if err := t.q.QueryRowxContext(ctx, q, transferId).StructScan(&transfer); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Transfer{}, ErrTransferNotFound
+		}
+		return entities.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+func (t sqlTransfers) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	q := `
+		UPDATE transfers
+		SET status = $1, risk_decision = $2, risk_evaluations = $3, decided_by = $4, expires_at = $5
+		WHERE id = $6;
+	`
+	var expiresAt *time.Time
+	if transfer.ExpiresAt != nil {
+		utc := transfer.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	res, err := t.q.ExecContext(ctx, q, transfer.Status, transfer.RiskDecision, transfer.RiskEvaluations,
+		transfer.DecidedBy, expiresAt, transfer.Id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTransferNotFound
+	}
+	return nil
+}
+
+func (t sqlTransfers) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	var totals entities.TransferTotals
+	q := `
+		SELECT COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count
+		FROM transfers
+		WHERE source_account_id = $1 AND status = 'completed' AND created_at > $2;
+	`
+	err := t.q.QueryRowxContext(ctx, q, accountId, since.UTC()).StructScan(&totals)
+	return totals, err
+}
+
+func (t sqlTransfers) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	// the conditions are only added for the set filters, postgres can't infer the type of a parameter only
+	// compared to NULL
+	conditions := []string{"TRUE"}
+	var args []any
+	if filter.SourceAccountId != nil {
+		args = append(args, *filter.SourceAccountId)
+		conditions = append(conditions, fmt.Sprintf("source_account_id = $%d", len(args)))
+	}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.ExpiresBefore != nil {
+		args = append(args, filter.ExpiresBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("expires_at < $%d", len(args)))
+	}
+
+	var transfers []entities.Transfer
+	q := `SELECT ` + transferColumns + ` FROM transfers WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY id;`
+	rows, err := t.q.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var transfer entities.Transfer
+		if err := rows.StructScan(&transfer); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (t sqlTransfers) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	var count int64
+	q := `
+		SELECT COUNT(*) FROM (
+			SELECT target_account_id
+			FROM transfers
+			WHERE source_account_id = $1 AND status = 'completed'
+			GROUP BY target_account_id
+			HAVING MIN(created_at) > $2
+		) AS new_targets;
+	`
+	err := t.q.QueryRowxContext(ctx, q, sourceAccountId, since.UTC()).Scan(&count)
+	return count, err
+}
+
+func (t sqlTransfers) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	var exists bool
+	q := `
+		SELECT EXISTS (
+			SELECT 1 FROM transfers
+			WHERE source_account_id = $1 AND target_account_id = $2 AND status = 'completed'
+		);
+	`
+	err := t.q.QueryRowxContext(ctx, q, sourceAccountId, targetAccountId).Scan(&exists)
+	return exists, err
+}
+
+func (t sqlTransfers) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	var limits entities.TransferLimits
+	q := `SELECT ` + transferLimitsColumns + ` FROM tier_transfer_limits WHERE tier = $1;`
+	err := t.q.QueryRowxContext(ctx, q, tier).StructScan(&limits)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.TransferLimits{}, nil
+	}
+	return limits, err
+}
+
+func (t sqlTransfers) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	q := `
+		INSERT INTO tier_transfer_limits (tier, ` + transferLimitsColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tier) DO UPDATE SET max_amount = excluded.max_amount, daily_amount = excluded.daily_amount,
+			weekly_amount = excluded.weekly_amount, monthly_amount = excluded.monthly_amount,
+			daily_count = excluded.daily_count;
+	`
+	_, err := t.q.ExecContext(ctx, q, tier, limits.MaxAmount, limits.DailyAmount, limits.WeeklyAmount,
+		limits.MonthlyAmount, limits.DailyCount)
+	return err
+}
+
+func (t sqlTransfers) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	var limits entities.TransferLimits
+	q := `SELECT ` + transferLimitsColumns + ` FROM account_transfer_limits WHERE account_id = $1;`
+	err := t.q.QueryRowxContext(ctx, q, accountId).StructScan(&limits)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.TransferLimits{}, nil
+	}
+	return limits, err
+}
+
+func (t sqlTransfers) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	q := `
+		INSERT INTO account_transfer_limits (account_id, ` + transferLimitsColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (account_id) DO UPDATE SET max_amount = excluded.max_amount,
+			daily_amount = excluded.daily_amount, weekly_amount = excluded.weekly_amount,
+			monthly_amount = excluded.monthly_amount, daily_count = excluded.daily_count;
+	`
+	_, err := t.q.ExecContext(ctx, q, accountId, limits.MaxAmount, limits.DailyAmount, limits.WeeklyAmount,
+		limits.MonthlyAmount, limits.DailyCount)
+	return err
+}
+
+func (t sqlTransfers) GetTiers(ctx context.Context) ([]entities.TierTransferLimits, error) {
+	var tiers []entities.TierTransferLimits
+	q := `SELECT tier, ` + transferLimitsColumns + ` FROM tier_transfer_limits ORDER BY tier;`
+	rows, err := t.q.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var tier entities.TierTransferLimits
+		if err := rows.StructScan(&tier); err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+const (
+	webhookColumns         = `id, url, event_types, account_ids, secret, owner_id, created_by, created_at`
+	webhookDeliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_attempt_at, last_response_status, last_error, delivered_at, created_at`
+)
+
+// sqlWebhooks implements Webhooks with queries that run on both postgres and sqlite.
+type sqlWebhooks struct {
+	q database.Querier
+	// forUpdate locks the deliveries read by id, only postgres needs it.
+	forUpdate bool
+}
+
+func (w sqlWebhooks) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	webhook.CreatedAt = webhook.CreatedAt.UTC()
+	q := `
+		INSERT INTO webhooks (url, event_types, account_ids, secret, owner_id, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id;
+	`
+	err := w.q.QueryRowxContext(ctx, q, webhook.URL, webhook.EventTypes, webhook.AccountIds, webhook.Secret,
+		webhook.OwnerId, webhook.CreatedBy, webhook.CreatedAt).Scan(&webhook.Id)
+	return webhook, err
+}
+
+func (w sqlWebhooks) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	var webhook entities.Webhook
+	q := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	if err := w.q.QueryRowxContext(ctx, q, webhookId).StructScan(&webhook); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Webhook{}, ErrWebhookNotFound
+		}
+		return entities.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (w sqlWebhooks) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+	if filter.OwnerId != nil {
+		args = append(args, *filter.OwnerId)
+		conditions = append(conditions, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+
+	q := `SELECT ` + webhookColumns + ` FROM webhooks WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY id;`
+	rows, err := w.q.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	var webhooks []entities.Webhook
+	for rows.Next() {
+		var webhook entities.Webhook
+		if err := rows.StructScan(&webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w sqlWebhooks) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	// the deliveries are deleted by the foreign key cascade
+	res, err := w.q.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1;`, webhookId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (w sqlWebhooks) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	delivery.CreatedAt = delivery.CreatedAt.UTC()
+	delivery.NextAttemptAt = utcTime(delivery.NextAttemptAt)
+	q := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id;
+	`
+	err := w.q.QueryRowxContext(ctx, q, delivery.WebhookId, delivery.EventId, delivery.EventType, delivery.Payload,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.CreatedAt).Scan(&delivery.Id)
+	return delivery, err
+}
+
+func (w sqlWebhooks) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	q := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	if w.forUpdate {
+		q += ` FOR UPDATE`
+	}
+	if err := w.q.QueryRowxContext(ctx, q, deliveryId).StructScan(&delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.WebhookDelivery{}, ErrWebhookDeliveryNotFound
+		}
+		return entities.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+func (w sqlWebhooks) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+	if filter.WebhookId != nil {
+		args = append(args, *filter.WebhookId)
+		conditions = append(conditions, fmt.Sprintf("webhook_id = $%d", len(args)))
+	}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.DueBefore != nil {
+		args = append(args, filter.DueBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("next_attempt_at <= $%d", len(args)))
+	}
+
+	q := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		q += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	rows, err := w.q.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	var deliveries []entities.WebhookDelivery
+	for rows.Next() {
+		var delivery entities.WebhookDelivery
+		if err := rows.StructScan(&delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w sqlWebhooks) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	q := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, last_response_status = $5,
+			last_error = $6, delivered_at = $7
+		WHERE id = $8;
+	`
+	res, err := w.q.ExecContext(ctx, q, delivery.Status, delivery.Attempts, utcTime(delivery.NextAttemptAt),
+		utcTime(delivery.LastAttemptAt), delivery.LastResponseStatus, delivery.LastError, utcTime(delivery.DeliveredAt),
+		delivery.Id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+// utcTime stores the optional times in UTC, sqlite compares them as text.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
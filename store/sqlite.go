@@ -0,0 +1,635 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"tiny-bank-api/pkg/database"
+	"tiny-bank-api/store/entities"
+)
+
+//go:embed sqlite_migrations/*.sql
+var sqliteMigrations embed.FS
+
+type SQLiteStore struct {
+	db database.SQLDB
+}
+
+var _ Store = SQLiteStore{}
+
+func NewSQLiteStore(db database.SQLDB) SQLiteStore {
+	return SQLiteStore{
+		db: db,
+	}
+}
+
+// Migrate applies the embedded sqlite migrations that haven't been applied yet. Unlike postgres,
+// sqlite deployments don't have a migration container so this runs on startup.
+func (s SQLiteStore) Migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY);`); err != nil {
+		return fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := s.db.QueryRowxContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&current); err != nil {
+		return fmt.Errorf("error reading schema version: %w", err)
+	}
+
+	files, err := fs.Glob(sqliteMigrations, "sqlite_migrations/*.up.sql")
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+
+	for _, file := range files {
+		version, err := strconv.Atoi(strings.SplitN(path.Base(file), "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("invalid migration file name %q: %w", file, err)
+		}
+		if version <= current {
+			continue
+		}
+
+		content, err := sqliteMigrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		err = WithTx(ctx, s.db, nil, func(q database.Querier) error {
+			if _, err := q.ExecContext(ctx, string(content)); err != nil {
+				return fmt.Errorf("error applying migration %q: %w", file, err)
+			}
+			_, err := q.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1);`, version)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("Applied sqlite migration", "file", file)
+	}
+
+	return nil
+}
+
+func (s SQLiteStore) CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error) {
+	return sqliteAccounts{q: s.db}.CreateAccount(ctx, account)
+}
+
+func (s SQLiteStore) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
+	return sqliteAccounts{q: s.db}.GetAccountById(ctx, accountId)
+}
+
+func (s SQLiteStore) GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error) {
+	return sqliteAccounts{q: s.db}.GetAccounts(ctx, filter)
+}
+
+func (s SQLiteStore) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	return sqliteAccounts{q: s.db}.AddBalance(ctx, accountId, amount)
+}
+
+func (s SQLiteStore) SubtractBalance(ctx context.Context, accountId int64, amount float64) error {
+	return sqliteAccounts{q: s.db}.SubtractBalance(ctx, accountId, amount)
+}
+
+func (s SQLiteStore) HoldBalance(ctx context.Context, accountId int64, amount float64) error {
+	return sqliteAccounts{q: s.db}.HoldBalance(ctx, accountId, amount)
+}
+
+func (s SQLiteStore) ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error {
+	return sqliteAccounts{q: s.db}.ReleaseHeldBalance(ctx, accountId, amount)
+}
+
+func (s SQLiteStore) UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	return sqliteAccounts{q: s.db}.UpdateAccount(ctx, accountId, ifVersion, update)
+}
+
+func (s SQLiteStore) AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error {
+	return sqliteAccounts{q: s.db}.AddAccountChanges(ctx, changes)
+}
+
+func (s SQLiteStore) GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error) {
+	return sqliteAccounts{q: s.db}.GetAccountChanges(ctx, accountId)
+}
+
+func (s SQLiteStore) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	return sqliteAccounts{q: s.db}.CreateTransfer(ctx, transfer)
+}
+
+func (s SQLiteStore) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	return sqliteAccounts{q: s.db}.GetOutgoingTransferTotals(ctx, accountId, since)
+}
+
+func (s SQLiteStore) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	return sqliteAccounts{q: s.db}.GetTransferById(ctx, transferId)
+}
+
+func (s SQLiteStore) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	return sqliteAccounts{q: s.db}.GetTransfers(ctx, filter)
+}
+
+func (s SQLiteStore) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	return sqliteAccounts{q: s.db}.UpdateTransfer(ctx, transfer)
+}
+
+func (s SQLiteStore) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	return sqliteAccounts{q: s.db}.CreateScreeningHit(ctx, hit)
+}
+
+func (s SQLiteStore) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	return sqliteAccounts{q: s.db}.GetScreeningHitById(ctx, hitId)
+}
+
+func (s SQLiteStore) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	return sqliteAccounts{q: s.db}.GetScreeningHits(ctx, filter)
+}
+
+func (s SQLiteStore) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	return sqliteAccounts{q: s.db}.UpdateScreeningHit(ctx, hit)
+}
+
+func (s SQLiteStore) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	return sqliteAccounts{q: s.db}.AddOutboxEvent(ctx, event)
+}
+
+func (s SQLiteStore) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	return sqliteAccounts{q: s.db}.ClaimOutboxEvents(ctx, limit, now, leasedUntil)
+}
+
+func (s SQLiteStore) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	return sqliteAccounts{q: s.db}.MarkOutboxEventsPublished(ctx, eventIds, publishedAt)
+}
+
+func (s SQLiteStore) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	return sqliteAccounts{q: s.db}.DeferOutboxEvent(ctx, eventId, attempts, nextAttemptAt)
+}
+
+func (s SQLiteStore) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	return sqliteAccounts{q: s.db}.GetOutboxEvents(ctx, filter)
+}
+
+func (s SQLiteStore) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	return sqliteAccounts{q: s.db}.GetLastOutboxEventId(ctx)
+}
+
+func (s SQLiteStore) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	return sqliteAccounts{q: s.db}.CreateWebhook(ctx, webhook)
+}
+
+func (s SQLiteStore) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	return sqliteAccounts{q: s.db}.GetWebhookById(ctx, webhookId)
+}
+
+func (s SQLiteStore) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	return sqliteAccounts{q: s.db}.GetWebhooks(ctx, filter)
+}
+
+func (s SQLiteStore) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	return sqliteAccounts{q: s.db}.DeleteWebhook(ctx, webhookId)
+}
+
+func (s SQLiteStore) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	return sqliteAccounts{q: s.db}.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (s SQLiteStore) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	return sqliteAccounts{q: s.db}.GetWebhookDeliveryById(ctx, deliveryId)
+}
+
+func (s SQLiteStore) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	return sqliteAccounts{q: s.db}.GetWebhookDeliveries(ctx, filter)
+}
+
+func (s SQLiteStore) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	return sqliteAccounts{q: s.db}.UpdateWebhookDelivery(ctx, delivery)
+}
+
+func (s SQLiteStore) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	return sqliteAccounts{q: s.db}.CountNewTransferTargets(ctx, sourceAccountId, since)
+}
+
+func (s SQLiteStore) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	return sqliteAccounts{q: s.db}.HasTransferredTo(ctx, sourceAccountId, targetAccountId)
+}
+
+func (s SQLiteStore) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	return sqliteAccounts{q: s.db}.GetTierTransferLimits(ctx, tier)
+}
+
+func (s SQLiteStore) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	return sqliteAccounts{q: s.db}.SetTierTransferLimits(ctx, tier, limits)
+}
+
+func (s SQLiteStore) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	return sqliteAccounts{q: s.db}.GetAccountTransferLimits(ctx, accountId)
+}
+
+func (s SQLiteStore) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	return sqliteAccounts{q: s.db}.SetAccountTransferLimits(ctx, accountId, limits)
+}
+
+func (s SQLiteStore) GetTiers(ctx context.Context) ([]entities.TierTransferLimits, error) {
+	return sqlTransfers{q: s.db}.GetTiers(ctx)
+}
+
+func (s SQLiteStore) CreateAPIKey(ctx context.Context, key entities.APIKey) (int64, error) {
+	return sqlAPIKeys{q: s.db}.CreateAPIKey(ctx, key)
+}
+
+func (s SQLiteStore) GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error) {
+	return sqlAPIKeys{q: s.db}.GetAPIKeyByHash(ctx, hash)
+}
+
+func (s SQLiteStore) LookupAPIKey(ctx context.Context, hash string) (entities.APIKey, bool, error) {
+	key, err := s.GetAPIKeyByHash(ctx, hash)
+	return lookup(key, err, ErrAPIKeyNotFound)
+}
+
+func (s SQLiteStore) GetAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	return sqlAPIKeys{q: s.db}.GetAPIKeys(ctx)
+}
+
+func (s SQLiteStore) RevokeAPIKey(ctx context.Context, id int64) error {
+	return sqlAPIKeys{q: s.db}.RevokeAPIKey(ctx, id)
+}
+
+func (s SQLiteStore) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.CreateCustomer(ctx, customer)
+}
+
+func (s SQLiteStore) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomers(ctx)
+}
+
+func (s SQLiteStore) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomerById(ctx, customerId)
+}
+
+func (s SQLiteStore) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	return sqlCustomers{q: s.db}.GetCustomerByExternalId(ctx, externalId)
+}
+
+func (s SQLiteStore) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := s.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+func (s SQLiteStore) AssignRole(ctx context.Context, subject, role string) error {
+	return sqlRoles{q: s.db}.AssignRole(ctx, subject, role)
+}
+
+func (s SQLiteStore) UnassignRole(ctx context.Context, subject, role string) error {
+	return sqlRoles{q: s.db}.UnassignRole(ctx, subject, role)
+}
+
+func (s SQLiteStore) GetRoles(ctx context.Context, subject string) ([]string, error) {
+	return sqlRoles{q: s.db}.GetRoles(ctx, subject)
+}
+
+func (s SQLiteStore) GetRoleAssignments(ctx context.Context) ([]entities.RoleAssignment, error) {
+	return sqlRoles{q: s.db}.GetRoleAssignments(ctx)
+}
+
+// AppendAuditEvent runs in its own transaction, whose write lock serializes the appends.
+func (s SQLiteStore) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	var appended entities.AuditEvent
+	err := WithTx(ctx, s.db, nil, func(q database.Querier) error {
+		var err error
+		appended, err = sqliteAccounts{q: q}.AppendAuditEvent(ctx, event)
+		return err
+	})
+	return appended, err
+}
+
+func (s SQLiteStore) GetAuditEvents(ctx context.Context, afterId int64, limit int) ([]entities.AuditEvent, error) {
+	return sqlAuditEvents{q: s.db}.GetAuditEvents(ctx, afterId, limit)
+}
+
+// RunInTx doesn't need row locks like postgres: the connection opens transactions with BEGIN IMMEDIATE,
+// which takes the database write lock for the whole unit of work.
+func (s SQLiteStore) RunInTx(ctx context.Context, fn func(tx Tx) error) error {
+	return WithTx(ctx, s.db, nil, func(q database.Querier) error {
+		return fn(sqliteAccounts{q: q})
+	})
+}
+
+// sqliteAccounts implements Tx on top of either the connection or a transaction.
+type sqliteAccounts struct {
+	q database.Querier
+}
+
+func (a sqliteAccounts) CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error) {
+	q := `
+		INSERT INTO accounts (name, balance, status, version, metadata, labels, owner_id, tier, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
+	`
+	err := a.q.QueryRowxContext(ctx, q, account.Name, account.Balance, account.Status, account.Version,
+		account.Metadata, account.Labels, account.OwnerId, account.Tier, account.CreatedAt, account.UpdatedAt).Scan(&account.Id)
+	return account, err
+}
+
+func (a sqliteAccounts) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
+	var account entities.Account
+	q := `SELECT ` + accountColumns + ` FROM accounts WHERE id = $1;`
+	if err := a.q.QueryRowxContext(ctx, q, accountId).StructScan(&account); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Account{}, ErrAccountNotFound
+		}
+		return entities.Account{}, err
+	}
+	return account, nil
+}
+
+func (a sqliteAccounts) GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error) {
+	var accounts []entities.Account
+	q := `SELECT ` + accountColumns + ` FROM accounts WHERE ($1 IS NULL OR owner_id = $1) ORDER BY id;`
+	rows, err := a.q.QueryxContext(ctx, q, filter.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var account entities.Account
+		if err := rows.StructScan(&account); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Balances are stored as REAL in sqlite, so they are rounded to cents on every update to behave
+// like the DECIMAL(15, 2) column in postgres.
+func (a sqliteAccounts) AddBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts 
+		SET balance = ROUND(balance + $1, 2), version = version + 1, updated_at = $2
+		WHERE id = $3;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, time.Now(), accountId)
+	return err
+}
+
+func (a sqliteAccounts) SubtractBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts 
+		SET balance = ROUND(balance - $1, 2), version = version + 1, updated_at = $2
+		WHERE id = $3;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, time.Now(), accountId)
+	return err
+}
+
+func (a sqliteAccounts) HoldBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts
+		SET held_balance = ROUND(held_balance + $1, 2), version = version + 1, updated_at = $2
+		WHERE id = $3;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, time.Now(), accountId)
+	return err
+}
+
+func (a sqliteAccounts) ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error {
+	q := `
+		UPDATE accounts
+		SET held_balance = ROUND(held_balance - $1, 2), version = version + 1, updated_at = $2
+		WHERE id = $3;
+	`
+	_, err := a.q.ExecContext(ctx, q, amount, time.Now(), accountId)
+	return err
+}
+
+func (a sqliteAccounts) UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error) {
+	var account entities.Account
+	q := `
+		UPDATE accounts
+		SET name = COALESCE($1, name), status = COALESCE($2, status), metadata = COALESCE($3, metadata),
+			labels = COALESCE($4, labels), tier = COALESCE($5, tier), version = version + 1, updated_at = $6
+		WHERE id = $7 AND ($8 IS NULL OR version = $8)
+		RETURNING ` + accountColumns + `;
+	`
+	err := a.q.QueryRowxContext(ctx, q, update.Name, update.Status, update.Metadata, update.Labels, update.Tier, time.Now(), accountId, ifVersion).StructScan(&account)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no row was updated, either because the account doesn't exist or because its version moved on
+		if _, err := a.GetAccountById(ctx, accountId); err != nil {
+			return entities.Account{}, err
+		}
+		return entities.Account{}, ErrVersionMismatch
+	}
+	if err != nil {
+		return entities.Account{}, err
+	}
+	return account, nil
+}
+
+func (a sqliteAccounts) AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	q := `
+		INSERT INTO account_changes (account_id, actor, field, old_value, new_value, changed_at)
+		VALUES (:account_id, :actor, :field, :old_value, :new_value, :changed_at);
+	`
+	_, err := a.q.NamedExecContext(ctx, q, changes)
+	return err
+}
+
+func (a sqliteAccounts) GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error) {
+	var changes []entities.AccountChange
+	q := `
+		SELECT id, account_id, actor, field, old_value, new_value, changed_at
+		FROM account_changes WHERE account_id = $1 ORDER BY id;
+	`
+	rows, err := a.q.QueryxContext(ctx, q, accountId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("Failed to close rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var change entities.AccountChange
+		if err := rows.StructScan(&change); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (a sqliteAccounts) CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.CreateCustomer(ctx, customer)
+}
+
+func (a sqliteAccounts) GetCustomers(ctx context.Context) ([]entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomers(ctx)
+}
+
+func (a sqliteAccounts) GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomerById(ctx, customerId)
+}
+
+func (a sqliteAccounts) GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error) {
+	return sqlCustomers{q: a.q}.GetCustomerByExternalId(ctx, externalId)
+}
+
+func (a sqliteAccounts) LookupCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, bool, error) {
+	customer, err := a.GetCustomerByExternalId(ctx, externalId)
+	return lookup(customer, err, ErrCustomerNotFound)
+}
+
+func (a sqliteAccounts) AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error) {
+	return sqlAuditEvents{q: a.q}.AppendAuditEvent(ctx, event)
+}
+
+func (a sqliteAccounts) CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error) {
+	return sqlTransfers{q: a.q}.CreateTransfer(ctx, transfer)
+}
+
+func (a sqliteAccounts) GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error) {
+	return sqlTransfers{q: a.q}.GetOutgoingTransferTotals(ctx, accountId, since)
+}
+
+func (a sqliteAccounts) GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error) {
+	return sqlTransfers{q: a.q}.GetTransferById(ctx, transferId)
+}
+
+func (a sqliteAccounts) GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error) {
+	return sqlTransfers{q: a.q}.GetTransfers(ctx, filter)
+}
+
+func (a sqliteAccounts) UpdateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	return sqlTransfers{q: a.q}.UpdateTransfer(ctx, transfer)
+}
+
+func (a sqliteAccounts) CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q}.CreateScreeningHit(ctx, hit)
+}
+
+func (a sqliteAccounts) GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q}.GetScreeningHitById(ctx, hitId)
+}
+
+func (a sqliteAccounts) GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error) {
+	return sqlScreening{q: a.q}.GetScreeningHits(ctx, filter)
+}
+
+func (a sqliteAccounts) UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error {
+	return sqlScreening{q: a.q}.UpdateScreeningHit(ctx, hit)
+}
+
+func (a sqliteAccounts) AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q}.AddOutboxEvent(ctx, event)
+}
+
+func (a sqliteAccounts) ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q}.ClaimOutboxEvents(ctx, limit, now, leasedUntil)
+}
+
+func (a sqliteAccounts) MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error {
+	return sqlOutbox{q: a.q}.MarkOutboxEventsPublished(ctx, eventIds, publishedAt)
+}
+
+func (a sqliteAccounts) DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error {
+	return sqlOutbox{q: a.q}.DeferOutboxEvent(ctx, eventId, attempts, nextAttemptAt)
+}
+
+func (a sqliteAccounts) GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error) {
+	return sqlOutbox{q: a.q}.GetOutboxEvents(ctx, filter)
+}
+
+func (a sqliteAccounts) GetLastOutboxEventId(ctx context.Context) (int64, error) {
+	return sqlOutbox{q: a.q}.GetLastOutboxEventId(ctx)
+}
+
+func (a sqliteAccounts) CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.CreateWebhook(ctx, webhook)
+}
+
+func (a sqliteAccounts) GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.GetWebhookById(ctx, webhookId)
+}
+
+func (a sqliteAccounts) GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error) {
+	return sqlWebhooks{q: a.q}.GetWebhooks(ctx, filter)
+}
+
+func (a sqliteAccounts) DeleteWebhook(ctx context.Context, webhookId int64) error {
+	return sqlWebhooks{q: a.q}.DeleteWebhook(ctx, webhookId)
+}
+
+func (a sqliteAccounts) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q}.CreateWebhookDelivery(ctx, delivery)
+}
+
+func (a sqliteAccounts) GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q}.GetWebhookDeliveryById(ctx, deliveryId)
+}
+
+func (a sqliteAccounts) GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	return sqlWebhooks{q: a.q}.GetWebhookDeliveries(ctx, filter)
+}
+
+func (a sqliteAccounts) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	return sqlWebhooks{q: a.q}.UpdateWebhookDelivery(ctx, delivery)
+}
+
+func (a sqliteAccounts) ClaimIdempotencyKey(ctx context.Context, key entities.IdempotencyKey) (entities.IdempotencyKey, bool, error) {
+	return sqlIdempotencyKeys{q: a.q}.ClaimIdempotencyKey(ctx, key)
+}
+
+func (a sqliteAccounts) SaveIdempotentResponse(ctx context.Context, key entities.IdempotencyKey) error {
+	return sqlIdempotencyKeys{q: a.q}.SaveIdempotentResponse(ctx, key)
+}
+
+func (a sqliteAccounts) CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error) {
+	return sqlTransfers{q: a.q}.CountNewTransferTargets(ctx, sourceAccountId, since)
+}
+
+func (a sqliteAccounts) HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error) {
+	return sqlTransfers{q: a.q}.HasTransferredTo(ctx, sourceAccountId, targetAccountId)
+}
+
+func (a sqliteAccounts) GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error) {
+	return sqlTransfers{q: a.q}.GetTierTransferLimits(ctx, tier)
+}
+
+func (a sqliteAccounts) SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error {
+	return sqlTransfers{q: a.q}.SetTierTransferLimits(ctx, tier, limits)
+}
+
+func (a sqliteAccounts) GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error) {
+	return sqlTransfers{q: a.q}.GetAccountTransferLimits(ctx, accountId)
+}
+
+func (a sqliteAccounts) SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error {
+	return sqlTransfers{q: a.q}.SetAccountTransferLimits(ctx, accountId, limits)
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS "accounts" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "name" VARCHAR(255) NOT NULL,
+    "balance" NUMERIC NOT NULL DEFAULT 0.00,
+    "created_at" DATETIME DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" DATETIME DEFAULT CURRENT_TIMESTAMP
+);
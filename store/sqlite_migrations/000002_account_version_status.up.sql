@@ -0,0 +1,2 @@
+ALTER TABLE "accounts" ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE "accounts" ADD COLUMN "status" VARCHAR(16) NOT NULL DEFAULT 'active';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "account_changes";
+ALTER TABLE "accounts" DROP COLUMN "labels";
+ALTER TABLE "accounts" DROP COLUMN "metadata";
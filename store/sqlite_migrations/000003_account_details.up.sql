@@ -0,0 +1,13 @@
+ALTER TABLE "accounts" ADD COLUMN "metadata" TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE "accounts" ADD COLUMN "labels" TEXT NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS "account_changes" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+    "actor" VARCHAR(255) NOT NULL,
+    "field" VARCHAR(255) NOT NULL,
+    "old_value" TEXT,
+    "new_value" TEXT,
+    "changed_at" DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "account_changes_account_id_idx" ON "account_changes" ("account_id", "id");
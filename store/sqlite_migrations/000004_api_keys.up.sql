@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(16) NOT NULL,
+    "hash" CHAR(64) NOT NULL UNIQUE,
+    "scopes" TEXT NOT NULL DEFAULT '[]',
+    "created_at" DATETIME DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" DATETIME,
+    "revoked_at" DATETIME
+);
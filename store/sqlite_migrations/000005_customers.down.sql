@@ -0,0 +1,4 @@
+ALTER TABLE "api_keys" DROP COLUMN "customer_id";
+DROP INDEX IF EXISTS "accounts_owner_id_idx";
+ALTER TABLE "accounts" DROP COLUMN "owner_id";
+DROP TABLE IF EXISTS "customers";
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "customers" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "name" VARCHAR(255) NOT NULL,
+    "external_id" VARCHAR(255) UNIQUE,
+    "created_at" DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "accounts" ADD COLUMN "owner_id" INTEGER REFERENCES "customers" ("id");
+CREATE INDEX IF NOT EXISTS "accounts_owner_id_idx" ON "accounts" ("owner_id");
+
+ALTER TABLE "api_keys" ADD COLUMN "customer_id" INTEGER REFERENCES "customers" ("id");
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" INTEGER PRIMARY KEY,
+    "actor" VARCHAR(255) NOT NULL,
+    "operation" VARCHAR(255) NOT NULL,
+    "request_id" VARCHAR(255) NOT NULL,
+    "client_ip" VARCHAR(64) NOT NULL,
+    "before" TEXT,
+    "after" TEXT,
+    "created_at" DATETIME NOT NULL,
+    "prev_hash" VARCHAR(64) NOT NULL,
+    "hash" VARCHAR(64) NOT NULL
+);
+
+CREATE TRIGGER IF NOT EXISTS "audit_events_no_update" BEFORE UPDATE ON "audit_events"
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS "audit_events_no_delete" BEFORE DELETE ON "audit_events"
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "account_transfer_limits";
+DROP TABLE IF EXISTS "tier_transfer_limits";
+DROP INDEX IF EXISTS "transfers_source_account_id_idx";
+DROP TABLE IF EXISTS "transfers";
+ALTER TABLE "accounts" DROP COLUMN "tier";
@@ -0,0 +1,28 @@
+ALTER TABLE "accounts" ADD COLUMN "tier" VARCHAR(64) NOT NULL DEFAULT 'standard';
+
+CREATE TABLE IF NOT EXISTS "transfers" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "source_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+    "target_account_id" INTEGER NOT NULL REFERENCES "accounts" ("id"),
+    "amount" NUMERIC NOT NULL,
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "transfers_source_account_id_idx" ON "transfers" ("source_account_id", "created_at");
+
+CREATE TABLE IF NOT EXISTS "tier_transfer_limits" (
+    "tier" VARCHAR(64) PRIMARY KEY,
+    "max_amount" NUMERIC,
+    "daily_amount" NUMERIC,
+    "weekly_amount" NUMERIC,
+    "monthly_amount" NUMERIC,
+    "daily_count" INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS "account_transfer_limits" (
+    "account_id" INTEGER PRIMARY KEY REFERENCES "accounts" ("id"),
+    "max_amount" NUMERIC,
+    "daily_amount" NUMERIC,
+    "weekly_amount" NUMERIC,
+    "monthly_amount" NUMERIC,
+    "daily_count" INTEGER
+);
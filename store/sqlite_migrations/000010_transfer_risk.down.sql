@@ -0,0 +1,3 @@
+ALTER TABLE "transfers" DROP COLUMN "risk_evaluations";
+ALTER TABLE "transfers" DROP COLUMN "risk_decision";
+ALTER TABLE "transfers" DROP COLUMN "status";
@@ -0,0 +1,3 @@
+ALTER TABLE "transfers" ADD COLUMN "status" VARCHAR(16) NOT NULL DEFAULT 'completed';
+ALTER TABLE "transfers" ADD COLUMN "risk_decision" VARCHAR(16) NOT NULL DEFAULT 'allow';
+ALTER TABLE "transfers" ADD COLUMN "risk_evaluations" TEXT NOT NULL DEFAULT '[]';
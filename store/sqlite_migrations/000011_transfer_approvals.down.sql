@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "transfers_pending_approval_idx";
+ALTER TABLE "transfers" DROP COLUMN "expires_at";
+ALTER TABLE "transfers" DROP COLUMN "decided_by";
+ALTER TABLE "transfers" DROP COLUMN "requested_by";
+ALTER TABLE "accounts" DROP COLUMN "held_balance";
@@ -0,0 +1,6 @@
+ALTER TABLE "accounts" ADD COLUMN "held_balance" NUMERIC NOT NULL DEFAULT 0.00;
+
+ALTER TABLE "transfers" ADD COLUMN "requested_by" VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE "transfers" ADD COLUMN "decided_by" VARCHAR(255);
+ALTER TABLE "transfers" ADD COLUMN "expires_at" DATETIME;
+CREATE INDEX IF NOT EXISTS "transfers_pending_approval_idx" ON "transfers" ("expires_at") WHERE "status" = 'pending_approval';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "screening_hits" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "subject_type" VARCHAR(16) NOT NULL,
+    "subject_id" INTEGER,
+    "operation" VARCHAR(64) NOT NULL,
+    "screened_name" VARCHAR(255) NOT NULL,
+    "entry_uid" VARCHAR(64) NOT NULL,
+    "entry_name" VARCHAR(255) NOT NULL,
+    "matched_name" VARCHAR(255) NOT NULL,
+    "score" REAL NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "decided_by" VARCHAR(255),
+    "decided_at" DATETIME,
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "screening_hits_status_idx" ON "screening_hits" ("status");
+CREATE INDEX IF NOT EXISTS "screening_hits_subject_idx" ON "screening_hits" ("subject_type", "subject_id");
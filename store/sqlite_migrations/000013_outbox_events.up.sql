@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "type" VARCHAR(64) NOT NULL,
+    "account_id" INTEGER NOT NULL,
+    "payload" TEXT NOT NULL,
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "published_at" DATETIME
+);
+CREATE INDEX IF NOT EXISTS "outbox_events_unpublished_idx" ON "outbox_events" ("id") WHERE "published_at" IS NULL;
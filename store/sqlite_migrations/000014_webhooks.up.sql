@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "url" VARCHAR(2048) NOT NULL,
+    "event_types" TEXT NOT NULL DEFAULT '[]',
+    "account_ids" TEXT NOT NULL DEFAULT '[]',
+    "secret" VARCHAR(255) NOT NULL,
+    "owner_id" INTEGER REFERENCES "customers" ("id"),
+    "created_by" VARCHAR(255) NOT NULL,
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "webhooks_owner_id_idx" ON "webhooks" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "webhook_id" INTEGER NOT NULL REFERENCES "webhooks" ("id") ON DELETE CASCADE,
+    "event_id" INTEGER NOT NULL REFERENCES "outbox_events" ("id"),
+    "event_type" VARCHAR(64) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" DATETIME,
+    "last_attempt_at" DATETIME,
+    "last_response_status" INTEGER,
+    "last_error" TEXT,
+    "delivered_at" DATETIME,
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_webhook_id_idx" ON "webhook_deliveries" ("webhook_id");
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_due_idx" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
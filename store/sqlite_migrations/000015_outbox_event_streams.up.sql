@@ -0,0 +1,6 @@
+ALTER TABLE "outbox_events" ADD COLUMN "related_account_id" INTEGER;
+UPDATE "outbox_events" SET "related_account_id" = json_extract("payload", '$.target_account_id')
+WHERE "type" = 'TransferCompleted' AND "related_account_id" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "outbox_events_account_id_idx" ON "outbox_events" ("account_id", "id");
+CREATE INDEX IF NOT EXISTS "outbox_events_related_account_id_idx" ON "outbox_events" ("related_account_id", "id");
@@ -0,0 +1,4 @@
+ALTER TABLE "outbox_events" ADD COLUMN "attempts" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "outbox_events" ADD COLUMN "next_attempt_at" DATETIME;
+
+CREATE INDEX IF NOT EXISTS "outbox_events_unpublished_account_id_idx" ON "outbox_events" ("account_id", "id") WHERE "published_at" IS NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "idempotency_keys" (
+    "subject" VARCHAR(255) NOT NULL,
+    "key" VARCHAR(255) NOT NULL,
+    "operation" VARCHAR(64) NOT NULL,
+    "request_hash" VARCHAR(64) NOT NULL,
+    "response_status" INTEGER NOT NULL DEFAULT 0,
+    "response_content_type" VARCHAR(255) NOT NULL DEFAULT '',
+    "response_body" TEXT NOT NULL DEFAULT '',
+    "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("subject", "key")
+);
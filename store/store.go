@@ -2,109 +2,290 @@ package store
 
 import (
 	"context"
-	"log/slog"
-	"tiny-bank-api/pkg/database"
+	"errors"
+	"time"
 	"tiny-bank-api/store/entities"
+)
 
-	"github.com/jmoiron/sqlx"
+var (
+	// ErrAccountNotFound is returned by every Store implementation when the requested account doesn't exist.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrVersionMismatch is returned when an update expected a version of the account that isn't the current one.
+	ErrVersionMismatch = errors.New("account version mismatch")
+	// ErrAPIKeyNotFound is returned when the requested API key doesn't exist.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrCustomerNotFound is returned when the requested customer doesn't exist.
+	ErrCustomerNotFound = errors.New("customer not found")
+	// ErrRoleAssignmentNotFound is returned when unassigning a role the subject doesn't have.
+	ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+	// ErrTransferNotFound is returned when the requested transfer doesn't exist.
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrScreeningHitNotFound is returned when the requested screening hit doesn't exist.
+	ErrScreeningHitNotFound = errors.New("screening hit not found")
+	// ErrWebhookNotFound is returned when the requested webhook doesn't exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when the requested webhook delivery doesn't exist.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
 )
 
-type Store struct {
-	db database.SQLDB
+const accountColumns = `id, name, balance, held_balance, status, version, metadata, labels, owner_id, tier, created_at, updated_at`
+
+// AccountFilter restricts the accounts returned by GetAccounts, the zero value matches every account.
+type AccountFilter struct {
+	OwnerId *int64
 }
 
-func NewStore(db database.SQLDB) Store {
-	return Store{
-		db: db,
-	}
+// TransferFilter restricts the transfers returned by GetTransfers, the zero value matches every transfer.
+type TransferFilter struct {
+	SourceAccountId *int64
+	Status          *entities.TransferStatus
+	// ExpiresBefore matches the transfers expiring before the given time.
+	ExpiresBefore *time.Time
 }
 
-func (s Store) CreateAccount(ctx context.Context, name string, balance float64) error {
-	account := entities.NewAccount(name, balance)
-	q := `
-		INSERT INTO accounts (name, balance, created_at, updated_at)
-		VALUES (:name, :balance, :created_at, :updated_at);
-	`
-	_, err := s.db.NamedExecContext(ctx, q, account)
-	return err
+// ScreeningHitFilter restricts the hits returned by GetScreeningHits, the zero value matches every hit.
+type ScreeningHitFilter struct {
+	Status      *entities.ScreeningHitStatus
+	SubjectType *entities.ScreeningSubject
+	SubjectId   *int64
 }
 
-func (s Store) GetAccountById(ctx context.Context, accountId int64) (entities.Account, error) {
-	var account entities.Account
-	q := `SELECT id, name, balance, created_at, updated_at FROM accounts WHERE id = $1;`
-	if err := s.db.QueryRowxContext(ctx, q, accountId).StructScan(&account); err != nil {
-		return entities.Account{}, err
-	}
-	return account, nil
+// OutboxEventFilter restricts the events returned by GetOutboxEvents, the zero value matches every event.
+type OutboxEventFilter struct {
+	// AccountId matches the events about the account, including the transfers it received.
+	AccountId *int64
+	// AfterId matches the events newer than the given one.
+	AfterId int64
+	// Ids matches the events with these ids, when not empty.
+	Ids []int64
+	// Limit caps the number of events returned, 0 returns all of them.
+	Limit int
 }
 
-func (s Store) GetAccounts(ctx context.Context) ([]entities.Account, error) {
-	var accounts []entities.Account
-	q := `SELECT id, name, balance, created_at, updated_at FROM accounts;`
-	rows, err := s.db.QueryxContext(ctx, q)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err = rows.Close(); err != nil {
-			slog.Warn("Failed to close rows", "error", err)
-		}
-	}()
-
-	for rows.Next() {
-		var account entities.Account
-		if err := rows.StructScan(&account); err != nil {
-			return nil, err
-		}
-		accounts = append(accounts, account)
-	}
+// WebhookFilter restricts the webhooks returned by GetWebhooks, the zero value matches every webhook.
+type WebhookFilter struct {
+	OwnerId *int64
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
+// WebhookDeliveryFilter restricts the deliveries returned by GetWebhookDeliveries, the zero value matches
+// every delivery.
+type WebhookDeliveryFilter struct {
+	WebhookId *int64
+	Status    *entities.WebhookDeliveryStatus
+	// DueBefore matches the deliveries whose next attempt is due before the given time.
+	DueBefore *time.Time
+	// Limit caps the number of deliveries returned, 0 returns all of them.
+	Limit int
+}
+
+// AccountUpdate lists the fields of an account to change, nil fields are left untouched.
+type AccountUpdate struct {
+	Name     *string
+	Status   *entities.AccountStatus
+	Metadata entities.StringMap
+	Labels   entities.StringMap
+	Tier     *string
+}
+
+// Store is the persistence layer used by the API. The operations of a unit of work are also available
+// outside of one, each running on its own.
+type Store interface {
+	Accounts
+	Customers
+	Transfers
+	Screening
+	Outbox
+	Webhooks
+	Limits
+	AuditTrail
+	APIKeys
+	Roles
+	AuditLog
+	Tiers
+
+	// RunInTx runs fn as a single unit of work. All the changes made through tx are committed when fn
+	// returns nil and discarded otherwise.
+	RunInTx(ctx context.Context, fn func(tx Tx) error) error
+}
+
+// Tx is a unit of work, the operations it is composed of take part in the same transaction.
+type Tx interface {
+	Accounts
+	Customers
+	Transfers
+	Screening
+	Outbox
+	Webhooks
+	Limits
+	AuditTrail
+	IdempotencyKeys
+}
 
-	return accounts, nil
+// Accounts are the operations on the accounts and their balances.
+type Accounts interface {
+	// CreateAccount stores a new account and returns it with its id.
+	CreateAccount(ctx context.Context, account entities.Account) (entities.Account, error)
+	GetAccountById(ctx context.Context, accountId int64) (entities.Account, error)
+	GetAccounts(ctx context.Context, filter AccountFilter) ([]entities.Account, error)
+	AddBalance(ctx context.Context, accountId int64, amount float64) error
+	SubtractBalance(ctx context.Context, accountId int64, amount float64) error
+	// HoldBalance sets amount of the balance aside, until ReleaseHeldBalance is called with it.
+	HoldBalance(ctx context.Context, accountId int64, amount float64) error
+	ReleaseHeldBalance(ctx context.Context, accountId int64, amount float64) error
+	// UpdateAccount applies update and bumps the version of the account. When ifVersion is set the update
+	// only happens if the account is still at that version, ErrVersionMismatch is returned otherwise.
+	UpdateAccount(ctx context.Context, accountId int64, ifVersion *int64, update AccountUpdate) (entities.Account, error)
+	AddAccountChanges(ctx context.Context, changes []entities.AccountChange) error
+	// GetAccountChanges returns the changes made to an account, oldest first.
+	GetAccountChanges(ctx context.Context, accountId int64) ([]entities.AccountChange, error)
 }
 
-func (s Store) AddBalance(ctx context.Context, accountId int64, amount float64) error {
-	q := `
-		UPDATE accounts 
-		SET balance = balance + $1, updated_at = NOW()
-		WHERE id = $2;
-	`
-	_, err := s.db.ExecContext(ctx, q, amount, accountId)
-	return err
+// Transfers are the operations on the transfers between accounts.
+type Transfers interface {
+	// CreateTransfer records a transfer, so it counts towards the transfer limits of its source account.
+	CreateTransfer(ctx context.Context, transfer entities.Transfer) (entities.Transfer, error)
+	// GetOutgoingTransferTotals sums up the completed transfers made from the account since the given time.
+	GetOutgoingTransferTotals(ctx context.Context, accountId int64, since time.Time) (entities.TransferTotals, error)
+	// CountNewTransferTargets counts the accounts the source account made its first completed transfer to
+	// since the given time.
+	CountNewTransferTargets(ctx context.Context, sourceAccountId int64, since time.Time) (int64, error)
+	// HasTransferredTo reports whether the source account ever completed a transfer to the target account.
+	HasTransferredTo(ctx context.Context, sourceAccountId, targetAccountId int64) (bool, error)
+	// GetTransferById locks the transfer until the end of the unit of work.
+	GetTransferById(ctx context.Context, transferId int64) (entities.Transfer, error)
+	// GetTransfers returns the transfers matching the filter, oldest first.
+	GetTransfers(ctx context.Context, filter TransferFilter) ([]entities.Transfer, error)
+	// UpdateTransfer saves the status, risk decision, decider and expiry of the transfer.
+	UpdateTransfer(ctx context.Context, transfer entities.Transfer) error
 }
 
-func (s Store) AddBalanceWithTx(ctx context.Context, tx database.Querier, accountId int64, amount float64) error {
-	q := `
-		UPDATE accounts 
-		SET balance = balance + $1, updated_at = NOW()
-		WHERE id = $2;
-	`
-	_, err := tx.ExecContext(ctx, q, amount, accountId)
-	return err
+// Screening are the operations on the sanctions screening hits.
+type Screening interface {
+	CreateScreeningHit(ctx context.Context, hit entities.ScreeningHit) (entities.ScreeningHit, error)
+	// GetScreeningHitById locks the hit until the end of the unit of work.
+	GetScreeningHitById(ctx context.Context, hitId int64) (entities.ScreeningHit, error)
+	// GetScreeningHits returns the hits matching the filter, oldest first.
+	GetScreeningHits(ctx context.Context, filter ScreeningHitFilter) ([]entities.ScreeningHit, error)
+	// UpdateScreeningHit saves the status and decision of the hit.
+	UpdateScreeningHit(ctx context.Context, hit entities.ScreeningHit) error
 }
 
-func (s Store) SubtractBalanceWithTx(ctx context.Context, tx database.Querier, accountId int64, amount float64) error {
-	q := `
-		UPDATE accounts 
-		SET balance = balance - $1, updated_at = NOW()
-		WHERE id = $2;
-	`
-	_, err := tx.ExecContext(ctx, q, amount, accountId)
-	return err
+// Outbox are the operations on the domain events waiting to be published.
+type Outbox interface {
+	// AddOutboxEvent stores a domain event to publish, in the unit of work making the change it notifies.
+	AddOutboxEvent(ctx context.Context, event entities.OutboxEvent) (entities.OutboxEvent, error)
+	// ClaimOutboxEvents returns up to limit unpublished events, oldest first, of the accounts whose oldest
+	// unpublished event is due at now. The oldest events of these accounts are leased until leasedUntil, so
+	// the concurrent relays skip the accounts until they are published, keeping the events of each account in
+	// order.
+	ClaimOutboxEvents(ctx context.Context, limit int, now, leasedUntil time.Time) ([]entities.OutboxEvent, error)
+	MarkOutboxEventsPublished(ctx context.Context, eventIds []int64, publishedAt time.Time) error
+	// DeferOutboxEvent records a failed attempt to publish the event, which holds back the events of its
+	// account until nextAttemptAt.
+	DeferOutboxEvent(ctx context.Context, eventId int64, attempts int, nextAttemptAt time.Time) error
+	// GetOutboxEvents returns the events matching the filter, oldest first, whether published or not.
+	GetOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]entities.OutboxEvent, error)
+	// GetLastOutboxEventId returns the id of the latest event, 0 when there is none.
+	GetLastOutboxEventId(ctx context.Context) (int64, error)
 }
 
-func (s Store) GetAccountByIdWithTx(ctx context.Context, tx database.Querier, accountId int64) (entities.Account, error) {
-	var account entities.Account
-	q := `SELECT id, name, balance, created_at, updated_at FROM accounts WHERE id = $1;`
-	if err := tx.QueryRowxContext(ctx, q, accountId).StructScan(&account); err != nil {
-		return entities.Account{}, err
+// Webhooks are the operations on the webhooks and their deliveries.
+type Webhooks interface {
+	CreateWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error)
+	GetWebhookById(ctx context.Context, webhookId int64) (entities.Webhook, error)
+	// GetWebhooks returns the webhooks matching the filter, oldest first.
+	GetWebhooks(ctx context.Context, filter WebhookFilter) ([]entities.Webhook, error)
+	// DeleteWebhook deletes the webhook along with its deliveries.
+	DeleteWebhook(ctx context.Context, webhookId int64) error
+	CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (entities.WebhookDelivery, error)
+	// GetWebhookDeliveryById locks the delivery until the end of the unit of work.
+	GetWebhookDeliveryById(ctx context.Context, deliveryId int64) (entities.WebhookDelivery, error)
+	// GetWebhookDeliveries returns the deliveries matching the filter, oldest first.
+	GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error)
+	// UpdateWebhookDelivery saves the status and the attempts of the delivery.
+	UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error
+}
+
+// Limits are the operations on the transfer limits of the tiers and of the accounts.
+type Limits interface {
+	// GetTierTransferLimits returns the limits of the accounts of a tier, the zero value when it has none.
+	GetTierTransferLimits(ctx context.Context, tier string) (entities.TransferLimits, error)
+	SetTierTransferLimits(ctx context.Context, tier string, limits entities.TransferLimits) error
+	// GetAccountTransferLimits returns the limits of an account overriding the ones of its tier, the zero
+	// value when it has none.
+	GetAccountTransferLimits(ctx context.Context, accountId int64) (entities.TransferLimits, error)
+	SetAccountTransferLimits(ctx context.Context, accountId int64, limits entities.TransferLimits) error
+}
+
+// AuditTrail appends to the audit log, in the unit of work making the change the event records.
+type AuditTrail interface {
+	// AppendAuditEvent chains the event to the audit log and returns it with its id and hashes.
+	AppendAuditEvent(ctx context.Context, event entities.AuditEvent) (entities.AuditEvent, error)
+}
+
+// IdempotencyKeys keep the responses of the requests sent with an Idempotency-Key. They are only available in
+// a unit of work, which saves the response along with the changes of the request.
+type IdempotencyKeys interface {
+	// ClaimIdempotencyKey stores the key unless its subject already used it, and returns the stored key along
+	// with whether this call stored it. A key claimed by a concurrent unit of work is only returned once that
+	// unit of work ends, with its response when it was committed.
+	ClaimIdempotencyKey(ctx context.Context, key entities.IdempotencyKey) (entities.IdempotencyKey, bool, error)
+	// SaveIdempotentResponse saves the response of a key claimed in the same unit of work.
+	SaveIdempotentResponse(ctx context.Context, key entities.IdempotencyKey) error
+}
+
+// APIKeys are the operations on API keys.
+type APIKeys interface {
+	CreateAPIKey(ctx context.Context, key entities.APIKey) (int64, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error)
+	// LookupAPIKey is GetAPIKeyByHash reporting a missing key with found, it satisfies auth.APIKeyLookup.
+	LookupAPIKey(ctx context.Context, hash string) (key entities.APIKey, found bool, err error)
+	GetAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+	// RevokeAPIKey marks the key as revoked, revoking an already revoked key is a no-op.
+	RevokeAPIKey(ctx context.Context, id int64) error
+}
+
+// Customers are the operations on the customers owning accounts.
+type Customers interface {
+	CreateCustomer(ctx context.Context, customer entities.Customer) (entities.Customer, error)
+	GetCustomers(ctx context.Context) ([]entities.Customer, error)
+	GetCustomerById(ctx context.Context, customerId int64) (entities.Customer, error)
+	// GetCustomerByExternalId finds the customer an identity provider subject belongs to.
+	GetCustomerByExternalId(ctx context.Context, externalId string) (entities.Customer, error)
+	// LookupCustomerByExternalId is GetCustomerByExternalId reporting a missing customer with found, it
+	// satisfies auth.CustomerLookup.
+	LookupCustomerByExternalId(ctx context.Context, externalId string) (customer entities.Customer, found bool, err error)
+}
+
+// lookup turns the not found error of a query into found being false.
+func lookup[T any](value T, err, notFound error) (T, bool, error) {
+	switch {
+	case err == nil:
+		return value, true, nil
+	case errors.Is(err, notFound):
+		return value, false, nil
+	default:
+		return value, false, err
 	}
-	return account, nil
 }
 
-func (s Store) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
-	return s.db.BeginTxx(ctx, nil)
+// Roles are the back-office role assignments of the principals, identified by their subject.
+type Roles interface {
+	// AssignRole is a no-op when the subject already has the role.
+	AssignRole(ctx context.Context, subject, role string) error
+	UnassignRole(ctx context.Context, subject, role string) error
+	GetRoles(ctx context.Context, subject string) ([]string, error)
+	GetRoleAssignments(ctx context.Context) ([]entities.RoleAssignment, error)
+}
+
+// Tiers are the account tiers having transfer limits, the limits are read and changed through Limits.
+type Tiers interface {
+	GetTiers(ctx context.Context) ([]entities.TierTransferLimits, error)
+}
+
+// AuditLog reads the append-only audit log, events are appended with AuditTrail.AppendAuditEvent so they
+// are part of the unit of work making the change.
+type AuditLog interface {
+	// GetAuditEvents returns up to limit events following afterId, in the order of the chain.
+	GetAuditEvents(ctx context.Context, afterId int64, limit int) ([]entities.AuditEvent, error)
 }
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+	"tiny-bank-api/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	txMaxAttempts    = 5
+	txBaseRetryDelay = 10 * time.Millisecond
+)
+
+// ErrTxConflict is returned by WithTx when the transaction still conflicts with concurrent ones after its
+// last attempt, nothing was committed and the operation can be retried later.
+var ErrTxConflict = errors.New("transaction conflicts with concurrent ones")
+
+// WithTx runs fn inside a transaction opened with opts, and owns its lifecycle: the transaction is
+// committed when fn returns nil and rolled back otherwise. Transactions failing because of a postgres
+// serialization failure or deadlock are retried with exponential backoff, so fn must be safe to run
+// more than once.
+func WithTx(ctx context.Context, db database.SQLDB, opts *sql.TxOptions, fn func(q database.Querier) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = runTx(ctx, db, opts, fn)
+		if err == nil || !isRetryableTxError(err) {
+			return err
+		}
+		if attempt == txMaxAttempts {
+			return errors.Join(ErrTxConflict, err)
+		}
+
+		// full jitter so the conflicting transactions don't retry in lockstep
+		delay := rand.N(txBaseRetryDelay << (attempt - 1))
+		slog.Debug("Retrying transaction", "attempt", attempt, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+func runTx(ctx context.Context, db database.SQLDB, opts *sql.TxOptions, fn func(q database.Querier) error) error {
+	tx, err := db.BeginTxx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	// the wrappers of the db, like the metrics, see the queries of the transaction too
+	var q database.Querier = tx
+	if w, ok := db.(database.TxWrapper); ok {
+		q = w.WrapTx(tx)
+	}
+
+	if err := fn(q); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			slog.Warn("Failed to rollback the transaction", "error", rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isRetryableTxError reports whether err is a serialization_failure (40001) or deadlock_detected
+// (40P01), both of which mean the transaction can succeed if it runs again.
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}